@@ -164,6 +164,20 @@ const (
 	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
 )
 
+const (
+	// BootstrapDataValidCondition reports whether the bootstrap data of an AWSMachine is valid, and fits
+	// the delivery path which is used for it. When true, the reason is the delivery path.
+	BootstrapDataValidCondition clusterv1.ConditionType = "BootstrapDataValid"
+
+	// BootstrapDataInvalidReason used when the bootstrap data can't be parsed.
+	BootstrapDataInvalidReason = "BootstrapDataInvalid"
+	// BootstrapDataTooLargeReason used when the bootstrap data exceeds the size limit of the delivery paths which can be used.
+	BootstrapDataTooLargeReason = "BootstrapDataTooLarge"
+	// BootstrapDataDeliveryUnavailableReason used when the delivery path of the bootstrap data isn't configured,
+	// for example when an Ignition config should be stored in a cluster object store which doesn't exist.
+	BootstrapDataDeliveryUnavailableReason = "BootstrapDataDeliveryUnavailable"
+)
+
 const (
 	// SecurityGroupsReadyCondition indicates the security groups are up to date on the AWSMachine.
 	SecurityGroupsReadyCondition clusterv1.ConditionType = "SecurityGroupsReady"
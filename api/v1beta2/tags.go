@@ -195,6 +195,18 @@ const (
 	// of the bootstrap secret that was used to create the user data for the latest launch
 	// template version.
 	LaunchTemplateBootstrapDataSecret = NameAWSProviderPrefix + "bootstrap-data-secret"
+
+	// FargateProfileOwnerTag is the tag we use to store the `<namespace>/<name>` of the
+	// AWSFargateProfile that created an EKS fargate profile.
+	FargateProfileOwnerTag = NameAWSProviderPrefix + "fargate-profile"
+
+	// FargateProfileActiveTag is the tag we use to mark the EKS fargate profile currently in use
+	// by an AWSFargateProfile, so it can be found again if the status is lost.
+	FargateProfileActiveTag = NameAWSProviderPrefix + "fargate-profile-active"
+
+	// RosaRoleConfigOwnerTag is the tag we use to store the `<namespace>/<name>` of the
+	// ROSARoleConfig that created an IAM role.
+	RosaRoleConfigOwnerTag = NameAWSProviderPrefix + "rosa-role-config"
 )
 
 // ClusterTagKey generates the key for resources associated with a cluster.
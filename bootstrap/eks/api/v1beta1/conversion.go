@@ -61,6 +61,26 @@ func (r *EKSConfig) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.NTP != nil {
 		dst.Spec.NTP = restored.Spec.NTP
 	}
+	dst.Spec.Format = restored.Spec.Format
+	dst.Spec.TemplateVariables = restored.Spec.TemplateVariables
+	if restored.Spec.IPFamily != nil {
+		dst.Spec.IPFamily = restored.Spec.IPFamily
+	}
+	if restored.Spec.Containerd != nil {
+		dst.Spec.Containerd = restored.Spec.Containerd
+	}
+	if restored.Spec.Bottlerocket != nil {
+		dst.Spec.Bottlerocket = restored.Spec.Bottlerocket
+	}
+	if restored.Spec.KubeletConfiguration != nil {
+		dst.Spec.KubeletConfiguration = restored.Spec.KubeletConfiguration
+	}
+	if restored.Status.RegistryCredentials != nil {
+		dst.Status.RegistryCredentials = restored.Status.RegistryCredentials
+	}
+	if restored.Status.ReplacedRegistryCredentials != nil {
+		dst.Status.ReplacedRegistryCredentials = restored.Status.ReplacedRegistryCredentials
+	}
 
 	return nil
 }
@@ -128,6 +148,20 @@ func (r *EKSConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.NTP != nil {
 		dst.Spec.Template.Spec.NTP = restored.Spec.Template.Spec.NTP
 	}
+	dst.Spec.Template.Spec.Format = restored.Spec.Template.Spec.Format
+	dst.Spec.Template.Spec.TemplateVariables = restored.Spec.Template.Spec.TemplateVariables
+	if restored.Spec.Template.Spec.IPFamily != nil {
+		dst.Spec.Template.Spec.IPFamily = restored.Spec.Template.Spec.IPFamily
+	}
+	if restored.Spec.Template.Spec.Containerd != nil {
+		dst.Spec.Template.Spec.Containerd = restored.Spec.Template.Spec.Containerd
+	}
+	if restored.Spec.Template.Spec.Bottlerocket != nil {
+		dst.Spec.Template.Spec.Bottlerocket = restored.Spec.Template.Spec.Bottlerocket
+	}
+	if restored.Spec.Template.Spec.KubeletConfiguration != nil {
+		dst.Spec.Template.Spec.KubeletConfiguration = restored.Spec.Template.Spec.KubeletConfiguration
+	}
 
 	return nil
 }
@@ -161,3 +195,8 @@ func (r *EKSConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 func Convert_v1beta2_EKSConfigSpec_To_v1beta1_EKSConfigSpec(in *v1beta2.EKSConfigSpec, out *EKSConfigSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta2_EKSConfigSpec_To_v1beta1_EKSConfigSpec(in, out, s)
 }
+
+// Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus converts a v1beta2 EKSConfigStatus to a v1beta1 EKSConfigStatus.
+func Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(in *v1beta2.EKSConfigStatus, out *EKSConfigStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(in, out, s)
+}
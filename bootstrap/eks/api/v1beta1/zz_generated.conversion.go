@@ -67,11 +67,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.EKSConfigStatus)(nil), (*EKSConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(a.(*v1beta2.EKSConfigStatus), b.(*EKSConfigStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*EKSConfigTemplate)(nil), (*v1beta2.EKSConfigTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_EKSConfigTemplate_To_v1beta2_EKSConfigTemplate(a.(*EKSConfigTemplate), b.(*v1beta2.EKSConfigTemplate), scope)
 	}); err != nil {
@@ -127,6 +122,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.EKSConfigStatus)(nil), (*EKSConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(a.(*v1beta2.EKSConfigStatus), b.(*EKSConfigStatus), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -222,14 +222,18 @@ func Convert_v1beta1_EKSConfigSpec_To_v1beta2_EKSConfigSpec(in *EKSConfigSpec, o
 }
 
 func autoConvert_v1beta2_EKSConfigSpec_To_v1beta1_EKSConfigSpec(in *v1beta2.EKSConfigSpec, out *EKSConfigSpec, s conversion.Scope) error {
+	// WARNING: in.Format requires manual conversion: does not exist in peer-type
 	out.KubeletExtraArgs = *(*map[string]string)(unsafe.Pointer(&in.KubeletExtraArgs))
+	// WARNING: in.KubeletConfiguration requires manual conversion: does not exist in peer-type
 	out.ContainerRuntime = (*string)(unsafe.Pointer(in.ContainerRuntime))
 	out.DNSClusterIP = (*string)(unsafe.Pointer(in.DNSClusterIP))
 	out.DockerConfigJSON = (*string)(unsafe.Pointer(in.DockerConfigJSON))
 	out.APIRetryAttempts = (*int)(unsafe.Pointer(in.APIRetryAttempts))
 	out.PauseContainer = (*PauseContainer)(unsafe.Pointer(in.PauseContainer))
 	out.UseMaxPods = (*bool)(unsafe.Pointer(in.UseMaxPods))
+	// WARNING: in.IPFamily requires manual conversion: does not exist in peer-type
 	out.ServiceIPV6Cidr = (*string)(unsafe.Pointer(in.ServiceIPV6Cidr))
+	// WARNING: in.TemplateVariables requires manual conversion: does not exist in peer-type
 	// WARNING: in.PreBootstrapCommands requires manual conversion: does not exist in peer-type
 	// WARNING: in.PostBootstrapCommands requires manual conversion: does not exist in peer-type
 	// WARNING: in.BootstrapCommandOverride requires manual conversion: does not exist in peer-type
@@ -238,6 +242,8 @@ func autoConvert_v1beta2_EKSConfigSpec_To_v1beta1_EKSConfigSpec(in *v1beta2.EKSC
 	// WARNING: in.Mounts requires manual conversion: does not exist in peer-type
 	// WARNING: in.Users requires manual conversion: does not exist in peer-type
 	// WARNING: in.NTP requires manual conversion: does not exist in peer-type
+	// WARNING: in.Containerd requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bottlerocket requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -262,15 +268,12 @@ func autoConvert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(in *v1beta2.
 	out.FailureReason = in.FailureReason
 	out.FailureMessage = in.FailureMessage
 	out.ObservedGeneration = in.ObservedGeneration
+	// WARNING: in.RegistryCredentials requires manual conversion: does not exist in peer-type
+	// WARNING: in.ReplacedRegistryCredentials requires manual conversion: does not exist in peer-type
 	out.Conditions = *(*apiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
-// Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus is an autogenerated conversion function.
-func Convert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(in *v1beta2.EKSConfigStatus, out *EKSConfigStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_EKSConfigStatus_To_v1beta1_EKSConfigStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_EKSConfigTemplate_To_v1beta2_EKSConfigTemplate(in *EKSConfigTemplate, out *v1beta2.EKSConfigTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_EKSConfigTemplateSpec_To_v1beta2_EKSConfigTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
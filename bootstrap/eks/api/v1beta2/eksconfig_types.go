@@ -17,16 +17,35 @@ limitations under the License.
 package v1beta2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// EKSConfigFinalizer allows the controller to clean up resources on delete.
+const EKSConfigFinalizer = "eksconfig.bootstrap.cluster.x-k8s.io"
+
 // EKSConfigSpec defines the desired state of Amazon EKS Bootstrap Configuration.
 type EKSConfigSpec struct {
+	// Format specifies the output format of the bootstrap data. The cloud-config format runs
+	// /etc/eks/bootstrap.sh and is used by Amazon Linux 2 based AMIs. The nodeadm format
+	// produces a multipart MIME document containing a NodeConfig and is used by Amazon Linux
+	// 2023 based AMIs. The bottlerocket format produces TOML settings for Bottlerocket AMIs.
+	// The ignition format produces an Ignition v3 config with a systemd unit which runs the
+	// EKS bootstrap script, and is used by Flatcar Container Linux AMIs.
+	// Defaults to cloud-config.
+	// +kubebuilder:validation:Enum=cloud-config;nodeadm;bottlerocket;ignition
+	// +optional
+	Format Format `json:"format,omitempty"`
 	// KubeletExtraArgs passes the specified kubelet args into the Amazon EKS machine bootstrap script
 	// +optional
 	KubeletExtraArgs map[string]string `json:"kubeletExtraArgs,omitempty"`
+	// KubeletConfiguration specifies kubelet settings which are merged into the kubelet
+	// configuration file of the node. Settings must not also be passed as kubeletExtraArgs.
+	// +optional
+	KubeletConfiguration *KubeletConfiguration `json:"kubeletConfiguration,omitempty"`
 	// ContainerRuntime specify the container runtime to use when bootstrapping EKS.
 	// +optional
 	ContainerRuntime *string `json:"containerRuntime,omitempty"`
@@ -46,10 +65,21 @@ type EKSConfigSpec struct {
 	// UseMaxPods  sets --max-pods for the kubelet when true.
 	// +optional
 	UseMaxPods *bool `json:"useMaxPods,omitempty"`
+	// IPFamily is the ip family of the node. If not specified it's ipv6 when serviceIPV6Cidr
+	// is specified or IPv6 is enabled for the VPC of the control plane, and ipv4 otherwise.
+	// +kubebuilder:validation:Enum=ipv4;ipv6
+	// +optional
+	IPFamily *string `json:"ipFamily,omitempty"`
 	// ServiceIPV6Cidr is the ipv6 cidr range of the cluster. If this is specified then
-	// the ip family will be set to ipv6.
+	// the ip family will be set to ipv6. If not specified for an ipv6 node, the service
+	// ipv6 cidr of the EKS cluster is used.
 	// +optional
 	ServiceIPV6Cidr *string `json:"serviceIPV6Cidr,omitempty"`
+	// TemplateVariables enables per-machine Go template variables, such as {{ .InstanceID }}, in
+	// the files, pre-bootstrap commands and kubelet extra args. When disabled, which is the
+	// default, they are used as is.
+	// +optional
+	TemplateVariables bool `json:"templateVariables,omitempty"`
 	// PreBootstrapCommands specifies extra commands to run before bootstrapping nodes to the cluster
 	// +optional
 	PreBootstrapCommands []string `json:"preBootstrapCommands,omitempty"`
@@ -74,6 +104,249 @@ type EKSConfigSpec struct {
 	// NTP specifies NTP configuration
 	// +optional
 	NTP *NTP `json:"ntp,omitempty"`
+	// Containerd specifies containerd configuration. Only used by the nodeadm format.
+	// +optional
+	Containerd *ContainerdConfig `json:"containerd,omitempty"`
+	// Bottlerocket specifies the bootstrap and host containers of Bottlerocket nodes.
+	// Only used by the bottlerocket format.
+	// +optional
+	Bottlerocket *BottlerocketSettings `json:"bottlerocket,omitempty"`
+}
+
+// Format specifies the output format of the bootstrap data.
+type Format string
+
+const (
+	// FormatCloudConfig is the cloud-config format which runs /etc/eks/bootstrap.sh.
+	FormatCloudConfig Format = "cloud-config"
+	// FormatNodeadm is the multipart MIME format containing a NodeConfig consumed by nodeadm.
+	FormatNodeadm Format = "nodeadm"
+	// FormatBottlerocket is the TOML settings format consumed by Bottlerocket.
+	FormatBottlerocket Format = "bottlerocket"
+	// FormatIgnition is the Ignition v3 format consumed by Flatcar Container Linux.
+	FormatIgnition Format = "ignition"
+)
+
+const (
+	// IPFamilyIPv4 is the ipv4 ip family.
+	IPFamilyIPv4 = "ipv4"
+	// IPFamilyIPv6 is the ipv6 ip family.
+	IPFamilyIPv6 = "ipv6"
+)
+
+// KubeletConfiguration defines kubelet settings of a node. The fields match the
+// kubelet.config.k8s.io/v1beta1 KubeletConfiguration.
+type KubeletConfiguration struct {
+	// EvictionHard is a map of signal names to quantities that defines hard eviction
+	// thresholds, for example {"memory.available": "300Mi"}.
+	// +optional
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+
+	// EvictionSoft is a map of signal names to quantities that defines soft eviction
+	// thresholds, for example {"memory.available": "500Mi"}.
+	// +optional
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+
+	// EvictionSoftGracePeriod is a map of signal names to durations that defines the grace
+	// periods of the soft eviction thresholds, for example {"memory.available": "1m30s"}.
+	// +optional
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+
+	// SystemReserved is a set of resource names to quantities reserved for non-kubernetes
+	// components, for example {"cpu": "100m", "memory": "100Mi"}.
+	// +optional
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+
+	// KubeReserved is a set of resource names to quantities reserved for kubernetes system
+	// components, for example {"cpu": "100m", "memory": "100Mi"}.
+	// +optional
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+
+	// MaxPods is the maximum number of pods that can run on the node.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPods *int32 `json:"maxPods,omitempty"`
+
+	// ImageGCHighThresholdPercent is the percent of disk usage after which image garbage
+	// collection is always run.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGCHighThresholdPercent *int32 `json:"imageGCHighThresholdPercent,omitempty"`
+
+	// ImageGCLowThresholdPercent is the percent of disk usage before which image garbage
+	// collection is never run. It must be lower than imageGCHighThresholdPercent.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ImageGCLowThresholdPercent *int32 `json:"imageGCLowThresholdPercent,omitempty"`
+
+	// FeatureGates is a map of feature names to bools that enable or disable
+	// experimental kubelet features.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// TopologyManagerPolicy is the topology manager policy to use.
+	// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+	// +optional
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+
+	// TopologyManagerScope is the scope to which topology hints are applied.
+	// +kubebuilder:validation:Enum=container;pod
+	// +optional
+	TopologyManagerScope string `json:"topologyManagerScope,omitempty"`
+}
+
+// BottlerocketSettings defines the Bottlerocket specific settings of a node.
+type BottlerocketSettings struct {
+	// BootstrapContainers specifies containers which run before the kubelet is started.
+	// +optional
+	BootstrapContainers []BottlerocketBootstrapContainer `json:"bootstrapContainers,omitempty"`
+
+	// HostContainers specifies long running containers with access to the host, such as
+	// the admin and control containers.
+	// +optional
+	HostContainers []BottlerocketHostContainer `json:"hostContainers,omitempty"`
+}
+
+// BottlerocketBootstrapContainer defines a Bottlerocket bootstrap container.
+type BottlerocketBootstrapContainer struct {
+	// Name is the name of the bootstrap container.
+	Name string `json:"name"`
+
+	// Source is the URI of the container image.
+	Source string `json:"source"`
+
+	// Mode specifies when the container runs.
+	// +kubebuilder:validation:Enum=always;once;off
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Essential specifies whether the boot fails if the container fails.
+	// +optional
+	Essential bool `json:"essential,omitempty"`
+
+	// UserData is passed to the container. It's base64 encoded by the controller.
+	// +optional
+	UserData string `json:"userData,omitempty"`
+}
+
+// BottlerocketHostContainer defines a Bottlerocket host container.
+type BottlerocketHostContainer struct {
+	// Name is the name of the host container, e.g. admin or control.
+	Name string `json:"name"`
+
+	// Source is the URI of the container image. If empty, the default image of
+	// Bottlerocket is used for the admin and control containers.
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Enabled specifies whether the container runs.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Superpowered specifies whether the container has elevated privileges on the host.
+	// +optional
+	Superpowered *bool `json:"superpowered,omitempty"`
+
+	// UserData is passed to the container. It's base64 encoded by the controller.
+	// +optional
+	UserData string `json:"userData,omitempty"`
+}
+
+// ContainerdConfig defines the containerd configuration of a node.
+type ContainerdConfig struct {
+	// Config is inline containerd configuration in TOML format, which is merged
+	// with the default containerd configuration of the AMI.
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// Registries specifies the hosts configuration of container registries, such as
+	// mirrors, which is written to /etc/containerd/certs.d/<name>/hosts.toml. Used by the
+	// cloud-config and nodeadm formats.
+	// +optional
+	Registries []ContainerdRegistry `json:"registries,omitempty"`
+
+	// SecureSecretsBackend is the AWS backend which stores the hosts configuration of
+	// registries with credentials, so the credentials aren't part of the user data. Nodes
+	// fetch it with the AWS CLI when they start, which requires the instance profile to
+	// allow reading the secrets.
+	// +kubebuilder:validation:Enum=secrets-manager;ssm-parameter-store
+	// +kubebuilder:default=secrets-manager
+	// +optional
+	SecureSecretsBackend infrav1.SecretBackend `json:"secureSecretsBackend,omitempty"`
+}
+
+// ContainerdRegistry defines the hosts configuration of a container registry.
+type ContainerdRegistry struct {
+	// Name is the registry namespace the configuration applies to, for example
+	// docker.io or registry.example.com:5000. _default applies to all registries
+	// without a configuration.
+	Name string `json:"name"`
+
+	// Server is the URL of the upstream registry, which is used when none of the mirrors
+	// can serve a request. Defaults to the registry namespace.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// Mirrors are the hosts which are tried, in order, before the server.
+	// +optional
+	Mirrors []ContainerdRegistryHost `json:"mirrors,omitempty"`
+}
+
+// ContainerdRegistryHost defines a host serving a container registry.
+type ContainerdRegistryHost struct {
+	// URL is the URL of the host, for example https://mirror.example.com.
+	URL string `json:"url"`
+
+	// Capabilities are the operations the host supports. Defaults to pull and resolve.
+	// +optional
+	Capabilities []ContainerdRegistryCapability `json:"capabilities,omitempty"`
+
+	// CABundle is a PEM encoded bundle of certificate authorities which are trusted
+	// by the host, in addition to the system certificate authorities.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// SkipVerify disables the TLS verification of the host.
+	// +optional
+	SkipVerify bool `json:"skipVerify,omitempty"`
+
+	// AuthSecretRef references a Secret in the namespace of the EKSConfig with the
+	// credentials of the host, in its username and password keys, as used by secrets of
+	// type kubernetes.io/basic-auth.
+	// +optional
+	AuthSecretRef *corev1.LocalObjectReference `json:"authSecretRef,omitempty"`
+}
+
+// ContainerdRegistryCapability is an operation a registry host supports.
+// +kubebuilder:validation:Enum=pull;resolve;push
+type ContainerdRegistryCapability string
+
+const (
+	// ContainerdRegistryCapabilityPull is the capability to fetch manifests and blobs by digest.
+	ContainerdRegistryCapabilityPull ContainerdRegistryCapability = "pull"
+	// ContainerdRegistryCapabilityResolve is the capability to resolve a name to a digest.
+	ContainerdRegistryCapabilityResolve ContainerdRegistryCapability = "resolve"
+	// ContainerdRegistryCapabilityPush is the capability to push manifests and blobs.
+	ContainerdRegistryCapabilityPush ContainerdRegistryCapability = "push"
+)
+
+// RegistryCredentials references the AWS secrets which store the hosts configuration of
+// registries with credentials.
+type RegistryCredentials struct {
+	// SecureSecretsBackend is the AWS backend which stores the secrets.
+	SecureSecretsBackend infrav1.SecretBackend `json:"secureSecretsBackend"`
+
+	// Prefix is the prefix of the names of the secrets.
+	Prefix string `json:"prefix"`
+
+	// Count is the number of secrets.
+	Count int32 `json:"count"`
+
+	// ReplacedAt is the time the secrets were replaced by the secrets of a newer configuration.
+	// +optional
+	ReplacedAt *metav1.Time `json:"replacedAt,omitempty"`
 }
 
 // PauseContainer contains details of pause container.
@@ -105,6 +378,17 @@ type EKSConfigStatus struct {
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
+	// RegistryCredentials references the AWS secrets which store the hosts configuration of
+	// registries with credentials.
+	// +optional
+	RegistryCredentials *RegistryCredentials `json:"registryCredentials,omitempty"`
+
+	// ReplacedRegistryCredentials references the AWS secrets of registry configurations which
+	// were replaced. They are deleted after a grace period, so nodes which were started with
+	// the previous bootstrap data can still fetch them.
+	// +optional
+	ReplacedRegistryCredentials []RegistryCredentials `json:"replacedRegistryCredentials,omitempty"`
+
 	// Conditions defines current service state of the EKSConfig.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
@@ -17,7 +17,13 @@ limitations under the License.
 package v1beta2
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -38,12 +44,24 @@ var _ webhook.Validator = &EKSConfig{}
 
 // ValidateCreate will do any extra validation when creating a EKSConfig.
 func (r *EKSConfig) ValidateCreate() (admission.Warnings, error) {
-	return nil, nil
+	return r.validate()
 }
 
 // ValidateUpdate will do any extra validation when updating a EKSConfig.
 func (r *EKSConfig) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+	return r.validate()
+}
+
+func (r *EKSConfig) validate() (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	warnings, allErrs := r.Spec.validateFormat(specPath)
+	allErrs = append(allErrs, r.Spec.validateKubeletConfiguration(specPath)...)
+	allErrs = append(allErrs, r.Spec.validateContainerdRegistries(specPath)...)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("EKSConfig").GroupKind(), r.Name, allErrs)
 }
 
 // ValidateDelete allows you to add any extra validation when deleting.
@@ -54,3 +72,241 @@ func (r *EKSConfig) ValidateDelete() (admission.Warnings, error) {
 // Default will set default values for the EKSConfig.
 func (r *EKSConfig) Default() {
 }
+
+// kubeletConfigurationFlags maps the kubelet flags which can be passed as kubeletExtraArgs to
+// the kubeletConfiguration fields which configure the same setting.
+var kubeletConfigurationFlags = map[string]string{
+	"eviction-hard":              "evictionHard",
+	"eviction-soft":              "evictionSoft",
+	"eviction-soft-grace-period": "evictionSoftGracePeriod",
+	"system-reserved":            "systemReserved",
+	"kube-reserved":              "kubeReserved",
+	"max-pods":                   "maxPods",
+	"image-gc-high-threshold":    "imageGCHighThresholdPercent",
+	"image-gc-low-threshold":     "imageGCLowThresholdPercent",
+	"feature-gates":              "featureGates",
+	"topology-manager-policy":    "topologyManagerPolicy",
+	"topology-manager-scope":     "topologyManagerScope",
+}
+
+// validateKubeletConfiguration returns errors for kubeletConfiguration settings which are
+// also passed as kubeletExtraArgs, or which are inconsistent.
+func (s *EKSConfigSpec) validateKubeletConfiguration(specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	kc := s.KubeletConfiguration
+	if kc == nil {
+		return allErrs
+	}
+	kcPath := specPath.Child("kubeletConfiguration")
+
+	configured := map[string]bool{
+		"evictionHard":                len(kc.EvictionHard) > 0,
+		"evictionSoft":                len(kc.EvictionSoft) > 0,
+		"evictionSoftGracePeriod":     len(kc.EvictionSoftGracePeriod) > 0,
+		"systemReserved":              len(kc.SystemReserved) > 0,
+		"kubeReserved":                len(kc.KubeReserved) > 0,
+		"maxPods":                     kc.MaxPods != nil,
+		"imageGCHighThresholdPercent": kc.ImageGCHighThresholdPercent != nil,
+		"imageGCLowThresholdPercent":  kc.ImageGCLowThresholdPercent != nil,
+		"featureGates":                len(kc.FeatureGates) > 0,
+		"topologyManagerPolicy":       kc.TopologyManagerPolicy != "",
+		"topologyManagerScope":        kc.TopologyManagerScope != "",
+	}
+
+	flags := make([]string, 0, len(s.KubeletExtraArgs))
+	for flag := range s.KubeletExtraArgs {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+	for _, flag := range flags {
+		if name, ok := kubeletConfigurationFlags[flag]; ok && configured[name] {
+			allErrs = append(allErrs, field.Forbidden(specPath.Child("kubeletExtraArgs").Key(flag),
+				fmt.Sprintf("conflicts with %s", kcPath.Child(name))))
+		}
+	}
+
+	if kc.MaxPods != nil && s.UseMaxPods != nil && *s.UseMaxPods {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("useMaxPods"),
+			fmt.Sprintf("can't be true when %s is set", kcPath.Child("maxPods"))))
+	}
+
+	if kc.ImageGCHighThresholdPercent != nil && kc.ImageGCLowThresholdPercent != nil &&
+		*kc.ImageGCLowThresholdPercent >= *kc.ImageGCHighThresholdPercent {
+		allErrs = append(allErrs, field.Invalid(kcPath.Child("imageGCLowThresholdPercent"), *kc.ImageGCLowThresholdPercent,
+			"must be lower than imageGCHighThresholdPercent"))
+	}
+
+	return allErrs
+}
+
+// validateFormat returns warnings for fields which are ignored by the configured format, and
+// errors for fields which can't be used with it or each other.
+func (s *EKSConfigSpec) validateFormat(specPath *field.Path) (admission.Warnings, field.ErrorList) {
+	var (
+		warnings admission.Warnings
+		allErrs  field.ErrorList
+	)
+
+	format := s.Format
+	if format == "" {
+		format = FormatCloudConfig
+	}
+	ignored := func(name string) {
+		warnings = append(warnings, fmt.Sprintf("%s is ignored by the %s format", specPath.Child(name), format))
+	}
+	forbidden := func(name string) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child(name), fmt.Sprintf("can't be used with the %s format", format)))
+	}
+
+	if s.IPFamily != nil && *s.IPFamily == IPFamilyIPv4 && s.ServiceIPV6Cidr != nil && *s.ServiceIPV6Cidr != "" {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("serviceIPV6Cidr"), "can't be used with the ipv4 ip family"))
+	}
+
+	switch format {
+	case FormatNodeadm:
+		if s.ContainerRuntime != nil {
+			ignored("containerRuntime")
+		}
+		if s.DockerConfigJSON != nil {
+			ignored("dockerConfigJson")
+		}
+		if s.APIRetryAttempts != nil {
+			ignored("apiRetryAttempts")
+		}
+		if s.PauseContainer != nil {
+			ignored("pauseContainer")
+		}
+		if s.UseMaxPods != nil {
+			ignored("useMaxPods")
+		}
+		if s.BootstrapCommandOverride != nil {
+			ignored("boostrapCommandOverride")
+		}
+		if s.Bottlerocket != nil {
+			ignored("bottlerocket")
+		}
+	case FormatBottlerocket:
+		if s.DiskSetup != nil {
+			forbidden("diskSetup")
+		}
+		if len(s.Users) > 0 {
+			forbidden("users")
+		}
+		if len(s.Mounts) > 0 {
+			forbidden("mounts")
+		}
+		if s.ContainerRuntime != nil {
+			ignored("containerRuntime")
+		}
+		if s.DockerConfigJSON != nil {
+			ignored("dockerConfigJson")
+		}
+		if s.APIRetryAttempts != nil {
+			ignored("apiRetryAttempts")
+		}
+		if s.UseMaxPods != nil {
+			ignored("useMaxPods")
+		}
+		if len(s.PreBootstrapCommands) > 0 {
+			ignored("preBootstrapCommands")
+		}
+		if len(s.PostBootstrapCommands) > 0 {
+			ignored("postBootstrapCommands")
+		}
+		if s.BootstrapCommandOverride != nil {
+			ignored("boostrapCommandOverride")
+		}
+		if len(s.Files) > 0 {
+			ignored("files")
+		}
+		if s.NTP != nil {
+			ignored("ntp")
+		}
+		if s.Containerd != nil {
+			ignored("containerd")
+		}
+		if s.KubeletConfiguration != nil && len(s.KubeletConfiguration.FeatureGates) > 0 {
+			ignored("kubeletConfiguration.featureGates")
+		}
+	case FormatIgnition:
+		if s.DiskSetup != nil {
+			forbidden("diskSetup")
+		}
+		if len(s.Mounts) > 0 {
+			forbidden("mounts")
+		}
+		for i, user := range s.Users {
+			if user.Inactive != nil {
+				ignored(fmt.Sprintf("users[%d].inactive", i))
+			}
+			if user.LockPassword != nil {
+				ignored(fmt.Sprintf("users[%d].lockPassword", i))
+			}
+		}
+		if s.Containerd != nil && s.Containerd.Config != "" {
+			ignored("containerd.config")
+		}
+		if s.Containerd != nil {
+			// Registry credentials are fetched with the AWS CLI, which isn't available on
+			// Ignition based AMIs.
+			for i, registry := range s.Containerd.Registries {
+				for j, mirror := range registry.Mirrors {
+					if mirror.AuthSecretRef != nil {
+						forbidden(fmt.Sprintf("containerd.registries[%d].mirrors[%d].authSecretRef", i, j))
+					}
+				}
+			}
+		}
+		if s.Bottlerocket != nil {
+			ignored("bottlerocket")
+		}
+	default:
+		if s.Containerd != nil && s.Containerd.Config != "" {
+			ignored("containerd.config")
+		}
+		if s.Bottlerocket != nil {
+			ignored("bottlerocket")
+		}
+	}
+
+	return warnings, allErrs
+}
+
+// validateContainerdRegistries returns errors for invalid containerd registries.
+func (s *EKSConfigSpec) validateContainerdRegistries(specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if s.Containerd == nil {
+		return allErrs
+	}
+
+	names := map[string]bool{}
+	for i, registry := range s.Containerd.Registries {
+		registryPath := specPath.Child("containerd", "registries").Index(i)
+		switch {
+		case registry.Name == "":
+			allErrs = append(allErrs, field.Required(registryPath.Child("name"), "registry name is required"))
+		case strings.Contains(registry.Name, "/"):
+			allErrs = append(allErrs, field.Invalid(registryPath.Child("name"), registry.Name, "must be a registry host"))
+		case names[registry.Name]:
+			allErrs = append(allErrs, field.Duplicate(registryPath.Child("name"), registry.Name))
+		}
+		names[registry.Name] = true
+
+		if registry.Server != "" && !isHTTPURL(registry.Server) {
+			allErrs = append(allErrs, field.Invalid(registryPath.Child("server"), registry.Server, "must be an http or https URL"))
+		}
+		for j, mirror := range registry.Mirrors {
+			if !isHTTPURL(mirror.URL) {
+				allErrs = append(allErrs, field.Invalid(registryPath.Child("mirrors").Index(j).Child("url"), mirror.URL, "must be an http or https URL"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestEKSConfigValidateFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      EKSConfigSpec
+		warnings  []string
+		expectErr bool
+	}{
+		{
+			name: "cloud-config without containerd config",
+			spec: EKSConfigSpec{
+				ContainerRuntime: ptr.To("containerd"),
+			},
+		},
+		{
+			name: "cloud-config with containerd config",
+			spec: EKSConfigSpec{
+				Containerd: &ContainerdConfig{Config: "version = 2"},
+			},
+			warnings: []string{"spec.containerd.config is ignored by the cloud-config format"},
+		},
+		{
+			name: "nodeadm with bootstrap script options",
+			spec: EKSConfigSpec{
+				Format:                   FormatNodeadm,
+				ContainerRuntime:         ptr.To("containerd"),
+				UseMaxPods:               ptr.To(false),
+				BootstrapCommandOverride: ptr.To("/bootstrap.sh"),
+				Containerd:               &ContainerdConfig{Config: "version = 2"},
+			},
+			warnings: []string{
+				"spec.containerRuntime is ignored by the nodeadm format",
+				"spec.useMaxPods is ignored by the nodeadm format",
+				"spec.boostrapCommandOverride is ignored by the nodeadm format",
+			},
+		},
+		{
+			name: "bottlerocket with ignored fields",
+			spec: EKSConfigSpec{
+				Format:               FormatBottlerocket,
+				PreBootstrapCommands: []string{"echo"},
+				Bottlerocket: &BottlerocketSettings{
+					HostContainers: []BottlerocketHostContainer{{Name: "admin", Enabled: ptr.To(true)}},
+				},
+			},
+			warnings: []string{"spec.preBootstrapCommands is ignored by the bottlerocket format"},
+		},
+		{
+			name: "ipv4 with service ipv6 cidr",
+			spec: EKSConfigSpec{
+				IPFamily:        ptr.To(IPFamilyIPv4),
+				ServiceIPV6Cidr: ptr.To("fd00::/108"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "bottlerocket with cloud-init only fields",
+			spec: EKSConfigSpec{
+				Format:    FormatBottlerocket,
+				DiskSetup: &DiskSetup{},
+				Users:     []User{{Name: "user"}},
+				Mounts:    []MountPoints{{"/dev/xvdb", "/data"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "ignition with users",
+			spec: EKSConfigSpec{
+				Format: FormatIgnition,
+				Users:  []User{{Name: "user", Sudo: ptr.To("ALL=(ALL) NOPASSWD:ALL"), LockPassword: ptr.To(true)}},
+				Files:  []File{{Path: "/etc/example.conf", Content: "example"}},
+			},
+			warnings: []string{"spec.users[0].lockPassword is ignored by the ignition format"},
+		},
+		{
+			name: "ignition with disk setup",
+			spec: EKSConfigSpec{
+				Format:    FormatIgnition,
+				DiskSetup: &DiskSetup{},
+				Mounts:    []MountPoints{{"/dev/xvdb", "/data"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "kubelet configuration with unrelated kubelet args",
+			spec: EKSConfigSpec{
+				KubeletExtraArgs: map[string]string{"node-labels": "role=worker"},
+				KubeletConfiguration: &KubeletConfiguration{
+					MaxPods:                     ptr.To[int32](110),
+					ImageGCHighThresholdPercent: ptr.To[int32](85),
+					ImageGCLowThresholdPercent:  ptr.To[int32](80),
+				},
+				UseMaxPods: ptr.To(false),
+			},
+		},
+		{
+			name: "kubelet configuration conflicting with kubelet args",
+			spec: EKSConfigSpec{
+				KubeletExtraArgs: map[string]string{"eviction-hard": "memory.available<100Mi"},
+				KubeletConfiguration: &KubeletConfiguration{
+					EvictionHard: map[string]string{"memory.available": "300Mi"},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "kubelet configuration max pods with use max pods",
+			spec: EKSConfigSpec{
+				UseMaxPods: ptr.To(true),
+				KubeletConfiguration: &KubeletConfiguration{
+					MaxPods: ptr.To[int32](110),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "kubelet configuration with inverted image gc thresholds",
+			spec: EKSConfigSpec{
+				KubeletConfiguration: &KubeletConfiguration{
+					ImageGCHighThresholdPercent: ptr.To[int32](80),
+					ImageGCLowThresholdPercent:  ptr.To[int32](85),
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "bottlerocket with kubelet feature gates",
+			spec: EKSConfigSpec{
+				Format: FormatBottlerocket,
+				KubeletConfiguration: &KubeletConfiguration{
+					FeatureGates: map[string]bool{"InPlacePodVerticalScaling": true},
+				},
+			},
+			warnings: []string{"spec.kubeletConfiguration.featureGates is ignored by the bottlerocket format"},
+		},
+		{
+			name: "cloud-config with containerd registries",
+			spec: EKSConfigSpec{
+				Containerd: &ContainerdConfig{
+					Registries: []ContainerdRegistry{{
+						Name:    "docker.io",
+						Mirrors: []ContainerdRegistryHost{{URL: "https://mirror.example.com"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "ignition with containerd registries",
+			spec: EKSConfigSpec{
+				Format: FormatIgnition,
+				Containerd: &ContainerdConfig{
+					Registries: []ContainerdRegistry{{
+						Name:    "docker.io",
+						Mirrors: []ContainerdRegistryHost{{URL: "https://mirror.example.com"}},
+					}},
+				},
+			},
+		},
+		{
+			name: "ignition with containerd registry credentials",
+			spec: EKSConfigSpec{
+				Format: FormatIgnition,
+				Containerd: &ContainerdConfig{
+					Registries: []ContainerdRegistry{{
+						Name: "docker.io",
+						Mirrors: []ContainerdRegistryHost{{
+							URL:           "https://mirror.example.com",
+							AuthSecretRef: &corev1.LocalObjectReference{Name: "mirror-credentials"},
+						}},
+					}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "containerd registries with invalid urls",
+			spec: EKSConfigSpec{
+				Containerd: &ContainerdConfig{
+					Registries: []ContainerdRegistry{{
+						Name:    "docker.io",
+						Mirrors: []ContainerdRegistryHost{{URL: "mirror.example.com"}},
+					}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "duplicate containerd registries",
+			spec: EKSConfigSpec{
+				Containerd: &ContainerdConfig{
+					Registries: []ContainerdRegistry{{Name: "docker.io"}, {Name: "docker.io"}},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &EKSConfig{Spec: tt.spec}
+			warnings, err := config.ValidateCreate()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect([]string(warnings)).To(Equal(tt.warnings))
+
+			template := &EKSConfigTemplate{Spec: EKSConfigTemplateSpec{Template: EKSConfigTemplateResource{Spec: tt.spec}}}
+			warnings, err = template.ValidateUpdate(template.DeepCopy())
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warnings).To(HaveLen(len(tt.warnings)))
+			for i := range warnings {
+				g.Expect(warnings[i]).To(Equal(strings.Replace(tt.warnings[i], "spec.", "spec.template.spec.", 1)))
+			}
+		})
+	}
+}
@@ -20,12 +20,15 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
@@ -36,9 +39,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/internal/userdata"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
@@ -56,6 +62,9 @@ type EKSConfigReconciler struct {
 	client.Client
 	Scheme           *runtime.Scheme
 	WatchFilterValue string
+	Endpoints        []scope.ServiceEndpoint
+
+	secretServiceFactory func(*clusterv1.Cluster, *ekscontrolplanev1.AWSManagedControlPlane, infrav1.SecretBackend) (services.ChunkedSecretInterface, error)
 }
 
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=eksconfigs,verbs=get;list;watch;update;patch
@@ -79,6 +88,10 @@ func (r *EKSConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	log = log.WithValues("EKSConfig", config.GetName())
 
+	if !config.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, config)
+	}
+
 	// check owner references and look up owning Machine object
 	configOwner, err := bsutil.GetTypedConfigOwner(ctx, r.Client, config)
 	if apierrors.IsNotFound(err) {
@@ -144,7 +157,11 @@ func (r *EKSConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}()
 
-	return ctrl.Result{}, r.joinWorker(ctx, cluster, config, configOwner)
+	if err := r.joinWorker(ctx, cluster, config, configOwner); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.reconcileReplacedRegistryCredentials(ctx, cluster, config)
 }
 
 func (r *EKSConfigReconciler) resolveFiles(ctx context.Context, cfg *eksbootstrapv1.EKSConfig) ([]eksbootstrapv1.File, error) {
@@ -235,45 +252,77 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 		return err
 	}
 
+	var vars *userdata.Variables
+	if config.Spec.TemplateVariables {
+		vars = templateVariables(controlPlane, configOwner)
+	}
+	rendered, err := userdata.RenderTemplates(vars, config.Spec.Format, files, config.Spec.PreBootstrapCommands, config.Spec.KubeletExtraArgs)
+	if err != nil {
+		log.Info("Failed to render user data templates")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	registryFiles, registryCommands, err := r.reconcileContainerdRegistries(ctx, cluster, config, controlPlane)
+	if err != nil {
+		log.Info("Failed to reconcile containerd registries")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	rendered.Files = append(rendered.Files, registryFiles...)
+	rendered.PreBootstrapCommands = append(registryCommands, rendered.PreBootstrapCommands...)
+
+	switch config.Spec.Format {
+	case eksbootstrapv1.FormatNodeadm:
+		return r.joinWorkerNodeadm(ctx, cluster, config, controlPlane, rendered)
+	case eksbootstrapv1.FormatBottlerocket:
+		return r.joinWorkerBottlerocket(ctx, cluster, config, controlPlane, rendered)
+	}
+
 	nodeInput := &userdata.NodeInput{
 		// AWSManagedControlPlane webhooks default and validate EKSClusterName
 		ClusterName:              controlPlane.Spec.EKSClusterName,
-		KubeletExtraArgs:         config.Spec.KubeletExtraArgs,
+		KubeletExtraArgs:         rendered.KubeletExtraArgs,
+		KubeletConfiguration:     config.Spec.KubeletConfiguration,
 		ContainerRuntime:         config.Spec.ContainerRuntime,
 		DNSClusterIP:             config.Spec.DNSClusterIP,
 		DockerConfigJSON:         config.Spec.DockerConfigJSON,
 		APIRetryAttempts:         config.Spec.APIRetryAttempts,
 		UseMaxPods:               config.Spec.UseMaxPods,
-		PreBootstrapCommands:     config.Spec.PreBootstrapCommands,
+		PreBootstrapCommands:     rendered.PreBootstrapCommands,
 		PostBootstrapCommands:    config.Spec.PostBootstrapCommands,
 		BootstrapCommandOverride: config.Spec.BootstrapCommandOverride,
 		NTP:                      config.Spec.NTP,
 		Users:                    config.Spec.Users,
 		DiskSetup:                config.Spec.DiskSetup,
 		Mounts:                   config.Spec.Mounts,
-		Files:                    files,
+		Files:                    rendered.Files,
 	}
 	if config.Spec.PauseContainer != nil {
 		nodeInput.PauseContainerAccount = &config.Spec.PauseContainer.AccountNumber
 		nodeInput.PauseContainerVersion = &config.Spec.PauseContainer.Version
 	}
 
-	// Check if IPv6 was provided to the user configuration first
-	// If not, we also check if the cluster is ipv6 based.
-	if config.Spec.ServiceIPV6Cidr != nil && *config.Spec.ServiceIPV6Cidr != "" {
-		nodeInput.ServiceIPV6Cidr = config.Spec.ServiceIPV6Cidr
-		nodeInput.IPFamily = ptr.To[string]("ipv6")
-	}
-
-	// we don't want to override any manually set configuration options.
-	if config.Spec.ServiceIPV6Cidr == nil && controlPlane.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+	if isIPv6Node(config, controlPlane) {
+		serviceCIDR := serviceIPv6CIDR(config, controlPlane)
+		if serviceCIDR == "" {
+			conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.WaitingForControlPlaneInitializationReason, clusterv1.ConditionSeverityInfo, "")
+			return errors.Errorf("control plane %s has no service ipv6 cidr yet", klog.KObj(controlPlane))
+		}
 		log.Info("Adding ipv6 data to userdata....")
-		nodeInput.ServiceIPV6Cidr = ptr.To[string](controlPlane.Spec.NetworkSpec.VPC.IPv6.CidrBlock)
-		nodeInput.IPFamily = ptr.To[string]("ipv6")
+		nodeInput.ServiceIPV6Cidr = ptr.To[string](serviceCIDR)
+		nodeInput.IPFamily = ptr.To[string](eksbootstrapv1.IPFamilyIPv6)
+	} else {
+		nodeInput.MaxPods = maxPodsInput(config, controlPlane, rendered.KubeletExtraArgs)
 	}
 
 	// generate userdata
-	userDataScript, err := userdata.NewNode(nodeInput)
+	var userDataScript []byte
+	if config.Spec.Format == eksbootstrapv1.FormatIgnition {
+		userDataScript, err = userdata.NewIgnition(nodeInput)
+	} else {
+		userDataScript, err = userdata.NewNode(nodeInput)
+	}
 	if err != nil {
 		log.Error(err, "Failed to create a worker join configuration")
 		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
@@ -290,6 +339,204 @@ func (r *EKSConfigReconciler) joinWorker(ctx context.Context, cluster *clusterv1
 	return nil
 }
 
+// joinWorkerNodeadm generates and stores the nodeadm user data of a node. The API server endpoint
+// and certificate authority are read from the control plane, so nodes don't need to call
+// DescribeCluster.
+func (r *EKSConfigReconciler) joinWorkerNodeadm(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, rendered *userdata.RenderedTemplates) error {
+	log := logger.FromContext(ctx)
+
+	if controlPlane.Spec.ControlPlaneEndpoint.Host == "" || controlPlane.Status.CertificateAuthorityData == "" {
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.WaitingForControlPlaneInitializationReason, clusterv1.ConditionSeverityInfo, "")
+		return errors.Errorf("control plane %s has no API server endpoint or certificate authority yet", klog.KObj(controlPlane))
+	}
+
+	if err := requireMaxPods(config, controlPlane, rendered.KubeletExtraArgs); err != nil {
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	serviceCIDR := controlPlane.Status.ServiceCIDR
+	if isIPv6Node(config, controlPlane) {
+		serviceCIDR = serviceIPv6CIDR(config, controlPlane)
+	}
+	if serviceCIDR == "" {
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.WaitingForControlPlaneInitializationReason, clusterv1.ConditionSeverityInfo, "")
+		return errors.Errorf("control plane %s has no service cidr yet", klog.KObj(controlPlane))
+	}
+
+	nodeInput := &userdata.NodeadmInput{
+		ClusterName:           controlPlane.Spec.EKSClusterName,
+		APIServerEndpoint:     "https://" + controlPlane.Spec.ControlPlaneEndpoint.Host,
+		CACert:                controlPlane.Status.CertificateAuthorityData,
+		ServiceCIDR:           serviceCIDR,
+		KubeletExtraArgs:      rendered.KubeletExtraArgs,
+		KubeletConfiguration:  config.Spec.KubeletConfiguration,
+		DNSClusterIP:          config.Spec.DNSClusterIP,
+		PreBootstrapCommands:  rendered.PreBootstrapCommands,
+		PostBootstrapCommands: config.Spec.PostBootstrapCommands,
+		NTP:                   config.Spec.NTP,
+		Users:                 config.Spec.Users,
+		DiskSetup:             config.Spec.DiskSetup,
+		Mounts:                config.Spec.Mounts,
+		Files:                 rendered.Files,
+	}
+	if config.Spec.Containerd != nil {
+		nodeInput.ContainerdConfig = config.Spec.Containerd.Config
+	}
+
+	userData, err := userdata.NewNodeadm(nodeInput)
+	if err != nil {
+		log.Error(err, "Failed to create a worker join configuration")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	if err := r.storeBootstrapData(ctx, cluster, config, userData); err != nil {
+		log.Error(err, "Failed to store bootstrap data")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	return nil
+}
+
+// joinWorkerBottlerocket generates and stores the TOML user data of a Bottlerocket node.
+func (r *EKSConfigReconciler) joinWorkerBottlerocket(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, rendered *userdata.RenderedTemplates) error {
+	log := logger.FromContext(ctx)
+
+	if controlPlane.Spec.ControlPlaneEndpoint.Host == "" || controlPlane.Status.CertificateAuthorityData == "" {
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.WaitingForControlPlaneInitializationReason, clusterv1.ConditionSeverityInfo, "")
+		return errors.Errorf("control plane %s has no API server endpoint or certificate authority yet", klog.KObj(controlPlane))
+	}
+
+	if err := requireMaxPods(config, controlPlane, rendered.KubeletExtraArgs); err != nil {
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	input := &userdata.BottlerocketInput{
+		ClusterName:          controlPlane.Spec.EKSClusterName,
+		APIServerEndpoint:    "https://" + controlPlane.Spec.ControlPlaneEndpoint.Host,
+		CACert:               controlPlane.Status.CertificateAuthorityData,
+		KubeletExtraArgs:     rendered.KubeletExtraArgs,
+		KubeletConfiguration: config.Spec.KubeletConfiguration,
+		DNSClusterIP:         config.Spec.DNSClusterIP,
+	}
+	if config.Spec.PauseContainer != nil {
+		input.PauseContainerImage = pauseContainerImage(config.Spec.PauseContainer, controlPlane.Spec.Region)
+	}
+	if config.Spec.Bottlerocket != nil {
+		input.BootstrapContainers = config.Spec.Bottlerocket.BootstrapContainers
+		input.HostContainers = config.Spec.Bottlerocket.HostContainers
+	}
+
+	userData, err := userdata.NewBottlerocket(input)
+	if err != nil {
+		log.Error(err, "Failed to create a worker join configuration")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	if err := r.storeBootstrapData(ctx, cluster, config, userData); err != nil {
+		log.Error(err, "Failed to store bootstrap data")
+		conditions.MarkFalse(config, eksbootstrapv1.DataSecretAvailableCondition, eksbootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, "")
+		return err
+	}
+
+	return nil
+}
+
+// templateVariables returns the variables of the user data templates which are known before
+// the node starts.
+func templateVariables(controlPlane *ekscontrolplanev1.AWSManagedControlPlane, configOwner *bsutil.ConfigOwner) *userdata.Variables {
+	vars := &userdata.Variables{
+		ClusterName: controlPlane.Spec.EKSClusterName,
+		Region:      controlPlane.Spec.Region,
+	}
+	if configOwner.IsMachinePool() {
+		vars.MachinePoolName = configOwner.GetName()
+		return vars
+	}
+
+	vars.MachineName = configOwner.GetName()
+	if failureDomain, _, err := unstructured.NestedString(configOwner.Object, "spec", "failureDomain"); err == nil {
+		vars.AvailabilityZone = failureDomain
+	}
+	return vars
+}
+
+// isIPv6Node returns whether the node uses the ipv6 ip family. Unless the ip family is set
+// explicitly, it's ipv6 if a service ipv6 cidr is set or IPv6 is enabled for the VPC.
+func isIPv6Node(config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) bool {
+	if config.Spec.IPFamily != nil {
+		return *config.Spec.IPFamily == eksbootstrapv1.IPFamilyIPv6
+	}
+	if config.Spec.ServiceIPV6Cidr != nil && *config.Spec.ServiceIPV6Cidr != "" {
+		return true
+	}
+	return controlPlane.Spec.NetworkSpec.VPC.IsIPv6Enabled()
+}
+
+// maxPodsInput returns the VPC CNI modes which the maximum number of pods of the node is
+// computed for, or nil if the modes don't change it or the config sets it explicitly.
+func maxPodsInput(config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, kubeletExtraArgs map[string]string) *userdata.MaxPodsInput {
+	vpcCni := controlPlane.Spec.VpcCni
+	if vpcCni.Disable || (!vpcCni.CustomNetworking && !vpcCni.PrefixDelegation) {
+		return nil
+	}
+	if _, ok := kubeletExtraArgs["max-pods"]; ok || config.Spec.UseMaxPods != nil {
+		return nil
+	}
+	if config.Spec.KubeletConfiguration != nil && config.Spec.KubeletConfiguration.MaxPods != nil {
+		return nil
+	}
+
+	return &userdata.MaxPodsInput{
+		CustomNetworking: vpcCni.CustomNetworking,
+		PrefixDelegation: vpcCni.PrefixDelegation,
+	}
+}
+
+// requireMaxPods returns an error if the maximum number of pods isn't set for a node which
+// doesn't run bootstrap.sh, as it can't be computed on the node when the VPC CNI uses custom
+// networking or prefix delegation.
+func requireMaxPods(config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, kubeletExtraArgs map[string]string) error {
+	vpcCni := controlPlane.Spec.VpcCni
+	if vpcCni.Disable || (!vpcCni.CustomNetworking && !vpcCni.PrefixDelegation) {
+		return nil
+	}
+	if _, ok := kubeletExtraArgs["max-pods"]; ok {
+		return nil
+	}
+	if config.Spec.KubeletConfiguration != nil && config.Spec.KubeletConfiguration.MaxPods != nil {
+		return nil
+	}
+
+	return errors.Errorf("kubeletConfiguration.maxPods is required by the %s format as the VPC CNI of control plane %s uses custom networking or prefix delegation",
+		config.Spec.Format, klog.KObj(controlPlane))
+}
+
+// serviceIPv6CIDR returns the service ipv6 cidr of the config, or the one of the EKS cluster
+// if it isn't set.
+func serviceIPv6CIDR(config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) string {
+	if config.Spec.ServiceIPV6Cidr != nil && *config.Spec.ServiceIPV6Cidr != "" {
+		return *config.Spec.ServiceIPV6Cidr
+	}
+	if strings.Contains(controlPlane.Status.ServiceCIDR, ":") {
+		return controlPlane.Status.ServiceCIDR
+	}
+	return ""
+}
+
+// pauseContainerImage returns the image of the pause container in the ECR registry of the region.
+func pauseContainerImage(pause *eksbootstrapv1.PauseContainer, region string) string {
+	domain := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		domain = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("%s.dkr.ecr.%s.%s/eks/pause:%s", pause.AccountNumber, region, domain, pause.Version)
+}
+
 func (r *EKSConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, option controller.Options) error {
 	b := ctrl.NewControllerManagedBy(mgr).
 		For(&eksbootstrapv1.EKSConfig{}).
@@ -345,7 +592,7 @@ func (r *EKSConfigReconciler) storeBootstrapData(ctx context.Context, cluster *c
 			return errors.Wrap(err, "failed to get data secret for EKSConfig")
 		}
 	} else {
-		updated, err := r.updateBootstrapSecret(ctx, secret, data)
+		updated, err := r.updateBootstrapSecret(ctx, secret, config, data)
 		if err != nil {
 			return errors.Wrap(err, "failed to update data secret for EKSConfig")
 		}
@@ -453,17 +700,35 @@ func (r *EKSConfigReconciler) createBootstrapSecret(ctx context.Context, cluster
 		},
 		Type: clusterv1.ClusterSecretType,
 	}
+	if format := bootstrapDataFormat(config); format != "" {
+		secret.Data["format"] = []byte(format)
+	}
 	return secret, r.Client.Create(ctx, secret)
 }
 
 // Update the userdata in the bootstrap Secret.
-func (r *EKSConfigReconciler) updateBootstrapSecret(ctx context.Context, secret *corev1.Secret, data []byte) (bool, error) {
+func (r *EKSConfigReconciler) updateBootstrapSecret(ctx context.Context, secret *corev1.Secret, config *eksbootstrapv1.EKSConfig, data []byte) (bool, error) {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
-	if !bytes.Equal(secret.Data["value"], data) {
+	format := []byte(bootstrapDataFormat(config))
+	if !bytes.Equal(secret.Data["value"], data) || !bytes.Equal(secret.Data["format"], format) {
 		secret.Data["value"] = data
+		if len(format) > 0 {
+			secret.Data["format"] = format
+		} else {
+			delete(secret.Data, "format")
+		}
 		return true, r.Client.Update(ctx, secret)
 	}
 	return false, nil
 }
+
+// bootstrapDataFormat returns the format of the bootstrap data which is stored in the bootstrap
+// Secret. Infrastructure providers use it to tell Ignition configs from other user data.
+func bootstrapDataFormat(config *eksbootstrapv1.EKSConfig) string {
+	if config.Spec.Format == eksbootstrapv1.FormatIgnition {
+		return string(eksbootstrapv1.FormatIgnition)
+	}
+	return ""
+}
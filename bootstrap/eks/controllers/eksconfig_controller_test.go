@@ -22,7 +22,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
 )
@@ -69,6 +73,57 @@ func TestEKSConfigReconcilerReturnEarlyIfClusterControlPlaneNotInitialized(t *te
 	}).Should(Succeed())
 }
 
+func TestEKSConfigReconcilerIPv6(t *testing.T) {
+	ipv6ControlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{IPv6: &infrav1.IPv6{CidrBlock: "2001:db8::/56"}}},
+		},
+		Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{ServiceCIDR: "fd00:ec2::/108"},
+	}
+
+	tests := []struct {
+		name         string
+		spec         eksbootstrapv1.EKSConfigSpec
+		controlPlane *ekscontrolplanev1.AWSManagedControlPlane
+		expectIPv6   bool
+		serviceCIDR  string
+	}{
+		{
+			name:         "ipv4 control plane",
+			controlPlane: &ekscontrolplanev1.AWSManagedControlPlane{Status: ekscontrolplanev1.AWSManagedControlPlaneStatus{ServiceCIDR: "10.100.0.0/16"}},
+		},
+		{
+			name:         "ipv6 control plane",
+			controlPlane: ipv6ControlPlane,
+			expectIPv6:   true,
+			serviceCIDR:  "fd00:ec2::/108",
+		},
+		{
+			name:         "explicit service ipv6 cidr",
+			spec:         eksbootstrapv1.EKSConfigSpec{ServiceIPV6Cidr: ptr.To("fd00::/108")},
+			controlPlane: &ekscontrolplanev1.AWSManagedControlPlane{},
+			expectIPv6:   true,
+			serviceCIDR:  "fd00::/108",
+		},
+		{
+			name:         "explicit ipv4 ip family",
+			spec:         eksbootstrapv1.EKSConfigSpec{IPFamily: ptr.To(eksbootstrapv1.IPFamilyIPv4)},
+			controlPlane: ipv6ControlPlane,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &eksbootstrapv1.EKSConfig{Spec: tt.spec}
+			g.Expect(isIPv6Node(config, tt.controlPlane)).To(Equal(tt.expectIPv6))
+			if tt.expectIPv6 {
+				g.Expect(serviceIPv6CIDR(config, tt.controlPlane)).To(Equal(tt.serviceCIDR))
+			}
+		})
+	}
+}
+
 func configOwner(kind string) *bsutil.ConfigOwner {
 	unstructuredOwner := unstructured.Unstructured{
 		Object: map[string]interface{}{"kind": kind},
@@ -76,3 +131,59 @@ func configOwner(kind string) *bsutil.ConfigOwner {
 	configOwner := bsutil.ConfigOwner{Unstructured: &unstructuredOwner}
 	return &configOwner
 }
+
+func TestRequireMaxPods(t *testing.T) {
+	tests := []struct {
+		name             string
+		vpcCni           ekscontrolplanev1.VpcCni
+		kubeletConfig    *eksbootstrapv1.KubeletConfiguration
+		kubeletExtraArgs map[string]string
+		expectErr        bool
+	}{
+		{
+			name: "default vpc cni",
+		},
+		{
+			name:   "disabled vpc cni",
+			vpcCni: ekscontrolplanev1.VpcCni{Disable: true, PrefixDelegation: true},
+		},
+		{
+			name:      "prefix delegation without max pods",
+			vpcCni:    ekscontrolplanev1.VpcCni{PrefixDelegation: true},
+			expectErr: true,
+		},
+		{
+			name:      "custom networking without max pods",
+			vpcCni:    ekscontrolplanev1.VpcCni{CustomNetworking: true},
+			expectErr: true,
+		},
+		{
+			name:          "prefix delegation with kubelet configuration max pods",
+			vpcCni:        ekscontrolplanev1.VpcCni{PrefixDelegation: true},
+			kubeletConfig: &eksbootstrapv1.KubeletConfiguration{MaxPods: ptr.To[int32](110)},
+		},
+		{
+			name:             "custom networking with max pods kubelet arg",
+			vpcCni:           ekscontrolplanev1.VpcCni{CustomNetworking: true},
+			kubeletExtraArgs: map[string]string{"max-pods": "58"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &eksbootstrapv1.EKSConfig{Spec: eksbootstrapv1.EKSConfigSpec{
+				Format:               eksbootstrapv1.FormatNodeadm,
+				KubeletConfiguration: tt.kubeletConfig,
+			}}
+			controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{VpcCni: tt.vpcCni}}
+
+			err := requireMaxPods(config, controlPlane, tt.kubeletExtraArgs)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
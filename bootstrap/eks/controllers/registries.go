@@ -0,0 +1,325 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/internal/userdata"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/secretsmanager"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/ssm"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+const (
+	registryAuthUsernameKey = "username"
+	registryAuthPasswordKey = "password"
+
+	// replacedRegistryCredentialsGracePeriod is the time replaced registry credentials are kept,
+	// so nodes which were started with the previous bootstrap data can still fetch them.
+	replacedRegistryCredentialsGracePeriod = time.Hour
+)
+
+func (r *EKSConfigReconciler) getSecretService(cluster *clusterv1.Cluster, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, backend infrav1.SecretBackend) (services.ChunkedSecretInterface, error) {
+	if r.secretServiceFactory != nil {
+		return r.secretServiceFactory(cluster, controlPlane, backend)
+	}
+
+	managedScope, err := scope.NewManagedControlPlaneScope(scope.ManagedControlPlaneScopeParams{
+		Client:         r.Client,
+		Cluster:        cluster,
+		ControlPlane:   controlPlane,
+		ControllerName: "eksconfig",
+		Endpoints:      r.Endpoints,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create scope")
+	}
+
+	switch backend {
+	case infrav1.SecretBackendSSMParameterStore:
+		return ssm.NewService(managedScope), nil
+	case infrav1.SecretBackendSecretsManager, "":
+		return secretsmanager.NewService(managedScope), nil
+	}
+	return nil, errors.Errorf("invalid secret backend %q", backend)
+}
+
+// reconcileContainerdRegistries returns the files with the hosts configuration of the containerd
+// registries, and the commands which fetch the configuration of registries with credentials.
+// The configuration of registries with credentials is stored in the secure secrets backend,
+// so the credentials aren't part of the user data.
+func (r *EKSConfigReconciler) reconcileContainerdRegistries(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) ([]eksbootstrapv1.File, []string, error) {
+	containerd := config.Spec.Containerd
+	if containerd == nil || len(containerd.Registries) == 0 || config.Spec.Format == eksbootstrapv1.FormatBottlerocket {
+		replaceRegistryCredentials(config)
+		return nil, nil, nil
+	}
+
+	authorizations := map[string]string{}
+	for _, registry := range containerd.Registries {
+		for _, mirror := range registry.Mirrors {
+			if mirror.AuthSecretRef == nil {
+				continue
+			}
+			authorization, err := r.resolveRegistryAuthorization(ctx, config.Namespace, mirror.AuthSecretRef.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			authorizations[mirror.AuthSecretRef.Name] = authorization
+		}
+	}
+
+	files, sensitiveFiles := userdata.NewContainerdRegistryFiles(containerd.Registries, authorizations)
+	if len(sensitiveFiles) == 0 {
+		replaceRegistryCredentials(config)
+		return files, nil, nil
+	}
+
+	archive, err := userdata.NewContainerdRegistryArchive(sensitiveFiles)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to archive registry configuration")
+	}
+
+	// The name changes with the configuration, so nodes which are starting can still fetch
+	// the previous configuration while it's replaced.
+	hash := sha256.Sum256(archive)
+	name := path.Join("eksconfig", string(config.UID), hex.EncodeToString(hash[:])[:16])
+
+	backend := containerd.SecureSecretsBackend
+	if backend == "" {
+		backend = infrav1.SecretBackendSecretsManager
+	}
+	svc, err := r.getSecretService(cluster, controlPlane, backend)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	current := config.Status.RegistryCredentials
+	if current == nil || current.SecureSecretsBackend != backend || !strings.HasSuffix(current.Prefix, name) {
+		controllerutil.AddFinalizer(config, eksbootstrapv1.EKSConfigFinalizer)
+
+		prefix, count, err := svc.CreateChunks(name, archive, nil)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to store registry credentials")
+		}
+		replaceRegistryCredentials(config)
+		config.Status.RegistryCredentials = &eksbootstrapv1.RegistryCredentials{
+			SecureSecretsBackend: backend,
+			Prefix:               prefix,
+			Count:                count,
+		}
+		logger.FromContext(ctx).Info("Stored registry credentials", "prefix", prefix)
+	}
+
+	credentials := config.Status.RegistryCredentials
+	commands := []string{
+		"mkdir -p " + userdata.ContainerdRegistriesDir,
+		svc.FetchCommand(credentials.Prefix, credentials.Count, controlPlane.Spec.Region, r.Endpoints) + " | tar -xz -C " + userdata.ContainerdRegistriesDir,
+	}
+
+	return files, commands, nil
+}
+
+// resolveRegistryAuthorization returns the value of the authorization header for the
+// credentials in the secret.
+func (r *EKSConfigReconciler) resolveRegistryAuthorization(ctx context.Context, ns, name string) (string, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: ns, Name: name}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "secret not found: %s", key)
+		}
+		return "", errors.Wrapf(err, "failed to retrieve Secret %q", key)
+	}
+
+	username, ok := secret.Data[registryAuthUsernameKey]
+	if !ok {
+		return "", errors.Errorf("secret %q has no %s key", key, registryAuthUsernameKey)
+	}
+	password, ok := secret.Data[registryAuthPasswordKey]
+	if !ok {
+		return "", errors.Errorf("secret %q has no %s key", key, registryAuthPasswordKey)
+	}
+
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(string(username)+":"+string(password))), nil
+}
+
+// replaceRegistryCredentials moves the registry credentials referenced by the status of the
+// config to the replaced credentials, which are deleted after a grace period.
+func replaceRegistryCredentials(config *eksbootstrapv1.EKSConfig) {
+	credentials := config.Status.RegistryCredentials
+	if credentials == nil {
+		return
+	}
+
+	credentials.ReplacedAt = ptr.To(metav1.Now())
+	config.Status.ReplacedRegistryCredentials = append(config.Status.ReplacedRegistryCredentials, *credentials)
+	config.Status.RegistryCredentials = nil
+}
+
+// deleteReplacedRegistryCredentials deletes the replaced registry credentials whose grace period
+// has passed. It returns the time until the grace period of the remaining credentials passes.
+func (r *EKSConfigReconciler) deleteReplacedRegistryCredentials(cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) (time.Duration, error) {
+	var requeueAfter time.Duration
+	remaining := []eksbootstrapv1.RegistryCredentials{}
+	for _, credentials := range config.Status.ReplacedRegistryCredentials {
+		if credentials.ReplacedAt != nil {
+			if wait := time.Until(credentials.ReplacedAt.Add(replacedRegistryCredentialsGracePeriod)); wait > 0 {
+				if requeueAfter == 0 || wait < requeueAfter {
+					requeueAfter = wait
+				}
+				remaining = append(remaining, credentials)
+				continue
+			}
+		}
+
+		if err := r.deleteChunks(cluster, controlPlane, credentials); err != nil {
+			return 0, err
+		}
+	}
+
+	config.Status.ReplacedRegistryCredentials = remaining
+	if len(remaining) == 0 {
+		config.Status.ReplacedRegistryCredentials = nil
+	}
+	return requeueAfter, nil
+}
+
+// reconcileReplacedRegistryCredentials deletes the replaced registry credentials whose grace
+// period has passed, and requeues until all of them are deleted.
+func (r *EKSConfigReconciler) reconcileReplacedRegistryCredentials(ctx context.Context, cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig) (ctrl.Result, error) {
+	if len(config.Status.ReplacedRegistryCredentials) == 0 || cluster.Spec.ControlPlaneRef == nil {
+		return ctrl.Result{}, nil
+	}
+
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Spec.ControlPlaneRef.Name, Namespace: cluster.Spec.ControlPlaneRef.Namespace}, controlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeueAfter, err := r.deleteReplacedRegistryCredentials(cluster, config, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// deleteRegistryCredentials deletes the current and replaced registry credentials referenced
+// by the status of the config.
+func (r *EKSConfigReconciler) deleteRegistryCredentials(cluster *clusterv1.Cluster, config *eksbootstrapv1.EKSConfig, controlPlane *ekscontrolplanev1.AWSManagedControlPlane) error {
+	for len(config.Status.ReplacedRegistryCredentials) > 0 {
+		if err := r.deleteChunks(cluster, controlPlane, config.Status.ReplacedRegistryCredentials[0]); err != nil {
+			return err
+		}
+		config.Status.ReplacedRegistryCredentials = config.Status.ReplacedRegistryCredentials[1:]
+	}
+	config.Status.ReplacedRegistryCredentials = nil
+
+	if credentials := config.Status.RegistryCredentials; credentials != nil {
+		if err := r.deleteChunks(cluster, controlPlane, *credentials); err != nil {
+			return err
+		}
+		config.Status.RegistryCredentials = nil
+	}
+	return nil
+}
+
+func (r *EKSConfigReconciler) deleteChunks(cluster *clusterv1.Cluster, controlPlane *ekscontrolplanev1.AWSManagedControlPlane, credentials eksbootstrapv1.RegistryCredentials) error {
+	svc, err := r.getSecretService(cluster, controlPlane, credentials.SecureSecretsBackend)
+	if err != nil {
+		return err
+	}
+	if err := svc.DeleteChunks(credentials.Prefix, credentials.Count); err != nil {
+		return errors.Wrapf(err, "failed to delete registry credentials %s", credentials.Prefix)
+	}
+	return nil
+}
+
+// reconcileDelete deletes the registry credentials of the config and removes its finalizer.
+func (r *EKSConfigReconciler) reconcileDelete(ctx context.Context, config *eksbootstrapv1.EKSConfig) error {
+	log := logger.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(config, eksbootstrapv1.EKSConfigFinalizer) {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(config, r.Client)
+	if err != nil {
+		return err
+	}
+
+	if config.Status.RegistryCredentials != nil || len(config.Status.ReplacedRegistryCredentials) > 0 {
+		cluster, controlPlane, err := r.getClusterAndControlPlane(ctx, config)
+		switch {
+		case apierrors.IsNotFound(err):
+			log.Info("Cluster or control plane not found, not deleting registry credentials")
+		case err != nil:
+			return err
+		default:
+			if err := r.deleteRegistryCredentials(cluster, config, controlPlane); err != nil {
+				return err
+			}
+		}
+	}
+
+	controllerutil.RemoveFinalizer(config, eksbootstrapv1.EKSConfigFinalizer)
+	return patchHelper.Patch(ctx, config)
+}
+
+func (r *EKSConfigReconciler) getClusterAndControlPlane(ctx context.Context, config *eksbootstrapv1.EKSConfig) (*clusterv1.Cluster, *ekscontrolplanev1.AWSManagedControlPlane, error) {
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, config.ObjectMeta)
+	if err != nil {
+		if errors.Is(err, util.ErrNoCluster) {
+			return nil, nil, apierrors.NewNotFound(clusterv1.GroupVersion.WithResource("clusters").GroupResource(), "")
+		}
+		return nil, nil, err
+	}
+	if cluster.Spec.ControlPlaneRef == nil {
+		return nil, nil, apierrors.NewNotFound(ekscontrolplanev1.GroupVersion.WithResource("awsmanagedcontrolplanes").GroupResource(), "")
+	}
+
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	if err := r.Get(ctx, client.ObjectKey{Name: cluster.Spec.ControlPlaneRef.Name, Namespace: cluster.Spec.ControlPlaneRef.Namespace}, controlPlane); err != nil {
+		return nil, nil, err
+	}
+
+	return cluster, controlPlane, nil
+}
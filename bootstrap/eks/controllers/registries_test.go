@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+type fakeChunkedSecretService struct {
+	deleted []string
+}
+
+func (f *fakeChunkedSecretService) CreateChunks(name string, _ []byte, _ infrav1.Tags) (string, int32, error) {
+	return name, 1, nil
+}
+
+func (f *fakeChunkedSecretService) DeleteChunks(prefix string, _ int32) error {
+	f.deleted = append(f.deleted, prefix)
+	return nil
+}
+
+func (f *fakeChunkedSecretService) FetchCommand(string, int32, string, []scope.ServiceEndpoint) string {
+	return ""
+}
+
+func TestReplacedRegistryCredentials(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := &fakeChunkedSecretService{}
+	r := &EKSConfigReconciler{
+		secretServiceFactory: func(*clusterv1.Cluster, *ekscontrolplanev1.AWSManagedControlPlane, infrav1.SecretBackend) (services.ChunkedSecretInterface, error) {
+			return svc, nil
+		},
+	}
+	cluster := newCluster("cluster")
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{}
+	config := newEKSConfig(nil)
+	config.Status.RegistryCredentials = &eksbootstrapv1.RegistryCredentials{
+		SecureSecretsBackend: infrav1.SecretBackendSecretsManager,
+		Prefix:               "aws.cluster.x-k8s.io/eksconfig/uid/first",
+		Count:                1,
+	}
+
+	// Replaced credentials are kept during the grace period.
+	replaceRegistryCredentials(config)
+	g.Expect(config.Status.RegistryCredentials).To(BeNil())
+	g.Expect(config.Status.ReplacedRegistryCredentials).To(HaveLen(1))
+
+	requeueAfter, err := r.deleteReplacedRegistryCredentials(cluster, config, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeueAfter).To(BeNumerically(">", 0))
+	g.Expect(requeueAfter).To(BeNumerically("<=", replacedRegistryCredentialsGracePeriod))
+	g.Expect(svc.deleted).To(BeEmpty())
+	g.Expect(config.Status.ReplacedRegistryCredentials).To(HaveLen(1))
+
+	// Only credentials whose grace period passed are deleted.
+	config.Status.ReplacedRegistryCredentials[0].ReplacedAt = ptr.To(metav1.NewTime(time.Now().Add(-2 * replacedRegistryCredentialsGracePeriod)))
+	config.Status.RegistryCredentials = &eksbootstrapv1.RegistryCredentials{
+		SecureSecretsBackend: infrav1.SecretBackendSecretsManager,
+		Prefix:               "aws.cluster.x-k8s.io/eksconfig/uid/second",
+		Count:                1,
+	}
+	replaceRegistryCredentials(config)
+
+	requeueAfter, err = r.deleteReplacedRegistryCredentials(cluster, config, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeueAfter).To(BeNumerically(">", 0))
+	g.Expect(svc.deleted).To(Equal([]string{"aws.cluster.x-k8s.io/eksconfig/uid/first"}))
+	g.Expect(config.Status.ReplacedRegistryCredentials).To(HaveLen(1))
+	g.Expect(config.Status.ReplacedRegistryCredentials[0].Prefix).To(Equal("aws.cluster.x-k8s.io/eksconfig/uid/second"))
+
+	// All credentials are deleted with the config.
+	config.Status.RegistryCredentials = &eksbootstrapv1.RegistryCredentials{
+		SecureSecretsBackend: infrav1.SecretBackendSecretsManager,
+		Prefix:               "aws.cluster.x-k8s.io/eksconfig/uid/third",
+		Count:                1,
+	}
+	g.Expect(r.deleteRegistryCredentials(cluster, config, controlPlane)).To(Succeed())
+	g.Expect(svc.deleted).To(Equal([]string{
+		"aws.cluster.x-k8s.io/eksconfig/uid/first",
+		"aws.cluster.x-k8s.io/eksconfig/uid/second",
+		"aws.cluster.x-k8s.io/eksconfig/uid/third",
+	}))
+	g.Expect(config.Status.RegistryCredentials).To(BeNil())
+	g.Expect(config.Status.ReplacedRegistryCredentials).To(BeNil())
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	nodeLabelsArg         = "node-labels"
+	registerWithTaintsArg = "register-with-taints"
+	maxPodsArg            = "max-pods"
+)
+
+// BottlerocketInput defines the context to generate the user data of a Bottlerocket node.
+type BottlerocketInput struct {
+	ClusterName string
+	// APIServerEndpoint is the URL of the Kubernetes API server of the cluster.
+	APIServerEndpoint string
+	// CACert is the base64 encoded certificate authority of the cluster.
+	CACert string
+
+	// KubeletExtraArgs are translated to node labels, taints and max pods. Other
+	// arguments aren't supported by Bottlerocket.
+	KubeletExtraArgs map[string]string
+	// KubeletConfiguration is translated to the equivalent Bottlerocket settings. Feature
+	// gates aren't supported by Bottlerocket.
+	KubeletConfiguration *eksbootstrapv1.KubeletConfiguration
+	DNSClusterIP         *string
+	PauseContainerImage  string
+	BootstrapContainers  []eksbootstrapv1.BottlerocketBootstrapContainer
+	HostContainers       []eksbootstrapv1.BottlerocketHostContainer
+}
+
+// bottlerocketUserData is the subset of the Bottlerocket settings that's generated.
+// See https://bottlerocket.dev/en/os/latest/api/settings/.
+type bottlerocketUserData struct {
+	Settings bottlerocketSettings `toml:"settings"`
+}
+
+type bottlerocketSettings struct {
+	Kubernetes          bottlerocketKubernetes                    `toml:"kubernetes"`
+	HostContainers      map[string]bottlerocketHostContainer      `toml:"host-containers,omitempty"`
+	BootstrapContainers map[string]bottlerocketBootstrapContainer `toml:"bootstrap-containers,omitempty"`
+}
+
+type bottlerocketKubernetes struct {
+	ClusterName            string              `toml:"cluster-name"`
+	APIServer              string              `toml:"api-server"`
+	ClusterCertificate     string              `toml:"cluster-certificate"`
+	ClusterDNSIP           string              `toml:"cluster-dns-ip,omitempty"`
+	MaxPods                *int                `toml:"max-pods,omitempty"`
+	PodInfraContainerImage string              `toml:"pod-infra-container-image,omitempty"`
+	NodeLabels             map[string]string   `toml:"node-labels,omitempty"`
+	NodeTaints             map[string][]string `toml:"node-taints,omitempty"`
+
+	EvictionHard                map[string]string `toml:"eviction-hard,omitempty"`
+	EvictionSoft                map[string]string `toml:"eviction-soft,omitempty"`
+	EvictionSoftGracePeriod     map[string]string `toml:"eviction-soft-grace-period,omitempty"`
+	SystemReserved              map[string]string `toml:"system-reserved,omitempty"`
+	KubeReserved                map[string]string `toml:"kube-reserved,omitempty"`
+	ImageGCHighThresholdPercent *int32            `toml:"image-gc-high-threshold-percent,omitempty"`
+	ImageGCLowThresholdPercent  *int32            `toml:"image-gc-low-threshold-percent,omitempty"`
+	TopologyManagerPolicy       string            `toml:"topology-manager-policy,omitempty"`
+	TopologyManagerScope        string            `toml:"topology-manager-scope,omitempty"`
+}
+
+type bottlerocketHostContainer struct {
+	Source       string `toml:"source,omitempty"`
+	Enabled      *bool  `toml:"enabled,omitempty"`
+	Superpowered *bool  `toml:"superpowered,omitempty"`
+	UserData     string `toml:"user-data,omitempty"`
+}
+
+type bottlerocketBootstrapContainer struct {
+	Source    string `toml:"source"`
+	Mode      string `toml:"mode,omitempty"`
+	Essential bool   `toml:"essential"`
+	UserData  string `toml:"user-data,omitempty"`
+}
+
+// NewBottlerocket returns the TOML user data to be used on a Bottlerocket node instance.
+func NewBottlerocket(input *BottlerocketInput) ([]byte, error) {
+	kubernetes := bottlerocketKubernetes{
+		ClusterName:            input.ClusterName,
+		APIServer:              input.APIServerEndpoint,
+		ClusterCertificate:     input.CACert,
+		PodInfraContainerImage: input.PauseContainerImage,
+	}
+	if input.DNSClusterIP != nil {
+		kubernetes.ClusterDNSIP = *input.DNSClusterIP
+	}
+
+	if labels, ok := input.KubeletExtraArgs[nodeLabelsArg]; ok {
+		nodeLabels, err := parseNodeLabels(labels)
+		if err != nil {
+			return nil, err
+		}
+		kubernetes.NodeLabels = nodeLabels
+	}
+	if taints, ok := input.KubeletExtraArgs[registerWithTaintsArg]; ok {
+		nodeTaints, err := parseNodeTaints(taints)
+		if err != nil {
+			return nil, err
+		}
+		kubernetes.NodeTaints = nodeTaints
+	}
+	if maxPods, ok := input.KubeletExtraArgs[maxPodsArg]; ok {
+		n, err := strconv.Atoi(maxPods)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s kubelet argument %q: %w", maxPodsArg, maxPods, err)
+		}
+		kubernetes.MaxPods = &n
+	}
+
+	if kc := input.KubeletConfiguration; kc != nil {
+		kubernetes.EvictionHard = kc.EvictionHard
+		kubernetes.EvictionSoft = kc.EvictionSoft
+		kubernetes.EvictionSoftGracePeriod = kc.EvictionSoftGracePeriod
+		kubernetes.SystemReserved = kc.SystemReserved
+		kubernetes.KubeReserved = kc.KubeReserved
+		kubernetes.ImageGCHighThresholdPercent = kc.ImageGCHighThresholdPercent
+		kubernetes.ImageGCLowThresholdPercent = kc.ImageGCLowThresholdPercent
+		kubernetes.TopologyManagerPolicy = kc.TopologyManagerPolicy
+		kubernetes.TopologyManagerScope = kc.TopologyManagerScope
+		if kc.MaxPods != nil {
+			n := int(*kc.MaxPods)
+			kubernetes.MaxPods = &n
+		}
+	}
+
+	userData := bottlerocketUserData{
+		Settings: bottlerocketSettings{Kubernetes: kubernetes},
+	}
+
+	for _, container := range input.HostContainers {
+		if userData.Settings.HostContainers == nil {
+			userData.Settings.HostContainers = map[string]bottlerocketHostContainer{}
+		}
+		userData.Settings.HostContainers[container.Name] = bottlerocketHostContainer{
+			Source:       container.Source,
+			Enabled:      container.Enabled,
+			Superpowered: container.Superpowered,
+			UserData:     encodeContainerUserData(container.UserData),
+		}
+	}
+
+	for _, container := range input.BootstrapContainers {
+		if userData.Settings.BootstrapContainers == nil {
+			userData.Settings.BootstrapContainers = map[string]bottlerocketBootstrapContainer{}
+		}
+		userData.Settings.BootstrapContainers[container.Name] = bottlerocketBootstrapContainer{
+			Source:    container.Source,
+			Mode:      container.Mode,
+			Essential: container.Essential,
+			UserData:  encodeContainerUserData(container.UserData),
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := toml.NewEncoder(&out)
+	encoder.Indent = ""
+	if err := encoder.Encode(userData); err != nil {
+		return nil, fmt.Errorf("failed to generate Bottlerocket settings: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+func encodeContainerUserData(userData string) string {
+	if userData == "" {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(userData))
+}
+
+// parseNodeLabels parses the value of the --node-labels kubelet argument, e.g. "a=b,c=d".
+func parseNodeLabels(labels string) (map[string]string, error) {
+	nodeLabels := map[string]string{}
+	for _, label := range strings.Split(labels, ",") {
+		if label == "" {
+			continue
+		}
+		key, value, found := strings.Cut(label, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid node label %q", label)
+		}
+		nodeLabels[key] = value
+	}
+	return nodeLabels, nil
+}
+
+// parseNodeTaints parses the value of the --register-with-taints kubelet argument,
+// e.g. "a=b:NoSchedule,c:NoExecute".
+func parseNodeTaints(taints string) (map[string][]string, error) {
+	nodeTaints := map[string][]string{}
+	for _, taint := range strings.Split(taints, ",") {
+		if taint == "" {
+			continue
+		}
+		keyValue, effect, found := strings.Cut(taint, ":")
+		if !found || effect == "" {
+			return nil, fmt.Errorf("invalid node taint %q", taint)
+		}
+		key, value, _ := strings.Cut(keyValue, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid node taint %q", taint)
+		}
+		nodeTaints[key] = append(nodeTaints[key], fmt.Sprintf("%s:%s", value, effect))
+	}
+	return nodeTaints, nil
+}
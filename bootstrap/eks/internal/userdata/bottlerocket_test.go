@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestNewBottlerocket(t *testing.T) {
+	format.TruncatedDiff = false
+
+	tests := []struct {
+		name      string
+		input     *BottlerocketInput
+		expectErr bool
+	}{
+		{
+			name: "bottlerocket-cluster-only",
+			input: &BottlerocketInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+			},
+		},
+		{
+			name: "bottlerocket-with-values",
+			input: &BottlerocketInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				KubeletExtraArgs: map[string]string{
+					"node-labels":          "node-role.undistro.io/infra=true,team=a",
+					"register-with-taints": "dedicated=infra:NoSchedule,dedicated=infra:NoExecute,spot:PreferNoSchedule",
+					"max-pods":             "58",
+				},
+				DNSClusterIP:        ptr.To("10.100.0.10"),
+				PauseContainerImage: "602401143452.dkr.ecr.us-east-1.amazonaws.com/eks/pause:3.9",
+				HostContainers: []eksbootstrapv1.BottlerocketHostContainer{
+					{
+						Name:         "admin",
+						Enabled:      ptr.To(true),
+						Superpowered: ptr.To(true),
+						UserData:     `{"ssh":{"authorized-keys":["ssh-rsa AAAA"]}}`,
+					},
+				},
+				BootstrapContainers: []eksbootstrapv1.BottlerocketBootstrapContainer{
+					{
+						Name:      "setup",
+						Source:    "example.com/setup:latest",
+						Mode:      "once",
+						Essential: true,
+						UserData:  "#!/bin/bash\necho setup\n",
+					},
+				},
+			},
+		},
+		{
+			name: "bottlerocket-kubelet-configuration",
+			input: &BottlerocketInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				KubeletConfiguration: &eksbootstrapv1.KubeletConfiguration{
+					EvictionHard:                map[string]string{"memory.available": "300Mi", "nodefs.available": "10%"},
+					SystemReserved:              map[string]string{"cpu": "100m", "memory": "100Mi"},
+					MaxPods:                     ptr.To[int32](110),
+					ImageGCHighThresholdPercent: ptr.To[int32](85),
+					ImageGCLowThresholdPercent:  ptr.To[int32](80),
+					TopologyManagerPolicy:       "single-numa-node",
+				},
+			},
+		},
+		{
+			name: "invalid taint",
+			input: &BottlerocketInput{
+				ClusterName:      "test-cluster",
+				KubeletExtraArgs: map[string]string{"register-with-taints": "dedicated=infra"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid max pods",
+			input: &BottlerocketInput{
+				ClusterName:      "test-cluster",
+				KubeletExtraArgs: map[string]string{"max-pods": "many"},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			output, err := NewBottlerocket(tt.input)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			expectGolden(g, tt.name, output)
+		})
+	}
+}
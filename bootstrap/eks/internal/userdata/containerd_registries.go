@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+// ContainerdRegistriesDir is the directory containerd reads the hosts configuration of
+// registries from.
+const ContainerdRegistriesDir = "/etc/containerd/certs.d"
+
+// NewContainerdRegistryFiles returns the hosts.toml files and certificate authority bundles of
+// the registries. Authorizations are the values of the authorization header of hosts, by the
+// name of their auth secret. The hosts.toml files of registries with credentials are returned
+// separately, with paths relative to ContainerdRegistriesDir.
+func NewContainerdRegistryFiles(registries []eksbootstrapv1.ContainerdRegistry, authorizations map[string]string) ([]eksbootstrapv1.File, []eksbootstrapv1.File) {
+	var files, sensitiveFiles []eksbootstrapv1.File
+
+	for _, registry := range registries {
+		dir := path.Join(ContainerdRegistriesDir, registry.Name)
+		sensitive := false
+
+		var hosts strings.Builder
+		if registry.Server != "" {
+			fmt.Fprintf(&hosts, "server = %s\n", strconv.Quote(registry.Server))
+		}
+		for _, mirror := range registry.Mirrors {
+			host := strconv.Quote(mirror.URL)
+			fmt.Fprintf(&hosts, "\n[host.%s]\n", host)
+
+			capabilities := mirror.Capabilities
+			if len(capabilities) == 0 {
+				capabilities = []eksbootstrapv1.ContainerdRegistryCapability{
+					eksbootstrapv1.ContainerdRegistryCapabilityPull,
+					eksbootstrapv1.ContainerdRegistryCapabilityResolve,
+				}
+			}
+			quoted := make([]string, 0, len(capabilities))
+			for _, capability := range capabilities {
+				quoted = append(quoted, strconv.Quote(string(capability)))
+			}
+			fmt.Fprintf(&hosts, "  capabilities = [%s]\n", strings.Join(quoted, ", "))
+
+			if mirror.CABundle != "" {
+				caPath := path.Join(dir, hostFileName(mirror.URL)+".crt")
+				fmt.Fprintf(&hosts, "  ca = %s\n", strconv.Quote(caPath))
+				files = append(files, eksbootstrapv1.File{
+					Path:        caPath,
+					Owner:       "root:root",
+					Permissions: "0644",
+					Content:     mirror.CABundle,
+				})
+			}
+			if mirror.SkipVerify {
+				hosts.WriteString("  skip_verify = true\n")
+			}
+			if mirror.AuthSecretRef != nil {
+				sensitive = true
+				fmt.Fprintf(&hosts, "  [host.%s.header]\n    authorization = [%s]\n", host, strconv.Quote(authorizations[mirror.AuthSecretRef.Name]))
+			}
+		}
+
+		if sensitive {
+			sensitiveFiles = append(sensitiveFiles, eksbootstrapv1.File{
+				Path:        path.Join(registry.Name, "hosts.toml"),
+				Permissions: "0600",
+				Content:     hosts.String(),
+			})
+			continue
+		}
+		files = append(files, eksbootstrapv1.File{
+			Path:        path.Join(dir, "hosts.toml"),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     hosts.String(),
+		})
+	}
+
+	return files, sensitiveFiles
+}
+
+// NewContainerdRegistryArchive returns a gzipped tar archive of the files. The archive only
+// depends on the files, so the same files always result in the same archive.
+func NewContainerdRegistryArchive(files []eksbootstrapv1.File) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	dirs := map[string]bool{}
+	for _, file := range files {
+		dir := path.Dir(file.Path)
+		if dir != "." && !dirs[dir] {
+			dirs[dir] = true
+			if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir + "/", Mode: 0o755}); err != nil {
+				return nil, fmt.Errorf("failed to write directory %s: %w", dir, err)
+			}
+		}
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: file.Path, Mode: 0o600, Size: int64(len(file.Content))}); err != nil {
+			return nil, fmt.Errorf("failed to write file %s: %w", file.Path, err)
+		}
+		if _, err := tw.Write([]byte(file.Content)); err != nil {
+			return nil, fmt.Errorf("failed to write file %s: %w", file.Path, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// hostFileName returns a file name for the host of the URL.
+func hostFileName(url string) string {
+	host := url
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	host = strings.SplitN(host, "/", 2)[0]
+	return strings.ReplaceAll(host, ":", "_")
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestNewContainerdRegistryFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	registries := []eksbootstrapv1.ContainerdRegistry{
+		{
+			Name:   "docker.io",
+			Server: "https://registry-1.docker.io",
+			Mirrors: []eksbootstrapv1.ContainerdRegistryHost{
+				{
+					URL:      "https://mirror.example.com:5000/v2",
+					CABundle: "-----BEGIN CERTIFICATE-----",
+				},
+				{
+					URL:          "http://fallback.example.com",
+					Capabilities: []eksbootstrapv1.ContainerdRegistryCapability{eksbootstrapv1.ContainerdRegistryCapabilityPull},
+					SkipVerify:   true,
+				},
+			},
+		},
+		{
+			Name: "registry.example.com",
+			Mirrors: []eksbootstrapv1.ContainerdRegistryHost{
+				{
+					URL:           "https://registry.example.com",
+					AuthSecretRef: &corev1.LocalObjectReference{Name: "registry-auth"},
+				},
+			},
+		},
+	}
+
+	files, sensitiveFiles := NewContainerdRegistryFiles(registries, map[string]string{"registry-auth": "Basic dXNlcjpwYXNz"})
+	g.Expect(files).To(Equal([]eksbootstrapv1.File{
+		{
+			Path:        "/etc/containerd/certs.d/docker.io/mirror.example.com_5000.crt",
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     "-----BEGIN CERTIFICATE-----",
+		},
+		{
+			Path:        "/etc/containerd/certs.d/docker.io/hosts.toml",
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content: `server = "https://registry-1.docker.io"
+
+[host."https://mirror.example.com:5000/v2"]
+  capabilities = ["pull", "resolve"]
+  ca = "/etc/containerd/certs.d/docker.io/mirror.example.com_5000.crt"
+
+[host."http://fallback.example.com"]
+  capabilities = ["pull"]
+  skip_verify = true
+`,
+		},
+	}))
+	g.Expect(sensitiveFiles).To(Equal([]eksbootstrapv1.File{
+		{
+			Path:        "registry.example.com/hosts.toml",
+			Permissions: "0600",
+			Content: `
+[host."https://registry.example.com"]
+  capabilities = ["pull", "resolve"]
+  [host."https://registry.example.com".header]
+    authorization = ["Basic dXNlcjpwYXNz"]
+`,
+		},
+	}))
+
+	archive, err := NewContainerdRegistryArchive(sensitiveFiles)
+	g.Expect(err).NotTo(HaveOccurred())
+	again, err := NewContainerdRegistryArchive(sensitiveFiles)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(again).To(Equal(archive), "archive should be deterministic")
+
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	g.Expect(err).NotTo(HaveOccurred())
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).NotTo(HaveOccurred())
+		names = append(names, header.Name)
+		if header.Typeflag == tar.TypeReg {
+			content, err := io.ReadAll(tr)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(content)).To(Equal(sensitiveFiles[0].Content))
+		}
+	}
+	g.Expect(names).To(Equal([]string{"registry.example.com/", "registry.example.com/hosts.toml"}))
+}
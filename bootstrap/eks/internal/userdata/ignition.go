@@ -0,0 +1,247 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	ignition "github.com/coreos/ignition/v2/config/v3_4/types"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	ignitionVersion = "3.4.0"
+
+	// defaultIgnitionBootstrapCommand is the EKS bootstrap script of the Flatcar AMIs.
+	defaultIgnitionBootstrapCommand = "/usr/share/amazon/eks/bootstrap.sh"
+
+	ignitionBootstrapScriptPath = "/opt/eks/bootstrap-node.sh"
+	ignitionBootstrapUnitName   = "eks-bootstrap.service"
+	ignitionBootstrapDonePath   = "/var/lib/eks-bootstrap/done"
+	ignitionNTPConfigPath       = "/etc/systemd/timesyncd.conf.d/eks.conf"
+	ignitionSudoersDir          = "/etc/sudoers.d"
+
+	ignitionBootstrapScript = `#!/bin/bash
+{{- range .PreBootstrapCommands }}
+{{ . }}
+{{- end }}
+{{ .BootstrapCommand }} {{.ClusterName}} {{- template "args" . }}
+{{- range .PostBootstrapCommands }}
+{{ . }}
+{{- end }}
+`
+
+	// The unit runs once, like the runcmd of cloud-init.
+	ignitionBootstrapUnit = `[Unit]
+Description=Bootstrap the node into the EKS cluster
+Wants=network-online.target
+After=network-online.target
+ConditionPathExists=!` + ignitionBootstrapDonePath + `
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=` + ignitionBootstrapScriptPath + `
+ExecStartPost=/usr/bin/mkdir -p /var/lib/eks-bootstrap
+ExecStartPost=/usr/bin/touch ` + ignitionBootstrapDonePath + `
+
+[Install]
+WantedBy=multi-user.target
+`
+)
+
+// NewIgnition returns the Ignition config to be used on a node instance. The pre-bootstrap
+// commands, bootstrap command and post-bootstrap commands are written to a script which is
+// run by a systemd unit, and the files, users and NTP servers are translated to Ignition.
+// Disks and mounts aren't supported.
+func NewIgnition(input *NodeInput) ([]byte, error) {
+	input, err := withKubeletConfiguration(input)
+	if err != nil {
+		return nil, err
+	}
+	input = withMaxPods(input)
+	if input.BootstrapCommandOverride == nil || *input.BootstrapCommandOverride == "" {
+		withCommand := *input
+		withCommand.BootstrapCommandOverride = ptr.To(defaultIgnitionBootstrapCommand)
+		input = &withCommand
+	}
+
+	script, err := ignitionScript(input)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ignition.Config{
+		Ignition: ignition.Ignition{Version: ignitionVersion},
+		Systemd: ignition.Systemd{
+			Units: []ignition.Unit{{
+				Name:     ignitionBootstrapUnitName,
+				Enabled:  ptr.To(true),
+				Contents: ptr.To(ignitionBootstrapUnit),
+			}},
+		},
+	}
+
+	files := append([]eksbootstrapv1.File{}, input.Files...)
+	files = append(files, eksbootstrapv1.File{
+		Path:        ignitionBootstrapScriptPath,
+		Owner:       "root:root",
+		Permissions: "0755",
+		Content:     script,
+	})
+	if ntp := input.NTP; ntp != nil && ptr.Deref(ntp.Enabled, true) && len(ntp.Servers) > 0 {
+		files = append(files, eksbootstrapv1.File{
+			Path:        ignitionNTPConfigPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     fmt.Sprintf("[Time]\nNTP=%s\n", strings.Join(ntp.Servers, " ")),
+		})
+	}
+
+	for _, user := range input.Users {
+		config.Passwd.Users = append(config.Passwd.Users, ignitionUser(user))
+		if user.Sudo != nil && *user.Sudo != "" {
+			files = append(files, eksbootstrapv1.File{
+				Path:        ignitionSudoersDir + "/" + user.Name,
+				Owner:       "root:root",
+				Permissions: "0440",
+				Content:     fmt.Sprintf("%s %s\n", user.Name, *user.Sudo),
+			})
+		}
+	}
+
+	for _, file := range files {
+		ignitionFile, err := newIgnitionFile(file)
+		if err != nil {
+			return nil, err
+		}
+		config.Storage.Files = append(config.Storage.Files, ignitionFile)
+	}
+
+	out, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Ignition config: %w", err)
+	}
+
+	return out, nil
+}
+
+// ignitionScript returns the script which bootstraps the node.
+func ignitionScript(input *NodeInput) (string, error) {
+	tm := template.New("Ignition").Funcs(defaultTemplateFuncMap)
+	if _, err := tm.Parse(argsTemplate); err != nil {
+		return "", fmt.Errorf("failed to parse args template: %w", err)
+	}
+	if _, err := tm.Parse(kubeletArgsTemplate); err != nil {
+		return "", fmt.Errorf("failed to parse kubeletExtraArgs template: %w", err)
+	}
+	t, err := tm.Parse(ignitionBootstrapScript)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Ignition bootstrap script template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, input); err != nil {
+		return "", fmt.Errorf("failed to generate Ignition bootstrap script: %w", err)
+	}
+
+	return out.String(), nil
+}
+
+// newIgnitionFile translates a file to an Ignition file. The content is embedded as a data URL.
+func newIgnitionFile(file eksbootstrapv1.File) (ignition.File, error) {
+	var contents ignition.Resource
+	switch file.Encoding {
+	case eksbootstrapv1.Base64:
+		contents.Source = ptr.To(dataURL(file.Content))
+	case eksbootstrapv1.Gzip:
+		contents.Source = ptr.To(dataURL(base64.StdEncoding.EncodeToString([]byte(file.Content))))
+		contents.Compression = ptr.To("gzip")
+	case eksbootstrapv1.GzipBase64:
+		contents.Source = ptr.To(dataURL(file.Content))
+		contents.Compression = ptr.To("gzip")
+	default:
+		contents.Source = ptr.To(dataURL(base64.StdEncoding.EncodeToString([]byte(file.Content))))
+	}
+
+	ignitionFile := ignition.File{
+		Node: ignition.Node{Path: file.Path},
+	}
+	if file.Append {
+		ignitionFile.Append = []ignition.Resource{contents}
+	} else {
+		ignitionFile.Contents = contents
+		ignitionFile.Overwrite = ptr.To(true)
+	}
+
+	if file.Permissions != "" {
+		mode, err := strconv.ParseUint(file.Permissions, 8, 32)
+		if err != nil {
+			return ignition.File{}, fmt.Errorf("invalid permissions %q of file %s: %w", file.Permissions, file.Path, err)
+		}
+		ignitionFile.Mode = ptr.To(int(mode))
+	}
+
+	if file.Owner != "" {
+		user, group, _ := strings.Cut(file.Owner, ":")
+		if user != "" {
+			ignitionFile.User.Name = ptr.To(user)
+		}
+		if group != "" {
+			ignitionFile.Group.Name = ptr.To(group)
+		}
+	}
+
+	return ignitionFile, nil
+}
+
+// ignitionUser translates a user to an Ignition user. Inactive and LockPassword aren't
+// supported by Ignition.
+func ignitionUser(user eksbootstrapv1.User) ignition.PasswdUser {
+	ignitionUser := ignition.PasswdUser{
+		Name:         user.Name,
+		Gecos:        user.Gecos,
+		HomeDir:      user.HomeDir,
+		Shell:        user.Shell,
+		PrimaryGroup: user.PrimaryGroup,
+		PasswordHash: user.Passwd,
+	}
+	if user.Groups != nil {
+		for _, group := range strings.Split(*user.Groups, ",") {
+			if group = strings.TrimSpace(group); group != "" {
+				ignitionUser.Groups = append(ignitionUser.Groups, ignition.Group(group))
+			}
+		}
+	}
+	for _, key := range user.SSHAuthorizedKeys {
+		ignitionUser.SSHAuthorizedKeys = append(ignitionUser.SSHAuthorizedKeys, ignition.SSHAuthorizedKey(key))
+	}
+
+	return ignitionUser
+}
+
+func dataURL(base64Data string) string {
+	return "data:;base64," + strings.Join(strings.Fields(base64Data), "")
+}
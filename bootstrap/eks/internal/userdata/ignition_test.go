@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	ignitionconfig "github.com/coreos/ignition/v2/config/v3_4"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestNewIgnition(t *testing.T) {
+	format.TruncatedDiff = false
+
+	tests := []struct {
+		name      string
+		input     *NodeInput
+		expectErr bool
+	}{
+		{
+			name: "ignition-cluster-only",
+			input: &NodeInput{
+				ClusterName: "test-cluster",
+			},
+		},
+		{
+			name: "ignition-with-values",
+			input: &NodeInput{
+				ClusterName: "test-cluster",
+				KubeletExtraArgs: map[string]string{
+					"node-labels": "node-role.undistro.io/infra=true",
+				},
+				DNSClusterIP:          ptr.To("10.100.0.10"),
+				PreBootstrapCommands:  []string{"echo pre"},
+				PostBootstrapCommands: []string{"echo post"},
+				Files: []eksbootstrapv1.File{
+					{
+						Path:        "/etc/example.conf",
+						Owner:       "core:core",
+						Permissions: "0600",
+						Content:     "example\n",
+					},
+					{
+						Path:     "/etc/example.bin",
+						Encoding: eksbootstrapv1.Base64,
+						Content:  "ZXhhbXBsZQo=",
+						Append:   true,
+					},
+				},
+				Users: []eksbootstrapv1.User{
+					{
+						Name:              "admin",
+						Groups:            ptr.To("docker, wheel"),
+						Shell:             ptr.To("/bin/bash"),
+						Sudo:              ptr.To("ALL=(ALL) NOPASSWD:ALL"),
+						SSHAuthorizedKeys: []string{"ssh-rsa AAAA"},
+					},
+				},
+				NTP: &eksbootstrapv1.NTP{
+					Servers: []string{"169.254.169.123"},
+				},
+			},
+		},
+		{
+			name: "ignition-bootstrap-command-override",
+			input: &NodeInput{
+				ClusterName:              "test-cluster",
+				BootstrapCommandOverride: ptr.To("/opt/bin/bootstrap.sh"),
+				KubeletConfiguration: &eksbootstrapv1.KubeletConfiguration{
+					MaxPods: ptr.To[int32](58),
+				},
+			},
+		},
+		{
+			name: "invalid permissions",
+			input: &NodeInput{
+				ClusterName: "test-cluster",
+				Files: []eksbootstrapv1.File{
+					{
+						Path:        "/etc/example.conf",
+						Permissions: "rw-r--r--",
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			out, err := NewIgnition(tt.input)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			_, report, err := ignitionconfig.ParseCompatibleVersion(out)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(report.IsFatal()).To(BeFalse(), report.String())
+
+			var indented bytes.Buffer
+			g.Expect(json.Indent(&indented, out, "", "  ")).To(Succeed())
+			expectGolden(g, tt.name, indented.Bytes())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+)
+
+const (
+	maxPodsVar = "CAPA_MAX_PODS"
+
+	// maxPodsCalculatorCommand is the max pods calculator of the EKS optimized AMIs.
+	maxPodsCalculatorCommand = "/etc/eks/max-pods-calculator.sh"
+	// maxPodsCalculatorCNIVersion is the VPC CNI version passed to the max pods calculator,
+	// which only uses it to check whether prefix delegation is supported.
+	maxPodsCalculatorCNIVersion = "1.10.0"
+)
+
+// MaxPodsInput defines the VPC CNI modes of the cluster which the maximum number of pods of
+// a node is computed for.
+type MaxPodsInput struct {
+	CustomNetworking bool
+	PrefixDelegation bool
+}
+
+// withMaxPods returns a copy of the input which computes the maximum number of pods on the
+// node before bootstrap.sh is run, and passes it to the kubelet.
+func withMaxPods(input *NodeInput) *NodeInput {
+	if input.MaxPods == nil {
+		return input
+	}
+
+	merged := *input
+	merged.PreBootstrapCommands = append(append([]string{}, input.PreBootstrapCommands...), maxPodsCommand(input.MaxPods))
+	merged.KubeletExtraArgs = make(map[string]string, len(input.KubeletExtraArgs)+1)
+	for name, value := range input.KubeletExtraArgs {
+		merged.KubeletExtraArgs[name] = value
+	}
+	// The kubelet extra args are single quoted by the bootstrap.sh command.
+	merged.KubeletExtraArgs["max-pods"] = `'"${` + maxPodsVar + `}"'`
+	merged.UseMaxPods = ptr.To(false)
+
+	return &merged
+}
+
+// maxPodsCommand returns the command which sets the shell variable of the maximum number of pods.
+func maxPodsCommand(input *MaxPodsInput) string {
+	command := fmt.Sprintf("%s --instance-type-from-imds --cni-version %s", maxPodsCalculatorCommand, maxPodsCalculatorCNIVersion)
+	if input.PrefixDelegation {
+		command += " --cni-prefix-delegation-enabled"
+	}
+	if input.CustomNetworking {
+		command += " --cni-custom-networking-enabled"
+	}
+
+	return fmt.Sprintf("%s=$(%s)", maxPodsVar, command)
+}
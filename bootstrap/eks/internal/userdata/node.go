@@ -18,10 +18,12 @@ package userdata
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"text/template"
 
 	"github.com/alessio/shellescape"
+	"k8s.io/utils/ptr"
 
 	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
 )
@@ -29,6 +31,16 @@ import (
 const (
 	defaultBootstrapCommand = "/etc/eks/bootstrap.sh"
 
+	kubeletConfigPath          = "/etc/kubernetes/kubelet/kubelet-config.json"
+	kubeletConfigOverridesPath = "/etc/kubernetes/kubelet/kubelet-config-overrides.json"
+	kubeletConfigDropInPath    = "/etc/systemd/system/kubelet.service.d/40-kubelet-config-overrides.conf"
+
+	// bootstrap.sh writes evictionHard, kubeReserved, clusterDNS and maxPods to the kubelet
+	// configuration file and then starts the kubelet, so the overrides are merged by the kubelet
+	// unit right before it starts. The merge is idempotent, so it's safe on every restart.
+	kubeletConfigDropIn = `[Service]
+ExecStartPre=/bin/sh -c "jq -s '.[0] * .[1]' ` + kubeletConfigPath + ` ` + kubeletConfigOverridesPath + ` > ` + kubeletConfigPath + `.tmp && mv ` + kubeletConfigPath + `.tmp ` + kubeletConfigPath + `"`
+
 	nodeUserData = `#cloud-config
 {{template "files" .Files}}
 runcmd:
@@ -45,17 +57,17 @@ runcmd:
 
 // NodeInput defines the context to generate a node user data.
 type NodeInput struct {
-	ClusterName           string
-	KubeletExtraArgs      map[string]string
-	ContainerRuntime      *string
-	DNSClusterIP          *string
-	DockerConfigJSON      *string
-	APIRetryAttempts      *int
-	PauseContainerAccount *string
-	PauseContainerVersion *string
-	UseMaxPods            *bool
-	// NOTE: currently the IPFamily/ServiceIPV6Cidr isn't exposed to the user.
-	// TODO (richardcase): remove the above comment when IPV6 / dual stack is implemented.
+	ClusterName              string
+	KubeletExtraArgs         map[string]string
+	KubeletConfiguration     *eksbootstrapv1.KubeletConfiguration
+	ContainerRuntime         *string
+	DNSClusterIP             *string
+	DockerConfigJSON         *string
+	APIRetryAttempts         *int
+	PauseContainerAccount    *string
+	PauseContainerVersion    *string
+	UseMaxPods               *bool
+	MaxPods                  *MaxPodsInput
 	IPFamily                 *string
 	ServiceIPV6Cidr          *string
 	PreBootstrapCommands     []string
@@ -88,7 +100,66 @@ func (ni *NodeInput) BootstrapCommand() string {
 
 // NewNode returns the user data string to be used on a node instance.
 func NewNode(input *NodeInput) ([]byte, error) {
-	tm := template.New("Node").Funcs(defaultTemplateFuncMap)
+	t, err := parseCloudConfigTemplate("Node", nodeUserData)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err = withKubeletConfiguration(input)
+	if err != nil {
+		return nil, err
+	}
+	input = withMaxPods(input)
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, input); err != nil {
+		return nil, fmt.Errorf("failed to generate Node template: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// withKubeletConfiguration returns a copy of the input which writes the kubelet configuration
+// to a file, and a kubelet unit drop-in which merges it into the kubelet configuration file
+// after bootstrap.sh has written it.
+func withKubeletConfiguration(input *NodeInput) (*NodeInput, error) {
+	if input.KubeletConfiguration == nil {
+		return input, nil
+	}
+
+	config, err := json.MarshalIndent(input.KubeletConfiguration, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal kubelet configuration: %w", err)
+	}
+
+	merged := *input
+	merged.Files = append(append([]eksbootstrapv1.File{}, input.Files...),
+		eksbootstrapv1.File{
+			Path:        kubeletConfigOverridesPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     string(config),
+		},
+		eksbootstrapv1.File{
+			Path:        kubeletConfigDropInPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     kubeletConfigDropIn,
+		},
+	)
+
+	// bootstrap.sh doesn't need to compute maxPods if it's overridden.
+	if input.KubeletConfiguration.MaxPods != nil && input.UseMaxPods == nil {
+		merged.UseMaxPods = ptr.To(false)
+	}
+
+	return &merged, nil
+}
+
+// parseCloudConfigTemplate parses a cloud-config template together with the templates it
+// can refer to.
+func parseCloudConfigTemplate(name, text string) (*template.Template, error) {
+	tm := template.New(name).Funcs(defaultTemplateFuncMap)
 
 	if _, err := tm.Parse(filesTemplate); err != nil {
 		return nil, fmt.Errorf("failed to parse args template: %w", err)
@@ -126,15 +197,10 @@ func NewNode(input *NodeInput) ([]byte, error) {
 		return nil, fmt.Errorf("failed to parse mounts template: %w", err)
 	}
 
-	t, err := tm.Parse(nodeUserData)
+	t, err := tm.Parse(text)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse Node template: %w", err)
+		return nil, fmt.Errorf("failed to parse %s template: %w", name, err)
 	}
 
-	var out bytes.Buffer
-	if err := t.Execute(&out, input); err != nil {
-		return nil, fmt.Errorf("failed to generate Node template: %w", err)
-	}
-
-	return out.Bytes(), nil
+	return t, nil
 }
@@ -131,6 +131,52 @@ runcmd:
 write_files:
 runcmd:
   - /etc/eks/bootstrap.sh test-cluster --use-max-pods false
+`),
+		},
+		{
+			name: "with prefix delegation max pods",
+			args: args{
+				input: &NodeInput{
+					ClusterName:      "test-cluster",
+					KubeletExtraArgs: map[string]string{"node-labels": "role=worker"},
+					MaxPods:          &MaxPodsInput{PrefixDelegation: true},
+				},
+			},
+			expectedBytes: []byte(`#cloud-config
+write_files:
+runcmd:
+  - "CAPA_MAX_PODS=$(/etc/eks/max-pods-calculator.sh --instance-type-from-imds --cni-version 1.10.0 --cni-prefix-delegation-enabled)"
+  - /etc/eks/bootstrap.sh test-cluster --kubelet-extra-args '--max-pods='"${CAPA_MAX_PODS}"' --node-labels=role=worker' --use-max-pods false
+`),
+		},
+		{
+			name: "with custom networking max pods",
+			args: args{
+				input: &NodeInput{
+					ClusterName: "test-cluster",
+					MaxPods:     &MaxPodsInput{CustomNetworking: true},
+				},
+			},
+			expectedBytes: []byte(`#cloud-config
+write_files:
+runcmd:
+  - "CAPA_MAX_PODS=$(/etc/eks/max-pods-calculator.sh --instance-type-from-imds --cni-version 1.10.0 --cni-custom-networking-enabled)"
+  - /etc/eks/bootstrap.sh test-cluster --kubelet-extra-args '--max-pods='"${CAPA_MAX_PODS}"'' --use-max-pods false
+`),
+		},
+		{
+			name: "with custom networking and prefix delegation max pods",
+			args: args{
+				input: &NodeInput{
+					ClusterName: "test-cluster",
+					MaxPods:     &MaxPodsInput{CustomNetworking: true, PrefixDelegation: true},
+				},
+			},
+			expectedBytes: []byte(`#cloud-config
+write_files:
+runcmd:
+  - "CAPA_MAX_PODS=$(/etc/eks/max-pods-calculator.sh --instance-type-from-imds --cni-version 1.10.0 --cni-prefix-delegation-enabled --cni-custom-networking-enabled)"
+  - /etc/eks/bootstrap.sh test-cluster --kubelet-extra-args '--max-pods='"${CAPA_MAX_PODS}"'' --use-max-pods false
 `),
 		},
 		{
@@ -386,3 +432,23 @@ users:
 		})
 	}
 }
+
+func TestNewNodeKubeletConfiguration(t *testing.T) {
+	g := NewWithT(t)
+
+	// The overrides are merged by the kubelet unit after bootstrap.sh has written the evictionHard,
+	// kubeReserved, clusterDNS and maxPods of the kubelet configuration file.
+	output, err := NewNode(&NodeInput{
+		ClusterName:           "test-cluster",
+		DNSClusterIP:          ptr.To("10.100.0.10"),
+		PreBootstrapCommands:  []string{"echo \"pre\""},
+		PostBootstrapCommands: []string{"echo \"post\""},
+		KubeletConfiguration: &eksbootstrapv1.KubeletConfiguration{
+			EvictionHard: map[string]string{"memory.available": "300Mi"},
+			KubeReserved: map[string]string{"cpu": "100m", "memory": "512Mi"},
+			MaxPods:      ptr.To[int32](110),
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	expectGolden(g, "node-kubelet-configuration", output)
+}
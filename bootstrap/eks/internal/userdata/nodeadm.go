@@ -0,0 +1,284 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"sort"
+	"text/template"
+
+	"sigs.k8s.io/yaml"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	nodeConfigAPIVersion  = "node.eks.aws/v1alpha1"
+	nodeConfigKind        = "NodeConfig"
+	nodeConfigContentType = "application/node.eks.aws"
+
+	cloudConfigContentType = `text/cloud-config; charset="us-ascii"`
+	shellScriptContentType = `text/x-shellscript; charset="us-ascii"`
+
+	nodeadmCloudConfig = `#cloud-config
+{{template "files" .Files}}
+runcmd:
+{{- template "commands" .PreBootstrapCommands }}
+{{- template "ntp" .NTP }}
+{{- template "users" .Users }}
+{{- template "disk_setup" .DiskSetup}}
+{{- template "fs_setup" .DiskSetup}}
+{{- template "mounts" .Mounts}}
+`
+
+	nodeadmPostBootstrapScriptPath = "/opt/eks/post-bootstrap.sh"
+	nodeadmPostBootstrapUnitName   = "eks-post-bootstrap.service"
+
+	// nodeadm-run.service only starts once cloud-init has finished, so the post-bootstrap
+	// commands can't be run by cloud-init itself. The script part installs a unit ordered after
+	// nodeadm-run.service and queues it without waiting for it. The unit isn't enabled, so it
+	// runs once, like the runcmd of cloud-init.
+	nodeadmPostBootstrap = `#!/bin/bash
+set -o errexit
+mkdir -p /opt/eks
+cat > ` + nodeadmPostBootstrapScriptPath + ` <<'EKS_POST_BOOTSTRAP'
+#!/bin/bash
+{{- range .PostBootstrapCommands }}
+{{ . }}
+{{- end }}
+EKS_POST_BOOTSTRAP
+chmod 0755 ` + nodeadmPostBootstrapScriptPath + `
+cat > /etc/systemd/system/` + nodeadmPostBootstrapUnitName + ` <<'EKS_POST_BOOTSTRAP'
+[Unit]
+Description=Run the post-bootstrap commands once the node is bootstrapped by nodeadm
+Requires=nodeadm-run.service
+After=nodeadm-run.service kubelet.service
+
+[Service]
+Type=oneshot
+ExecStart=` + nodeadmPostBootstrapScriptPath + `
+EKS_POST_BOOTSTRAP
+systemctl daemon-reload
+systemctl start --no-block ` + nodeadmPostBootstrapUnitName + `
+`
+)
+
+// NodeadmInput defines the context to generate the user data of a node bootstrapped by nodeadm.
+type NodeadmInput struct {
+	ClusterName string
+	// APIServerEndpoint is the URL of the Kubernetes API server of the cluster.
+	APIServerEndpoint string
+	// CACert is the base64 encoded certificate authority of the cluster.
+	CACert string
+	// ServiceCIDR is the CIDR block that Kubernetes service IP addresses are assigned from. It's
+	// required by nodeadm.
+	ServiceCIDR string
+
+	KubeletExtraArgs      map[string]string
+	KubeletConfiguration  *eksbootstrapv1.KubeletConfiguration
+	DNSClusterIP          *string
+	ContainerdConfig      string
+	PreBootstrapCommands  []string
+	PostBootstrapCommands []string
+	Files                 []eksbootstrapv1.File
+	DiskSetup             *eksbootstrapv1.DiskSetup
+	Mounts                []eksbootstrapv1.MountPoints
+	Users                 []eksbootstrapv1.User
+	NTP                   *eksbootstrapv1.NTP
+}
+
+// nodeConfig is the subset of the nodeadm NodeConfig API that's generated.
+// See https://awslabs.github.io/amazon-eks-ami/nodeadm/doc/api/.
+type nodeConfig struct {
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+	Spec       nodeConfigSpec `json:"spec"`
+}
+
+type nodeConfigSpec struct {
+	Cluster    clusterDetails     `json:"cluster"`
+	Kubelet    *kubeletOptions    `json:"kubelet,omitempty"`
+	Containerd *containerdOptions `json:"containerd,omitempty"`
+}
+
+type clusterDetails struct {
+	Name                 string `json:"name"`
+	APIServerEndpoint    string `json:"apiServerEndpoint"`
+	CertificateAuthority string `json:"certificateAuthority"`
+	CIDR                 string `json:"cidr"`
+}
+
+type kubeletOptions struct {
+	Config map[string]interface{} `json:"config,omitempty"`
+	Flags  []string               `json:"flags,omitempty"`
+}
+
+type containerdOptions struct {
+	Config string `json:"config,omitempty"`
+}
+
+// NewNodeadm returns a multipart MIME document containing the NodeConfig consumed by nodeadm,
+// followed by a cloud-config part for the files, pre-bootstrap commands, users and disk
+// configuration, and a shell script part that runs the post-bootstrap commands once nodeadm has
+// bootstrapped the node.
+func NewNodeadm(input *NodeadmInput) ([]byte, error) {
+	if input.ServiceCIDR == "" {
+		return nil, errors.New("service CIDR is required by nodeadm")
+	}
+
+	nodeConfig, err := newNodeConfig(input)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := yaml.Marshal(nodeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate NodeConfig: %w", err)
+	}
+
+	parts := []mimePart{{
+		contentType: nodeConfigContentType,
+		body:        append([]byte("---\n"), config...),
+	}}
+
+	if input.hasCloudConfig() {
+		t, err := parseCloudConfigTemplate("Nodeadm", nodeadmCloudConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		var out bytes.Buffer
+		if err := t.Execute(&out, input); err != nil {
+			return nil, fmt.Errorf("failed to generate Nodeadm template: %w", err)
+		}
+		parts = append(parts, mimePart{contentType: cloudConfigContentType, body: out.Bytes()})
+	}
+
+	if len(input.PostBootstrapCommands) > 0 {
+		t, err := template.New("NodeadmPostBootstrap").Parse(nodeadmPostBootstrap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Nodeadm post-bootstrap template: %w", err)
+		}
+
+		var out bytes.Buffer
+		if err := t.Execute(&out, input); err != nil {
+			return nil, fmt.Errorf("failed to generate Nodeadm post-bootstrap script: %w", err)
+		}
+		parts = append(parts, mimePart{contentType: shellScriptContentType, body: out.Bytes()})
+	}
+
+	return newMultipartDocument(parts)
+}
+
+func newNodeConfig(input *NodeadmInput) (*nodeConfig, error) {
+	config := &nodeConfig{
+		APIVersion: nodeConfigAPIVersion,
+		Kind:       nodeConfigKind,
+		Spec: nodeConfigSpec{
+			Cluster: clusterDetails{
+				Name:                 input.ClusterName,
+				APIServerEndpoint:    input.APIServerEndpoint,
+				CertificateAuthority: input.CACert,
+				CIDR:                 input.ServiceCIDR,
+			},
+		},
+	}
+
+	kubelet := &kubeletOptions{}
+	if input.KubeletConfiguration != nil {
+		// The kubelet configuration fields match the kubelet config file, so it's merged as is.
+		data, err := json.Marshal(input.KubeletConfiguration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal kubelet configuration: %w", err)
+		}
+		if err := json.Unmarshal(data, &kubelet.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal kubelet configuration: %w", err)
+		}
+		if len(kubelet.Config) == 0 {
+			kubelet.Config = nil
+		}
+	}
+	if input.DNSClusterIP != nil && *input.DNSClusterIP != "" {
+		if kubelet.Config == nil {
+			kubelet.Config = map[string]interface{}{}
+		}
+		kubelet.Config["clusterDNS"] = []string{*input.DNSClusterIP}
+	}
+	for k, v := range input.KubeletExtraArgs {
+		kubelet.Flags = append(kubelet.Flags, fmt.Sprintf("--%s=%s", k, v))
+	}
+	sort.Strings(kubelet.Flags)
+	if kubelet.Config != nil || kubelet.Flags != nil {
+		config.Spec.Kubelet = kubelet
+	}
+
+	if input.ContainerdConfig != "" {
+		config.Spec.Containerd = &containerdOptions{Config: input.ContainerdConfig}
+	}
+
+	return config, nil
+}
+
+func (ni *NodeadmInput) hasCloudConfig() bool {
+	return len(ni.Files) > 0 || len(ni.PreBootstrapCommands) > 0 ||
+		ni.NTP != nil || len(ni.Users) > 0 || ni.DiskSetup != nil || len(ni.Mounts) > 0
+}
+
+type mimePart struct {
+	contentType string
+	body        []byte
+}
+
+// newMultipartDocument returns a multipart MIME document of the parts. The boundary is derived
+// from the parts, so the same input always results in the same document.
+func newMultipartDocument(parts []mimePart) ([]byte, error) {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part.contentType))
+		hash.Write(part.body)
+	}
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(hex.EncodeToString(hash.Sum(nil))[:32]); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=%q\n\n", mpWriter.Boundary())
+
+	for _, part := range parts {
+		w, err := mpWriter.CreatePart(textproto.MIMEHeader{"Content-Type": {part.contentType}})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"k8s.io/utils/ptr"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the userdata tests")
+
+// expectGolden compares the output with the golden file testdata/<name>.golden. Run the
+// tests with -update to regenerate the golden files.
+func expectGolden(g *WithT, name string, output []byte) {
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		g.Expect(os.WriteFile(path, output, 0o600)).To(Succeed())
+	}
+
+	expected, err := os.ReadFile(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(output)).To(Equal(string(expected)))
+}
+
+func TestNewNodeadm(t *testing.T) {
+	format.TruncatedDiff = false
+
+	tests := []struct {
+		name  string
+		input *NodeadmInput
+	}{
+		{
+			name: "nodeadm-cluster-only",
+			input: &NodeadmInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				ServiceCIDR:       "10.100.0.0/16",
+			},
+		},
+		{
+			name: "nodeadm-kubelet-and-containerd",
+			input: &NodeadmInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				ServiceCIDR:       "10.100.0.0/16",
+				KubeletExtraArgs: map[string]string{
+					"register-with-taints": "dedicated=infra:NoSchedule",
+					"node-labels":          "node-role.undistro.io/infra=true",
+				},
+				DNSClusterIP:     ptr.To("10.100.0.10"),
+				ContainerdConfig: "[grpc]\naddress = \"/run/foo/foo.sock\"\n",
+			},
+		},
+		{
+			name: "nodeadm-kubelet-configuration",
+			input: &NodeadmInput{
+				ClusterName:       "test-cluster",
+				APIServerEndpoint: "https://example.com",
+				CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				ServiceCIDR:       "10.100.0.0/16",
+				DNSClusterIP:      ptr.To("10.100.0.10"),
+				KubeletConfiguration: &eksbootstrapv1.KubeletConfiguration{
+					EvictionHard:          map[string]string{"memory.available": "300Mi"},
+					KubeReserved:          map[string]string{"cpu": "100m"},
+					MaxPods:               ptr.To[int32](110),
+					FeatureGates:          map[string]bool{"InPlacePodVerticalScaling": true},
+					TopologyManagerPolicy: "best-effort",
+					TopologyManagerScope:  "pod",
+				},
+			},
+		},
+		{
+			name: "nodeadm-with-cloud-config",
+			input: &NodeadmInput{
+				ClusterName:           "test-cluster",
+				APIServerEndpoint:     "https://example.com",
+				CACert:                "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+				ServiceCIDR:           "10.100.0.0/16",
+				PreBootstrapCommands:  []string{"echo \"pre\""},
+				PostBootstrapCommands: []string{"echo \"post\""},
+				Files: []eksbootstrapv1.File{
+					{
+						Path:        "/etc/sysctl.d/91-fs.conf",
+						Owner:       "root:root",
+						Permissions: "0644",
+						Content:     "fs.inotify.max_user_instances=8192",
+					},
+				},
+				NTP: &eksbootstrapv1.NTP{
+					Enabled: ptr.To(true),
+					Servers: []string{"time1.example.com"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			output, err := NewNodeadm(tt.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			expectGolden(g, tt.name, output)
+
+			again, err := NewNodeadm(tt.input)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(again).To(Equal(output), "output should be deterministic")
+		})
+	}
+}
+
+func TestNewNodeadmRequiresServiceCIDR(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewNodeadm(&NodeadmInput{
+		ClusterName:       "test-cluster",
+		APIServerEndpoint: "https://example.com",
+		CACert:            "Y2VydGlmaWNhdGVBdXRob3JpdHk=",
+	})
+	g.Expect(err).To(MatchError(ContainSubstring("service CIDR is required")))
+}
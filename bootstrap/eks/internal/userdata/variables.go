@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+const (
+	imdsEndpoint = "http://169.254.169.254/latest"
+	imdsTokenVar = "CAPA_IMDS_TOKEN"
+)
+
+// Variables are the per-machine variables known when the bootstrap data is generated.
+// Variables which aren't known are resolved on the node from the instance metadata service.
+type Variables struct {
+	ClusterName      string
+	MachineName      string
+	MachinePoolName  string
+	Region           string
+	AvailabilityZone string
+}
+
+// nodeVariable is a variable which is resolved on the node from the instance metadata service.
+type nodeVariable struct {
+	envVar string
+	// path is the path of the instance metadata, relative to meta-data/.
+	path string
+}
+
+var nodeVariables = map[string]nodeVariable{
+	"InstanceID":       {envVar: "CAPA_INSTANCE_ID", path: "instance-id"},
+	"InstanceType":     {envVar: "CAPA_INSTANCE_TYPE", path: "instance-type"},
+	"AvailabilityZone": {envVar: "CAPA_AVAILABILITY_ZONE", path: "placement/availability-zone"},
+	"PrivateIPv4":      {envVar: "CAPA_PRIVATE_IPV4", path: "local-ipv4"},
+	"SubnetID":         {envVar: "CAPA_SUBNET_ID", path: "network/interfaces/macs/$(imds mac)/subnet-id"},
+}
+
+// RenderedTemplates are the files, pre-bootstrap commands and kubelet extra args of an
+// EKSConfig with the variables replaced.
+type RenderedTemplates struct {
+	Files                []eksbootstrapv1.File
+	PreBootstrapCommands []string
+	KubeletExtraArgs     map[string]string
+}
+
+// RenderTemplates renders the Go templates in the files, pre-bootstrap commands and kubelet
+// extra args of a node. Variables which aren't known are replaced with shell variables,
+// which are set from the instance metadata service by commands prepended to the
+// pre-bootstrap commands. As the kubelet extra args of the other formats aren't evaluated by
+// a shell, such variables can only be used in the kubelet extra args of the cloud-config and
+// ignition formats. If vars is nil, template variables are disabled and the text is returned
+// unchanged.
+func RenderTemplates(vars *Variables, format eksbootstrapv1.Format, files []eksbootstrapv1.File, preBootstrapCommands []string, kubeletExtraArgs map[string]string) (*RenderedTemplates, error) {
+	if vars == nil {
+		return &RenderedTemplates{
+			Files:                files,
+			PreBootstrapCommands: preBootstrapCommands,
+			KubeletExtraArgs:     kubeletExtraArgs,
+		}, nil
+	}
+
+	rendered := &RenderedTemplates{}
+	used := map[string]bool{}
+
+	var filesToResolve []string
+	for _, file := range files {
+		// Encoded content can't be rendered.
+		if file.Encoding == "" {
+			content, fileUsed, err := renderTemplate(vars, file.Content, nodeVariablePlaceholder)
+			if err != nil {
+				return nil, fmt.Errorf("failed to render file %s: %w", file.Path, err)
+			}
+			file.Content = content
+			if len(fileUsed) > 0 {
+				filesToResolve = append(filesToResolve, file.Path)
+			}
+			mergeUsed(used, fileUsed)
+		}
+		rendered.Files = append(rendered.Files, file)
+	}
+
+	for _, command := range preBootstrapCommands {
+		command, commandUsed, err := renderTemplate(vars, command, nodeVariablePlaceholder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render pre-bootstrap command: %w", err)
+		}
+		mergeUsed(used, commandUsed)
+		rendered.PreBootstrapCommands = append(rendered.PreBootstrapCommands, command)
+	}
+
+	if kubeletExtraArgs != nil {
+		rendered.KubeletExtraArgs = make(map[string]string, len(kubeletExtraArgs))
+	}
+	for name, value := range kubeletExtraArgs {
+		// The kubelet extra args are single quoted by the bootstrap.sh command.
+		value, argUsed, err := renderTemplate(vars, value, func(v nodeVariable) string {
+			return `'"` + nodeVariablePlaceholder(v) + `"'`
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to render kubelet extra arg %s: %w", name, err)
+		}
+		if len(argUsed) > 0 && format != "" && format != eksbootstrapv1.FormatCloudConfig && format != eksbootstrapv1.FormatIgnition {
+			return nil, fmt.Errorf("kubelet extra arg %s uses variables %s, which aren't known before the node starts and are only supported by the %s and %s formats",
+				name, strings.Join(argUsed, ", "), eksbootstrapv1.FormatCloudConfig, eksbootstrapv1.FormatIgnition)
+		}
+		mergeUsed(used, argUsed)
+		rendered.KubeletExtraArgs[name] = value
+	}
+
+	if len(used) > 0 {
+		rendered.PreBootstrapCommands = append(resolveCommands(used, filesToResolve), rendered.PreBootstrapCommands...)
+	}
+
+	return rendered, nil
+}
+
+// renderTemplate renders the template text. The variables which have to be resolved on the
+// node are replaced with the placeholder, and their names are returned.
+func renderTemplate(vars *Variables, text string, placeholder func(nodeVariable) string) (string, []string, error) {
+	// Skip parsing text which doesn't use templates.
+	if !strings.Contains(text, "{{") {
+		return text, nil, nil
+	}
+
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return "", nil, err
+	}
+
+	data := &templateData{vars: vars, placeholder: placeholder, used: map[string]bool{}}
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", nil, err
+	}
+
+	return out.String(), sortedKeys(data.used), nil
+}
+
+// templateData are the variables available to templates.
+type templateData struct {
+	vars        *Variables
+	placeholder func(nodeVariable) string
+	used        map[string]bool
+}
+
+func (d *templateData) node(name string) string {
+	d.used[name] = true
+	return d.placeholder(nodeVariables[name])
+}
+
+// ClusterName returns the name of the EKS cluster.
+func (d *templateData) ClusterName() string { return d.vars.ClusterName }
+
+// MachineName returns the name of the Machine, if the node belongs to a Machine.
+func (d *templateData) MachineName() string { return d.vars.MachineName }
+
+// MachinePoolName returns the name of the MachinePool, if the node belongs to a MachinePool.
+func (d *templateData) MachinePoolName() string { return d.vars.MachinePoolName }
+
+// Region returns the region of the cluster.
+func (d *templateData) Region() string { return d.vars.Region }
+
+// AvailabilityZone returns the availability zone of the node. It's resolved on the node
+// unless the failure domain of the Machine is known.
+func (d *templateData) AvailabilityZone() string {
+	if d.vars.AvailabilityZone != "" {
+		return d.vars.AvailabilityZone
+	}
+	return d.node("AvailabilityZone")
+}
+
+// InstanceID returns the EC2 instance ID of the node.
+func (d *templateData) InstanceID() string { return d.node("InstanceID") }
+
+// InstanceType returns the EC2 instance type of the node.
+func (d *templateData) InstanceType() string { return d.node("InstanceType") }
+
+// PrivateIPv4 returns the private IPv4 address of the node.
+func (d *templateData) PrivateIPv4() string { return d.node("PrivateIPv4") }
+
+// SubnetID returns the ID of the subnet of the primary network interface of the node.
+func (d *templateData) SubnetID() string { return d.node("SubnetID") }
+
+// nodeVariablePlaceholder returns a reference to the shell variable of the node variable.
+func nodeVariablePlaceholder(v nodeVariable) string {
+	return "${" + v.envVar + "}"
+}
+
+// resolveCommands returns the commands which set the shell variables of the node variables
+// from the instance metadata service, and replace their placeholders in the files.
+func resolveCommands(used map[string]bool, files []string) []string {
+	commands := []string{
+		fmt.Sprintf(`%s=$(curl -sS -X PUT -H "X-aws-ec2-metadata-token-ttl-seconds: 300" %s/api/token)`, imdsTokenVar, imdsEndpoint),
+		fmt.Sprintf(`imds() { curl -sS -H "X-aws-ec2-metadata-token: ${%s}" %s/meta-data/$1; }`, imdsTokenVar, imdsEndpoint),
+	}
+
+	names := sortedKeys(used)
+	for _, name := range names {
+		v := nodeVariables[name]
+		commands = append(commands, fmt.Sprintf("export %s=$(imds %s)", v.envVar, v.path))
+	}
+
+	for _, file := range files {
+		command := "sed -i"
+		for _, name := range names {
+			v := nodeVariables[name]
+			command += fmt.Sprintf(` -e "s|\${%[1]s}|${%[1]s}|g"`, v.envVar)
+		}
+		commands = append(commands, command+" "+file)
+	}
+
+	return commands
+}
+
+func mergeUsed(used map[string]bool, names []string) {
+	for _, name := range names {
+		used[name] = true
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	eksbootstrapv1 "sigs.k8s.io/cluster-api-provider-aws/v2/bootstrap/eks/api/v1beta2"
+)
+
+func TestRenderTemplates(t *testing.T) {
+	vars := &Variables{
+		ClusterName:     "test-cluster",
+		MachineName:     "machine-0",
+		MachinePoolName: "",
+		Region:          "us-east-1",
+	}
+
+	tests := []struct {
+		name                 string
+		vars                 *Variables
+		disabled             bool
+		format               eksbootstrapv1.Format
+		files                []eksbootstrapv1.File
+		preBootstrapCommands []string
+		kubeletExtraArgs     map[string]string
+		expected             *RenderedTemplates
+		expectErr            bool
+	}{
+		{
+			name:                 "without templates",
+			files:                []eksbootstrapv1.File{{Path: "/etc/foo", Content: "${FOO}"}},
+			preBootstrapCommands: []string{"echo hello"},
+			kubeletExtraArgs:     map[string]string{"node-labels": "role=worker"},
+			expected: &RenderedTemplates{
+				Files:                []eksbootstrapv1.File{{Path: "/etc/foo", Content: "${FOO}"}},
+				PreBootstrapCommands: []string{"echo hello"},
+				KubeletExtraArgs:     map[string]string{"node-labels": "role=worker"},
+			},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			files: []eksbootstrapv1.File{
+				{Path: "/etc/foo", Content: "{{ .InstanceID }}"},
+				{Path: "/etc/bar", Content: "{{ unparsable"},
+			},
+			preBootstrapCommands: []string{"echo '{{ .ClusterName }}'"},
+			kubeletExtraArgs:     map[string]string{"node-labels": "zone={{ .AvailabilityZone }}"},
+			expected: &RenderedTemplates{
+				Files: []eksbootstrapv1.File{
+					{Path: "/etc/foo", Content: "{{ .InstanceID }}"},
+					{Path: "/etc/bar", Content: "{{ unparsable"},
+				},
+				PreBootstrapCommands: []string{"echo '{{ .ClusterName }}'"},
+				KubeletExtraArgs:     map[string]string{"node-labels": "zone={{ .AvailabilityZone }}"},
+			},
+		},
+		{
+			name:                 "with known variables",
+			vars:                 &Variables{ClusterName: "test-cluster", MachineName: "machine-0", Region: "us-east-1", AvailabilityZone: "us-east-1a"},
+			files:                []eksbootstrapv1.File{{Path: "/etc/foo", Content: "cluster={{ .ClusterName }}"}},
+			preBootstrapCommands: []string{"echo {{ .Region }}"},
+			kubeletExtraArgs:     map[string]string{"node-labels": "machine={{ .MachineName }},zone={{ .AvailabilityZone }}"},
+			expected: &RenderedTemplates{
+				Files:                []eksbootstrapv1.File{{Path: "/etc/foo", Content: "cluster=test-cluster"}},
+				PreBootstrapCommands: []string{"echo us-east-1"},
+				KubeletExtraArgs:     map[string]string{"node-labels": "machine=machine-0,zone=us-east-1a"},
+			},
+		},
+		{
+			name: "with variables resolved on the node",
+			files: []eksbootstrapv1.File{
+				{Path: "/etc/foo", Content: "id={{ .InstanceID }}"},
+				{Path: "/etc/bar", Content: "e3sgLkluc3RhbmNlSUQgfX0=", Encoding: eksbootstrapv1.Base64},
+			},
+			preBootstrapCommands: []string{"echo {{ .SubnetID }}"},
+			kubeletExtraArgs:     map[string]string{"node-labels": "zone={{ .AvailabilityZone }}"},
+			expected: &RenderedTemplates{
+				Files: []eksbootstrapv1.File{
+					{Path: "/etc/foo", Content: "id=${CAPA_INSTANCE_ID}"},
+					{Path: "/etc/bar", Content: "e3sgLkluc3RhbmNlSUQgfX0=", Encoding: eksbootstrapv1.Base64},
+				},
+				PreBootstrapCommands: []string{
+					`CAPA_IMDS_TOKEN=$(curl -sS -X PUT -H "X-aws-ec2-metadata-token-ttl-seconds: 300" http://169.254.169.254/latest/api/token)`,
+					`imds() { curl -sS -H "X-aws-ec2-metadata-token: ${CAPA_IMDS_TOKEN}" http://169.254.169.254/latest/meta-data/$1; }`,
+					"export CAPA_AVAILABILITY_ZONE=$(imds placement/availability-zone)",
+					"export CAPA_INSTANCE_ID=$(imds instance-id)",
+					"export CAPA_SUBNET_ID=$(imds network/interfaces/macs/$(imds mac)/subnet-id)",
+					`sed -i -e "s|\${CAPA_AVAILABILITY_ZONE}|${CAPA_AVAILABILITY_ZONE}|g" -e "s|\${CAPA_INSTANCE_ID}|${CAPA_INSTANCE_ID}|g" -e "s|\${CAPA_SUBNET_ID}|${CAPA_SUBNET_ID}|g" /etc/foo`,
+					"echo ${CAPA_SUBNET_ID}",
+				},
+				KubeletExtraArgs: map[string]string{"node-labels": `zone='"${CAPA_AVAILABILITY_ZONE}"'`},
+			},
+		},
+		{
+			name:             "node variables in kubelet args of the nodeadm format",
+			format:           eksbootstrapv1.FormatNodeadm,
+			kubeletExtraArgs: map[string]string{"node-labels": "instance={{ .InstanceID }}"},
+			expectErr:        true,
+		},
+		{
+			name:                 "unknown variable",
+			preBootstrapCommands: []string{"echo {{ .Unknown }}"},
+			expectErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			v := tt.vars
+			if v == nil {
+				v = vars
+			}
+			if tt.disabled {
+				v = nil
+			}
+			rendered, err := RenderTemplates(v, tt.format, tt.files, tt.preBootstrapCommands, tt.kubeletExtraArgs)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(rendered).To(Equal(tt.expected))
+		})
+	}
+}
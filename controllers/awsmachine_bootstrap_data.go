@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// bootstrapDataDelivery is the path which delivers the bootstrap data to an instance. It's
+// used as the reason of the BootstrapDataValid condition.
+type bootstrapDataDelivery string
+
+const (
+	bootstrapDataDeliveryUserData           bootstrapDataDelivery = "UserData"
+	bootstrapDataDeliveryCompressedUserData bootstrapDataDelivery = "CompressedUserData"
+	bootstrapDataDeliverySecretsManager     bootstrapDataDelivery = "SecretsManager"
+	bootstrapDataDeliverySSMParameterStore  bootstrapDataDelivery = "SSMParameterStore"
+	bootstrapDataDeliveryClusterObjectStore bootstrapDataDelivery = "ClusterObjectStore"
+)
+
+// bootstrapDataPlan is the result of the validation of the bootstrap data of an AWSMachine.
+type bootstrapDataPlan struct {
+	delivery bootstrapDataDelivery
+	// size is the size of the bootstrap data as it's stored by the delivery path.
+	size int
+	// rawSize is the size of the bootstrap data before it's compressed.
+	rawSize int
+}
+
+func (p *bootstrapDataPlan) message() string {
+	if p.size == p.rawSize {
+		return fmt.Sprintf("Bootstrap data (%d bytes) is delivered with %s", p.rawSize, p.delivery)
+	}
+	return fmt.Sprintf("Bootstrap data (%d bytes, %d bytes compressed) is delivered with %s", p.rawSize, p.size, p.delivery)
+}
+
+// bootstrapDataError is an error which invalidates the bootstrap data of an AWSMachine.
+type bootstrapDataError struct {
+	reason string
+	err    error
+}
+
+func (e *bootstrapDataError) Error() string {
+	return e.err.Error()
+}
+
+// planBootstrapData validates the bootstrap data and picks the cheapest path which can deliver it to
+// the instance without weakening the configured protection of the data: Secrets Manager or SSM
+// Parameter Store when secrets are used, the cluster object store or plain user data for Ignition,
+// and plain or compressed user data otherwise. Compressed user data is only picked when
+// compression isn't disabled and the plain user data exceeds the EC2 user data limit.
+func planBootstrapData(machineScope *scope.MachineScope, data []byte, format string, objectStoreSvc services.ObjectStoreInterface) (*bootstrapDataPlan, error) {
+	if err := userdata.Validate(data, format); err != nil {
+		return nil, &bootstrapDataError{reason: infrav1.BootstrapDataInvalidReason, err: err}
+	}
+
+	plan := &bootstrapDataPlan{size: len(data), rawSize: len(data)}
+
+	if machineScope.UseIgnition(format) {
+		storageType := infrav1.IgnitionStorageTypeOptionClusterObjectStore
+		if machineScope.AWSMachine.Spec.Ignition != nil {
+			storageType = machineScope.AWSMachine.Spec.Ignition.StorageType
+		}
+
+		switch storageType {
+		case infrav1.IgnitionStorageTypeOptionClusterObjectStore:
+			if objectStoreSvc == nil {
+				return nil, &bootstrapDataError{
+					reason: infrav1.BootstrapDataDeliveryUnavailableReason,
+					err: errors.New("Ignition config requires a cluster object store at `AWSCluster.Spec.S3Bucket` or `AWSManagedControlPlane.Spec.S3Bucket`, " +
+						"or `AWSMachine.Spec.Ignition.StorageType` set to `UnencryptedUserData`"),
+				}
+			}
+			plan.delivery = bootstrapDataDeliveryClusterObjectStore
+		case infrav1.IgnitionStorageTypeOptionUnencryptedUserData:
+			if plan.size > userdata.MaxUserDataSize {
+				return nil, &bootstrapDataError{
+					reason: infrav1.BootstrapDataTooLargeReason,
+					err: errors.Errorf("Ignition config is %d bytes, which exceeds the EC2 user data limit of %d bytes; use the %s storage type instead",
+						plan.size, userdata.MaxUserDataSize, infrav1.IgnitionStorageTypeOptionClusterObjectStore),
+				}
+			}
+			plan.delivery = bootstrapDataDeliveryUserData
+		default:
+			return nil, &bootstrapDataError{
+				reason: infrav1.BootstrapDataDeliveryUnavailableReason,
+				err:    errors.Errorf("unsupported ignition storageType %q", storageType),
+			}
+		}
+
+		return plan, nil
+	}
+
+	compressed, err := userdata.GzipBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if machineScope.UseSecretsManager(format) {
+		// The secrets hold the compressed bootstrap data in chunks, and the user data is a
+		// fetch script of constant size.
+		plan.size = len(compressed)
+		plan.delivery = bootstrapDataDeliverySecretsManager
+		if machineScope.SecureSecretsBackend() == infrav1.SecretBackendSSMParameterStore {
+			plan.delivery = bootstrapDataDeliverySSMParameterStore
+		}
+		return plan, nil
+	}
+
+	uncompressed := machineScope.AWSMachine.Spec.UncompressedUserData
+	switch {
+	case machineScope.CompressUserData(format):
+		plan.size = len(compressed)
+		plan.delivery = bootstrapDataDeliveryCompressedUserData
+	case uncompressed == nil && len(data) > userdata.MaxUserDataSize:
+		plan.size = len(compressed)
+		plan.delivery = bootstrapDataDeliveryCompressedUserData
+	default:
+		plan.delivery = bootstrapDataDeliveryUserData
+	}
+
+	if plan.size > userdata.MaxUserDataSize {
+		return nil, &bootstrapDataError{
+			reason: infrav1.BootstrapDataTooLargeReason,
+			err: errors.Errorf("bootstrap data is %d bytes (%d bytes compressed), which exceeds the EC2 user data limit of %d bytes; "+
+				"enable compression or AWS Secrets Manager instead", len(data), len(compressed), userdata.MaxUserDataSize),
+		}
+	}
+
+	return plan, nil
+}
+
+// reconcileBootstrapDataPlan plans the delivery of the bootstrap data, and reports the plan or the
+// reason the bootstrap data can't be delivered on the BootstrapDataValid condition.
+func (r *AWSMachineReconciler) reconcileBootstrapDataPlan(machineScope *scope.MachineScope, data []byte, format string, objectStoreSvc services.ObjectStoreInterface) (*bootstrapDataPlan, error) {
+	plan, err := planBootstrapData(machineScope, data, format, objectStoreSvc)
+	if err != nil {
+		var dataErr *bootstrapDataError
+		if errors.As(err, &dataErr) {
+			conditions.MarkFalse(machineScope.AWSMachine, infrav1.BootstrapDataValidCondition, dataErr.reason, clusterv1.ConditionSeverityError, "%s", dataErr.Error())
+			r.Recorder.Eventf(machineScope.AWSMachine, corev1.EventTypeWarning, "InvalidBootstrapData", dataErr.Error())
+		}
+		return nil, err
+	}
+
+	conditions.Set(machineScope.AWSMachine, &clusterv1.Condition{
+		Type:    infrav1.BootstrapDataValidCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  string(plan.delivery),
+		Message: plan.message(),
+	})
+	machineScope.Info("Planned bootstrap data delivery", "delivery", plan.delivery, "size", plan.size, "rawSize", plan.rawSize)
+
+	return plan, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/mock_services"
+)
+
+func TestPlanBootstrapData(t *testing.T) {
+	// Repetitive data compresses below the EC2 user data limit, random data doesn't.
+	compressible := "#!/bin/bash\n" + strings.Repeat("echo hello\n", 2000)
+	random := make([]byte, 20*1024)
+	_, _ = rand.Read(random)
+	incompressible := "#!/bin/bash\n" + string(random)
+
+	tests := []struct {
+		name           string
+		spec           infrav1.AWSMachineSpec
+		data           string
+		format         string
+		noObjectStore  bool
+		expectDelivery bootstrapDataDelivery
+		expectReason   string
+	}{
+		{
+			name:           "secrets manager",
+			data:           compressible,
+			expectDelivery: bootstrapDataDeliverySecretsManager,
+		},
+		{
+			name: "ssm parameter store",
+			spec: infrav1.AWSMachineSpec{
+				CloudInit: infrav1.CloudInit{SecureSecretsBackend: infrav1.SecretBackendSSMParameterStore},
+			},
+			data:           compressible,
+			expectDelivery: bootstrapDataDeliverySSMParameterStore,
+		},
+		{
+			name:           "small user data",
+			spec:           infrav1.AWSMachineSpec{CloudInit: infrav1.CloudInit{InsecureSkipSecretsManager: true}},
+			data:           "#!/bin/bash\necho hello\n",
+			expectDelivery: bootstrapDataDeliveryUserData,
+		},
+		{
+			name:           "large user data",
+			spec:           infrav1.AWSMachineSpec{CloudInit: infrav1.CloudInit{InsecureSkipSecretsManager: true}},
+			data:           compressible,
+			expectDelivery: bootstrapDataDeliveryCompressedUserData,
+		},
+		{
+			name: "large user data with compression disabled",
+			spec: infrav1.AWSMachineSpec{
+				CloudInit:            infrav1.CloudInit{InsecureSkipSecretsManager: true},
+				UncompressedUserData: ptr.To(true),
+			},
+			data:         compressible,
+			expectReason: infrav1.BootstrapDataTooLargeReason,
+		},
+		{
+			name:         "incompressible user data",
+			spec:         infrav1.AWSMachineSpec{CloudInit: infrav1.CloudInit{InsecureSkipSecretsManager: true}},
+			data:         incompressible,
+			expectReason: infrav1.BootstrapDataTooLargeReason,
+		},
+		{
+			name:         "invalid cloud-config",
+			data:         "#cloud-config\nruncmd: echo hello\n",
+			expectReason: infrav1.BootstrapDataInvalidReason,
+		},
+		{
+			name:           "ignition with object store",
+			data:           `{"ignition":{"version":"3.4.0"}}`,
+			format:         "ignition",
+			expectDelivery: bootstrapDataDeliveryClusterObjectStore,
+		},
+		{
+			name:          "ignition without object store",
+			data:          `{"ignition":{"version":"3.4.0"}}`,
+			format:        "ignition",
+			noObjectStore: true,
+			expectReason:  infrav1.BootstrapDataDeliveryUnavailableReason,
+		},
+		{
+			name: "large ignition in user data",
+			spec: infrav1.AWSMachineSpec{
+				Ignition: &infrav1.Ignition{StorageType: infrav1.IgnitionStorageTypeOptionUnencryptedUserData},
+			},
+			data:         `{"ignition":{"version":"3.4.0"},"passwd":{"users":[{"name":"core","gecos":"` + strings.Repeat("a", 17*1024) + `"}]}}`,
+			format:       "ignition",
+			expectReason: infrav1.BootstrapDataTooLargeReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machineScope := &scope.MachineScope{AWSMachine: &infrav1.AWSMachine{Spec: tt.spec}}
+			var objectStoreSvc services.ObjectStoreInterface
+			if !tt.noObjectStore {
+				objectStoreSvc = mock_services.NewMockObjectStoreInterface(gomock.NewController(t))
+			}
+
+			plan, err := planBootstrapData(machineScope, []byte(tt.data), tt.format, objectStoreSvc)
+			if tt.expectReason != "" {
+				var dataErr *bootstrapDataError
+				g.Expect(errors.As(err, &dataErr)).To(BeTrue())
+				g.Expect(dataErr.reason).To(Equal(tt.expectReason))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(plan.delivery).To(Equal(tt.expectDelivery))
+			g.Expect(plan.rawSize).To(Equal(len(tt.data)))
+		})
+	}
+}
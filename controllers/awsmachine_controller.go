@@ -223,11 +223,16 @@ func (r *AWSMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	switch infraScope := infraCluster.(type) {
 	case *scope.ManagedControlPlaneScope:
+		var objectStoreScope scope.S3Scope
+		if infraScope.Bucket() != nil {
+			objectStoreScope = infraScope
+		}
+
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
-			return r.reconcileDelete(machineScope, infraScope, infraScope, nil, nil)
+			return r.reconcileDelete(machineScope, infraScope, infraScope, nil, objectStoreScope)
 		}
 
-		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, nil)
+		return r.reconcileNormal(ctx, machineScope, infraScope, infraScope, nil, objectStoreScope)
 	case *scope.ClusterScope:
 		if !awsMachine.ObjectMeta.DeletionTimestamp.IsZero() {
 			return r.reconcileDelete(machineScope, infraScope, infraScope, infraScope, infraScope)
@@ -723,10 +728,21 @@ func (r *AWSMachineReconciler) resolveUserData(machineScope *scope.MachineScope,
 		return nil, "", err
 	}
 
+	plan, err := r.reconcileBootstrapDataPlan(machineScope, userData, userDataFormat, objectStoreSvc)
+	if err != nil {
+		return nil, "", err
+	}
+
 	if machineScope.UseSecretsManager(userDataFormat) {
 		userData, err = r.cloudInitUserData(machineScope, clusterScope, userData)
 	}
 
+	// Plain user data which exceeds the EC2 user data limit is compressed, unless the
+	// EC2 service compresses it already.
+	if plan.delivery == bootstrapDataDeliveryCompressedUserData && !machineScope.CompressUserData(userDataFormat) {
+		userData, err = userdata.GzipBytes(userData)
+	}
+
 	if machineScope.UseIgnition(userDataFormat) {
 		var ignitionStorageType infrav1.IgnitionStorageTypeOption
 		if machineScope.AWSMachine.Spec.Ignition == nil {
@@ -786,7 +802,7 @@ func (r *AWSMachineReconciler) cloudInitUserData(machineScope *scope.MachineScop
 // then returns the config to instruct ignition on how to pull the user data from the bucket.
 func (r *AWSMachineReconciler) generateIgnitionWithRemoteStorage(scope *scope.MachineScope, objectStoreSvc services.ObjectStoreInterface, userData []byte) ([]byte, error) {
 	if objectStoreSvc == nil {
-		return nil, errors.New("using Ignition by default requires a cluster wide object storage configured at `AWSCluster.Spec.Ignition.S3Bucket`. " +
+		return nil, errors.New("using Ignition by default requires a cluster wide object storage configured at `AWSCluster.Spec.S3Bucket` or `AWSManagedControlPlane.Spec.S3Bucket`. " +
 			"You must configure one or instruct Ignition to use EC2 user data instead, by setting `AWSMachine.Spec.Ignition.StorageType` to `UnencryptedUserData`")
 	}
 
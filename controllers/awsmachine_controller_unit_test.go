@@ -101,7 +101,7 @@ func TestAWSMachineReconciler(t *testing.T) {
 				Name: "bootstrap-data-ignition",
 			},
 			Data: map[string][]byte{
-				"value":  []byte("ignitionJSON"),
+				"value":  []byte(`{"ignition":{"version":"2.3.0"}}`),
 				"format": []byte("ignition"),
 			},
 		}
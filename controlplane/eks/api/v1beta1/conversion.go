@@ -40,6 +40,16 @@ func (r *AWSManagedControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 	dst.Spec.VpcCni.Disable = r.Spec.DisableVPCCNI
 	dst.Spec.Partition = restored.Spec.Partition
+	dst.Spec.S3Bucket = restored.Spec.S3Bucket
+	dst.Spec.VpcCni.CustomNetworking = restored.Spec.VpcCni.CustomNetworking
+	dst.Spec.VpcCni.PrefixDelegation = restored.Spec.VpcCni.PrefixDelegation
+	dst.Spec.VpcCni.PodSecurityGroups = restored.Spec.VpcCni.PodSecurityGroups
+	dst.Spec.VpcCni.MigrateToAddon = restored.Spec.VpcCni.MigrateToAddon
+	dst.Spec.KubeProxy.MigrateToAddon = restored.Spec.KubeProxy.MigrateToAddon
+	dst.Status.ComponentOwnership = restored.Status.ComponentOwnership
+	restoreAddonStates(restored.Status.Addons, dst.Status.Addons)
+	dst.Status.CertificateAuthorityData = restored.Status.CertificateAuthorityData
+	dst.Status.ServiceCIDR = restored.Status.ServiceCIDR
 
 	return nil
 }
@@ -112,7 +122,35 @@ func Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *ekscontrolplanev1.VpcCni, out
 	return autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in, out, s)
 }
 
+// Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy is a conversion function.
+func Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(in *ekscontrolplanev1.KubeProxy, out *KubeProxy, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(in, out, s)
+}
+
 // Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec is a generated conversion function
 func Convert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in *ekscontrolplanev1.AWSManagedControlPlaneSpec, out *AWSManagedControlPlaneSpec, scope apiconversion.Scope) error {
 	return autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControlPlaneSpec(in, out, scope)
 }
+
+// Convert_v1beta2_AddonState_To_v1beta1_AddonState is a conversion function.
+func Convert_v1beta2_AddonState_To_v1beta1_AddonState(in *ekscontrolplanev1.AddonState, out *AddonState, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AddonState_To_v1beta1_AddonState(in, out, s)
+}
+
+// restoreAddonStates restores the AddonState fields that don't exist in v1beta1.
+func restoreAddonStates(restored, dst []ekscontrolplanev1.AddonState) {
+	for i := range dst {
+		for _, addon := range restored {
+			if addon.Name == dst[i].Name {
+				dst[i].ResolvedVersion = addon.ResolvedVersion
+				dst[i].Conditions = addon.Conditions
+				break
+			}
+		}
+	}
+}
+
+// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus converts a v1beta2 AWSManagedControlPlaneStatus receiver to a v1beta1 AWSManagedControlPlaneStatus.
+func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *ekscontrolplanev1.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, s)
+}
@@ -65,11 +65,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AWSManagedControlPlaneStatus)(nil), (*AWSManagedControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(a.(*v1beta2.AWSManagedControlPlaneStatus), b.(*AWSManagedControlPlaneStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Addon)(nil), (*v1beta2.Addon)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Addon_To_v1beta2_Addon(a.(*Addon), b.(*v1beta2.Addon), scope)
 	}); err != nil {
@@ -95,11 +90,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.AddonState)(nil), (*AddonState)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_AddonState_To_v1beta1_AddonState(a.(*v1beta2.AddonState), b.(*AddonState), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneLoggingSpec)(nil), (*v1beta2.ControlPlaneLoggingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ControlPlaneLoggingSpec_To_v1beta2_ControlPlaneLoggingSpec(a.(*ControlPlaneLoggingSpec), b.(*v1beta2.ControlPlaneLoggingSpec), scope)
 	}); err != nil {
@@ -155,11 +145,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.KubeProxy)(nil), (*KubeProxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(a.(*v1beta2.KubeProxy), b.(*KubeProxy), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*KubernetesMapping)(nil), (*v1beta2.KubernetesMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_KubernetesMapping_To_v1beta2_KubernetesMapping(a.(*KubernetesMapping), b.(*v1beta2.KubernetesMapping), scope)
 	}); err != nil {
@@ -240,11 +225,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.AWSManagedControlPlaneStatus)(nil), (*AWSManagedControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(a.(*v1beta2.AWSManagedControlPlaneStatus), b.(*AWSManagedControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.AddonState)(nil), (*AddonState)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_AddonState_To_v1beta1_AddonState(a.(*v1beta2.AddonState), b.(*AddonState), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*apiv1beta2.Bastion)(nil), (*apiv1beta1.Bastion)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_Bastion_To_v1beta1_Bastion(a.(*apiv1beta2.Bastion), b.(*apiv1beta1.Bastion), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.KubeProxy)(nil), (*KubeProxy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(a.(*v1beta2.KubeProxy), b.(*KubeProxy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VpcCni)(nil), (*VpcCni)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VpcCni_To_v1beta1_VpcCni(a.(*v1beta2.VpcCni), b.(*VpcCni), scope)
 	}); err != nil {
@@ -386,6 +386,7 @@ func autoConvert_v1beta2_AWSManagedControlPlaneSpec_To_v1beta1_AWSManagedControl
 	out.ImageLookupOrg = in.ImageLookupOrg
 	out.ImageLookupBaseOS = in.ImageLookupBaseOS
 	out.Bastion = in.Bastion
+	// WARNING: in.S3Bucket requires manual conversion: does not exist in peer-type
 	out.TokenMethod = (*EKSTokenMethod)(unsafe.Pointer(in.TokenMethod))
 	out.AssociateOIDCProvider = in.AssociateOIDCProvider
 	out.Addons = (*[]Addon)(unsafe.Pointer(in.Addons))
@@ -411,7 +412,17 @@ func autoConvert_v1beta1_AWSManagedControlPlaneStatus_To_v1beta2_AWSManagedContr
 	out.Ready = in.Ready
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
-	out.Addons = *(*[]v1beta2.AddonState)(unsafe.Pointer(&in.Addons))
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]v1beta2.AddonState, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_AddonState_To_v1beta2_AddonState(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Addons = nil
+	}
 	if err := Convert_v1beta1_IdentityProviderStatus_To_v1beta2_IdentityProviderStatus(&in.IdentityProviderStatus, &out.IdentityProviderStatus, s); err != nil {
 		return err
 	}
@@ -435,18 +446,26 @@ func autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedContr
 	out.Ready = in.Ready
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Conditions = *(*clusterapiapiv1beta1.Conditions)(unsafe.Pointer(&in.Conditions))
-	out.Addons = *(*[]AddonState)(unsafe.Pointer(&in.Addons))
+	// WARNING: in.ComponentOwnership requires manual conversion: does not exist in peer-type
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = make([]AddonState, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta2_AddonState_To_v1beta1_AddonState(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Addons = nil
+	}
 	if err := Convert_v1beta2_IdentityProviderStatus_To_v1beta1_IdentityProviderStatus(&in.IdentityProviderStatus, &out.IdentityProviderStatus, s); err != nil {
 		return err
 	}
+	// WARNING: in.CertificateAuthorityData requires manual conversion: does not exist in peer-type
+	// WARNING: in.ServiceCIDR requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus is an autogenerated conversion function.
-func Convert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in *v1beta2.AWSManagedControlPlaneStatus, out *AWSManagedControlPlaneStatus, s conversion.Scope) error {
-	return autoConvert_v1beta2_AWSManagedControlPlaneStatus_To_v1beta1_AWSManagedControlPlaneStatus(in, out, s)
-}
-
 func autoConvert_v1beta1_Addon_To_v1beta2_Addon(in *Addon, out *v1beta2.Addon, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
@@ -520,19 +539,16 @@ func autoConvert_v1beta2_AddonState_To_v1beta1_AddonState(in *v1beta2.AddonState
 	out.Name = in.Name
 	out.Version = in.Version
 	out.ARN = in.ARN
+	// WARNING: in.ResolvedVersion requires manual conversion: does not exist in peer-type
 	out.ServiceAccountRoleArn = (*string)(unsafe.Pointer(in.ServiceAccountRoleArn))
 	out.CreatedAt = in.CreatedAt
 	out.ModifiedAt = in.ModifiedAt
 	out.Status = (*string)(unsafe.Pointer(in.Status))
 	out.Issues = *(*[]AddonIssue)(unsafe.Pointer(&in.Issues))
+	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_AddonState_To_v1beta1_AddonState is an autogenerated conversion function.
-func Convert_v1beta2_AddonState_To_v1beta1_AddonState(in *v1beta2.AddonState, out *AddonState, s conversion.Scope) error {
-	return autoConvert_v1beta2_AddonState_To_v1beta1_AddonState(in, out, s)
-}
-
 func autoConvert_v1beta1_ControlPlaneLoggingSpec_To_v1beta2_ControlPlaneLoggingSpec(in *ControlPlaneLoggingSpec, out *v1beta2.ControlPlaneLoggingSpec, s conversion.Scope) error {
 	out.APIServer = in.APIServer
 	out.Audit = in.Audit
@@ -663,14 +679,10 @@ func Convert_v1beta1_KubeProxy_To_v1beta2_KubeProxy(in *KubeProxy, out *v1beta2.
 
 func autoConvert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(in *v1beta2.KubeProxy, out *KubeProxy, s conversion.Scope) error {
 	out.Disable = in.Disable
+	// WARNING: in.MigrateToAddon requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy is an autogenerated conversion function.
-func Convert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(in *v1beta2.KubeProxy, out *KubeProxy, s conversion.Scope) error {
-	return autoConvert_v1beta2_KubeProxy_To_v1beta1_KubeProxy(in, out, s)
-}
-
 func autoConvert_v1beta1_KubernetesMapping_To_v1beta2_KubernetesMapping(in *KubernetesMapping, out *v1beta2.KubernetesMapping, s conversion.Scope) error {
 	out.UserName = in.UserName
 	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
@@ -816,5 +828,9 @@ func Convert_v1beta1_VpcCni_To_v1beta2_VpcCni(in *VpcCni, out *v1beta2.VpcCni, s
 func autoConvert_v1beta2_VpcCni_To_v1beta1_VpcCni(in *v1beta2.VpcCni, out *VpcCni, s conversion.Scope) error {
 	// WARNING: in.Disable requires manual conversion: does not exist in peer-type
 	out.Env = *(*[]v1.EnvVar)(unsafe.Pointer(&in.Env))
+	// WARNING: in.CustomNetworking requires manual conversion: does not exist in peer-type
+	// WARNING: in.PrefixDelegation requires manual conversion: does not exist in peer-type
+	// WARNING: in.PodSecurityGroups requires manual conversion: does not exist in peer-type
+	// WARNING: in.MigrateToAddon requires manual conversion: does not exist in peer-type
 	return nil
 }
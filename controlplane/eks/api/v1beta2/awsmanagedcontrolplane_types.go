@@ -147,6 +147,12 @@ type AWSManagedControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	Bastion infrav1.Bastion `json:"bastion"`
 
+	// S3Bucket contains options to configure a supporting S3 bucket for this
+	// cluster, used to store the Ignition (https://coreos.github.io/ignition/)
+	// bootstrap data of nodes (requires BootstrapFormatIgnition feature flag to be enabled).
+	// +optional
+	S3Bucket *infrav1.S3Bucket `json:"s3Bucket,omitempty"`
+
 	// TokenMethod is used to specify the method for obtaining a client token for communicating with EKS
 	// iam-authenticator - obtains a client token using iam-authentictor
 	// aws-cli - obtains a client token using the AWS CLI
@@ -186,6 +192,10 @@ type KubeProxy struct {
 	// set this to true if you are using the Amazon kube-proxy addon.
 	// +kubebuilder:default=false
 	Disable bool `json:"disable,omitempty"`
+	// MigrateToAddon hands kube-proxy over to the kube-proxy EKS addon. Once set, kube-proxy
+	// isn't managed by CAPA anymore.
+	// +optional
+	MigrateToAddon *AddonMigration `json:"migrateToAddon,omitempty"`
 }
 
 // VpcCni specifies configuration related to the VPC CNI.
@@ -197,9 +207,31 @@ type VpcCni struct {
 	// Amazon VPC CNI addon.
 	// +kubebuilder:default=false
 	Disable bool `json:"disable,omitempty"`
-	// Env defines a list of environment variables to apply to the `aws-node` DaemonSet
+	// Env defines a list of environment variables to apply to the `aws-node` DaemonSet.
+	// They take precedence over the environment variables set by the other options.
 	// +optional
 	Env []corev1.EnvVar `json:"env,omitempty"`
+	// CustomNetworking assigns pod IPs from the secondary subnets instead of the subnets
+	// of the nodes. An ENIConfig is created for each availability zone with a secondary
+	// subnet, and is selected by the zone label of the nodes. Requires SecondaryCidrBlock,
+	// or subnets tagged as secondary subnets in an unmanaged VPC.
+	// +optional
+	CustomNetworking bool `json:"customNetworking,omitempty"`
+	// PrefixDelegation assigns IPv4 prefixes instead of individual IPs to the network
+	// interfaces of nodes, which increases the number of pods per node. Only Nitro
+	// instance types are supported.
+	// +optional
+	PrefixDelegation bool `json:"prefixDelegation,omitempty"`
+	// PodSecurityGroups enables security groups for pods. The IAM role of the cluster
+	// requires the AmazonEKSVPCResourceController policy.
+	// +optional
+	PodSecurityGroups bool `json:"podSecurityGroups,omitempty"`
+	// MigrateToAddon hands the Amazon VPC CNI over to the vpc-cni EKS addon. Unless a
+	// configuration is set, the addon is configured with the environment variables of the
+	// other options. Once set, the `aws-node` DaemonSet and the ENIConfigs aren't managed by
+	// CAPA anymore.
+	// +optional
+	MigrateToAddon *AddonMigration `json:"migrateToAddon,omitempty"`
 }
 
 // EndpointAccess specifies how control plane endpoints are accessible.
@@ -272,6 +304,10 @@ type AWSManagedControlPlaneStatus struct {
 	FailureMessage *string `json:"failureMessage,omitempty"`
 	// Conditions specifies the cpnditions for the managed control plane
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+	// ComponentOwnership records whether the VPC CNI and kube-proxy are managed by CAPA
+	// or by EKS addons.
+	// +optional
+	ComponentOwnership *ComponentOwnership `json:"componentOwnership,omitempty"`
 	// Addons holds the current status of the EKS addons
 	// +optional
 	Addons []AddonState `json:"addons,omitempty"`
@@ -279,6 +315,15 @@ type AWSManagedControlPlaneStatus struct {
 	// associated identity provider
 	// +optional
 	IdentityProviderStatus IdentityProviderStatus `json:"identityProviderStatus,omitempty"`
+	// CertificateAuthorityData is the base64 encoded certificate authority of the
+	// EKS cluster. It's used by bootstrap providers so nodes don't need to call
+	// DescribeCluster.
+	// +optional
+	CertificateAuthorityData string `json:"certificateAuthorityData,omitempty"`
+	// ServiceCIDR is the CIDR block that Kubernetes service IP addresses are
+	// assigned from.
+	// +optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -33,6 +33,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 )
 
 const (
@@ -90,11 +91,16 @@ func (r *AWSManagedControlPlane) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateEKSAddonsVersion(nil)...)
+	allErrs = append(allErrs, r.validateEKSAddonsConfiguration()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validateVpcCniModes()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateAddonMigrations(nil)...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateS3Bucket()...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -125,10 +131,15 @@ func (r *AWSManagedControlPlane) ValidateUpdate(old runtime.Object) (admission.W
 	allErrs = append(allErrs, r.validateIAMAuthConfig()...)
 	allErrs = append(allErrs, r.validateSecondaryCIDR()...)
 	allErrs = append(allErrs, r.validateEKSAddons()...)
+	allErrs = append(allErrs, r.validateEKSAddonsVersion(oldAWSManagedControlplane)...)
+	allErrs = append(allErrs, r.validateEKSAddonsConfiguration()...)
 	allErrs = append(allErrs, r.validateDisableVPCCNI()...)
+	allErrs = append(allErrs, r.validateVpcCniModes()...)
 	allErrs = append(allErrs, r.validateKubeProxy()...)
+	allErrs = append(allErrs, r.validateAddonMigrations(oldAWSManagedControlplane)...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 	allErrs = append(allErrs, r.validatePrivateDNSHostnameTypeOnLaunch()...)
+	allErrs = append(allErrs, r.validateS3Bucket()...)
 
 	if r.Spec.Region != oldAWSManagedControlplane.Spec.Region {
 		allErrs = append(allErrs,
@@ -269,7 +280,8 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 		}
 
 		for _, addon := range *r.Spec.Addons {
-			if addon.Name == vpcCniAddon {
+			// The version selector is resolved during reconciliation
+			if addon.Name == vpcCniAddon && !eksaddons.IsVersionSelector(addon.Version) {
 				v, err := version.ParseGeneric(addon.Version)
 				if err != nil {
 					allErrs = append(allErrs, field.Invalid(addonsPath, addon.Version, err.Error()))
@@ -287,6 +299,55 @@ func (r *AWSManagedControlPlane) validateEKSAddons() field.ErrorList {
 	return allErrs
 }
 
+// validateEKSAddonsVersion checks that the version of the addons is either a version or a version selector.
+// Addons whose version is unchanged from the old object aren't validated, so objects created before the
+// version was validated can still be updated.
+func (r *AWSManagedControlPlane) validateEKSAddonsVersion(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.Addons == nil {
+		return allErrs
+	}
+
+	oldVersions := map[string]string{}
+	if old != nil && old.Spec.Addons != nil {
+		for _, addon := range *old.Spec.Addons {
+			oldVersions[addon.Name] = addon.Version
+		}
+	}
+
+	for i, addon := range *r.Spec.Addons {
+		if oldVersion, ok := oldVersions[addon.Name]; ok && oldVersion == addon.Version {
+			continue
+		}
+		if err := eksaddons.ValidateVersion(addon.Version); err != nil {
+			path := field.NewPath("spec", "addons").Index(i).Child("version")
+			allErrs = append(allErrs, field.Invalid(path, addon.Version, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateEKSAddonsConfiguration checks that the addons configuration can be parsed. The
+// configuration is validated against the addon configuration schema during reconciliation.
+func (r *AWSManagedControlPlane) validateEKSAddonsConfiguration() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if r.Spec.Addons == nil {
+		return allErrs
+	}
+
+	for i, addon := range *r.Spec.Addons {
+		if _, err := eksaddons.NormalizeConfiguration(addon.Configuration); err != nil {
+			path := field.NewPath("spec", "addons").Index(i).Child("configuration")
+			allErrs = append(allErrs, field.Invalid(path, addon.Configuration, "configuration must be valid JSON or YAML"))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateIAMAuthConfig() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -392,6 +453,104 @@ func (r *AWSManagedControlPlane) validateDisableVPCCNI() field.ErrorList {
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateVpcCniModes() field.ErrorList {
+	var allErrs field.ErrorList
+
+	vpcCniField := field.NewPath("spec", "vpcCni")
+	vpcCni := r.Spec.VpcCni
+	if vpcCni.Disable {
+		modes := []struct {
+			name    string
+			enabled bool
+		}{
+			{"customNetworking", vpcCni.CustomNetworking},
+			{"prefixDelegation", vpcCni.PrefixDelegation},
+			{"podSecurityGroups", vpcCni.PodSecurityGroups},
+		}
+		for _, mode := range modes {
+			if mode.enabled {
+				allErrs = append(allErrs, field.Forbidden(vpcCniField.Child(mode.name), "cannot be set if the vpc cni is disabled"))
+			}
+		}
+	}
+
+	if vpcCni.CustomNetworking {
+		customNetworkingField := vpcCniField.Child("customNetworking")
+		if r.Spec.NetworkSpec.VPC.IsIPv6Enabled() {
+			allErrs = append(allErrs, field.Forbidden(customNetworkingField, "custom networking isn't supported for IPv6"))
+		}
+		// The secondary subnets of an unmanaged VPC are tagged instead.
+		if r.Spec.SecondaryCidrBlock == nil && r.Spec.NetworkSpec.VPC.ID == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "secondaryCidrBlock"), "a secondary cidr block is required for custom networking"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAddonMigrations validates the migrations of the VPC CNI and kube-proxy to their EKS addons.
+// A migration can't be undone, as that would delete the addon, unless the addon is listed in the
+// addons instead.
+func (r *AWSManagedControlPlane) validateAddonMigrations(old *AWSManagedControlPlane) field.ErrorList {
+	var allErrs field.ErrorList
+
+	migrations := []struct {
+		path      *field.Path
+		addonName string
+		disabled  bool
+		migration *AddonMigration
+		previous  *AddonMigration
+	}{
+		{
+			path:      field.NewPath("spec", "vpcCni", "migrateToAddon"),
+			addonName: vpcCniAddon,
+			disabled:  r.Spec.VpcCni.Disable,
+			migration: r.Spec.VpcCni.MigrateToAddon,
+		},
+		{
+			path:      field.NewPath("spec", "kubeProxy", "migrateToAddon"),
+			addonName: kubeProxyAddon,
+			disabled:  r.Spec.KubeProxy.Disable,
+			migration: r.Spec.KubeProxy.MigrateToAddon,
+		},
+	}
+	if old != nil {
+		migrations[0].previous = old.Spec.VpcCni.MigrateToAddon
+		migrations[1].previous = old.Spec.KubeProxy.MigrateToAddon
+	}
+
+	for _, m := range migrations {
+		hasAddon := false
+		if r.Spec.Addons != nil {
+			for _, addon := range *r.Spec.Addons {
+				if addon.Name == m.addonName {
+					hasAddon = true
+				}
+			}
+		}
+
+		if m.migration != nil {
+			if m.disabled {
+				allErrs = append(allErrs, field.Forbidden(m.path, fmt.Sprintf("cannot migrate to the %s addon if it's disabled", m.addonName)))
+			}
+			if hasAddon {
+				allErrs = append(allErrs, field.Forbidden(m.path, fmt.Sprintf("cannot migrate to the %s addon if it's specified in the addons", m.addonName)))
+			}
+			if m.migration.Version != "" && !eksaddons.IsVersionSelector(m.migration.Version) {
+				if _, err := version.ParseGeneric(m.migration.Version); err != nil {
+					allErrs = append(allErrs, field.Invalid(m.path.Child("version"), m.migration.Version, "must be a version or a version selector"))
+				}
+			}
+		}
+
+		if m.previous != nil && m.migration == nil && !hasAddon {
+			allErrs = append(allErrs, field.Forbidden(m.path, fmt.Sprintf("cannot be removed unless the %s addon is specified in the addons", m.addonName)))
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validatePrivateDNSHostnameTypeOnLaunch() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -403,6 +562,20 @@ func (r *AWSManagedControlPlane) validatePrivateDNSHostnameTypeOnLaunch() field.
 	return allErrs
 }
 
+func (r *AWSManagedControlPlane) validateS3Bucket() field.ErrorList {
+	var allErrs field.ErrorList
+
+	// EKS control planes have no instances, so only nodes read their bootstrap data from the bucket.
+	controlPlaneProfilePath := field.NewPath("spec", "s3Bucket", "controlPlaneIAMInstanceProfiles").String()
+	for _, err := range r.Spec.S3Bucket.Validate() {
+		if err.Field != controlPlaneProfilePath {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	return allErrs
+}
+
 func (r *AWSManagedControlPlane) validateNetwork() field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
@@ -176,6 +177,8 @@ func TestWebhookCreate(t *testing.T) {
 		additionalTags infrav1.Tags
 		secondaryCidr  *string
 		kubeProxy      KubeProxy
+		addonConfig    string
+		addonVersion   string
 	}{
 		{
 			name:           "ekscluster specified",
@@ -219,6 +222,42 @@ func TestWebhookCreate(t *testing.T) {
 			hasAddons:      true,
 			vpcCNI:         VpcCni{Disable: false},
 		},
+		{
+			name:           "addons with yaml configuration",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.18",
+			expectError:    false,
+			hasAddons:      true,
+			vpcCNI:         VpcCni{Disable: false},
+			addonConfig:    "env:\n  ENABLE_PREFIX_DELEGATION: \"true\"\n",
+		},
+		{
+			name:           "addons with version selector",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.18",
+			expectError:    false,
+			hasAddons:      true,
+			vpcCNI:         VpcCni{Disable: false},
+			addonVersion:   ">=1.15.0 <1.16.0",
+		},
+		{
+			name:           "addons with invalid version",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.18",
+			expectError:    true,
+			hasAddons:      true,
+			vpcCNI:         VpcCni{Disable: false},
+			addonVersion:   "newest",
+		},
+		{
+			name:           "addons with malformed configuration",
+			eksClusterName: "default_cluster1",
+			eksVersion:     "v1.18",
+			expectError:    true,
+			hasAddons:      true,
+			vpcCNI:         VpcCni{Disable: false},
+			addonConfig:    `{"env": {"ENABLE_PREFIX_DELEGATION": "true"}`,
+		},
 		{
 			name:           "disable vpc cni allowed with no addons or secondary cidr",
 			eksClusterName: "default_cluster1",
@@ -333,10 +372,15 @@ func TestWebhookCreate(t *testing.T) {
 				mcp.Spec.Version = aws.String(tc.eksVersion)
 			}
 			if tc.hasAddons {
+				addonVersion := "v1.0.0"
+				if tc.addonVersion != "" {
+					addonVersion = tc.addonVersion
+				}
 				testAddons := []Addon{
 					{
-						Name:    vpcCniAddon,
-						Version: "v1.0.0",
+						Name:          vpcCniAddon,
+						Version:       addonVersion,
+						Configuration: tc.addonConfig,
 					},
 					{
 						Name:    kubeProxyAddon,
@@ -866,3 +910,224 @@ func TestValidatingWebhookUpdateSecondaryCidr(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatingWebhookCreateVpcCniModes(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        AWSManagedControlPlaneSpec
+		expectError bool
+	}{
+		{
+			name: "prefix delegation and pod security groups",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{PrefixDelegation: true, PodSecurityGroups: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "custom networking with secondary cidr",
+			spec: AWSManagedControlPlaneSpec{
+				SecondaryCidrBlock: aws.String("100.64.0.0/16"),
+				VpcCni:             VpcCni{CustomNetworking: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "custom networking in unmanaged vpc",
+			spec: AWSManagedControlPlaneSpec{
+				NetworkSpec: infrav1.NetworkSpec{VPC: infrav1.VPCSpec{ID: "vpc-123"}},
+				VpcCni:      VpcCni{CustomNetworking: true},
+			},
+			expectError: false,
+		},
+		{
+			name: "custom networking without secondary cidr",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{CustomNetworking: true},
+			},
+			expectError: true,
+		},
+		{
+			name: "prefix delegation with disabled vpc cni",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{Disable: true, PrefixDelegation: true},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{Spec: tc.spec}
+			mcp.Spec.EKSClusterName = "default_cluster1"
+			warn, err := mcp.ValidateCreate()
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			// Nothing emits warnings yet
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidatingWebhookAddonMigrations(t *testing.T) {
+	migration := &AddonMigration{Version: "default"}
+	vpcCniAddons := &[]Addon{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}}
+
+	tests := []struct {
+		name        string
+		oldSpec     *AWSManagedControlPlaneSpec
+		spec        AWSManagedControlPlaneSpec
+		expectError bool
+	}{
+		{
+			name: "vpc cni and kube-proxy migrations",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni:    VpcCni{MigrateToAddon: migration},
+				KubeProxy: KubeProxy{MigrateToAddon: &AddonMigration{Version: "v1.29.0-eksbuild.1"}},
+			},
+			expectError: false,
+		},
+		{
+			name: "migration with invalid version",
+			spec: AWSManagedControlPlaneSpec{
+				KubeProxy: KubeProxy{MigrateToAddon: &AddonMigration{Version: "not a version"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "migration of disabled vpc cni",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{Disable: true, MigrateToAddon: migration},
+			},
+			expectError: true,
+		},
+		{
+			name: "migration of disabled kube-proxy",
+			spec: AWSManagedControlPlaneSpec{
+				KubeProxy: KubeProxy{Disable: true, MigrateToAddon: migration},
+			},
+			expectError: true,
+		},
+		{
+			name: "migration of vpc cni specified in the addons",
+			spec: AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{MigrateToAddon: migration},
+				Addons: vpcCniAddons,
+			},
+			expectError: true,
+		},
+		{
+			name: "migration removed",
+			oldSpec: &AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{MigrateToAddon: migration},
+			},
+			spec:        AWSManagedControlPlaneSpec{},
+			expectError: true,
+		},
+		{
+			name: "migration replaced by the addon",
+			oldSpec: &AWSManagedControlPlaneSpec{
+				VpcCni: VpcCni{MigrateToAddon: migration},
+			},
+			spec: AWSManagedControlPlaneSpec{
+				Addons: vpcCniAddons,
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{Spec: tc.spec}
+			mcp.Spec.EKSClusterName = "default_cluster1"
+
+			var warn admission.Warnings
+			var err error
+			if tc.oldSpec != nil {
+				oldMCP := &AWSManagedControlPlane{Spec: *tc.oldSpec}
+				oldMCP.Spec.EKSClusterName = "default_cluster1"
+				warn, err = mcp.ValidateUpdate(oldMCP)
+			} else {
+				warn, err = mcp.ValidateCreate()
+			}
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+			// Nothing emits warnings yet
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestValidatingWebhookAddonsVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		oldAddons   *[]Addon
+		addons      *[]Addon
+		expectError bool
+	}{
+		{
+			name:        "create with a version selector",
+			addons:      &[]Addon{{Name: "vpc-cni", Version: "latest"}},
+			expectError: false,
+		},
+		{
+			name:        "create with an invalid version",
+			addons:      &[]Addon{{Name: "vpc-cni", Version: "not a version"}},
+			expectError: true,
+		},
+		{
+			name:        "update keeping an invalid version",
+			oldAddons:   &[]Addon{{Name: "vpc-cni", Version: "not a version"}},
+			addons:      &[]Addon{{Name: "vpc-cni", Version: "not a version"}, {Name: "coredns", Version: "v1.11.1-eksbuild.4"}},
+			expectError: false,
+		},
+		{
+			name:        "update changing to an invalid version",
+			oldAddons:   &[]Addon{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}},
+			addons:      &[]Addon{{Name: "vpc-cni", Version: "not a version"}},
+			expectError: true,
+		},
+		{
+			name:        "update adding an addon with an invalid version",
+			oldAddons:   &[]Addon{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}},
+			addons:      &[]Addon{{Name: "vpc-cni", Version: "v1.18.0-eksbuild.1"}, {Name: "coredns", Version: "not a version"}},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mcp := &AWSManagedControlPlane{Spec: AWSManagedControlPlaneSpec{Addons: tc.addons}}
+			mcp.Spec.EKSClusterName = "default_cluster1"
+
+			var err error
+			if tc.oldAddons != nil {
+				oldMCP := &AWSManagedControlPlane{Spec: AWSManagedControlPlaneSpec{Addons: tc.oldAddons}}
+				oldMCP.Spec.EKSClusterName = "default_cluster1"
+				_, err = mcp.ValidateUpdate(oldMCP)
+			} else {
+				_, err = mcp.ValidateCreate()
+			}
+
+			if tc.expectError {
+				g.Expect(err).ToNot(BeNil())
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
@@ -52,6 +52,15 @@ const (
 	EKSAddonsConfiguredFailedReason = "EKSAddonsConfiguredFailed"
 )
 
+const (
+	// EKSAddonConfigurationValidCondition condition reports on whether the configuration of an EKS addon
+	// is valid for the addon version. It is reported on the addon in the control plane status.
+	EKSAddonConfigurationValidCondition clusterv1.ConditionType = "ConfigurationValid"
+	// EKSAddonConfigurationInvalidReason used to report that the configuration of an EKS addon doesn't
+	// match the configuration schema of the addon version.
+	EKSAddonConfigurationInvalidReason = "ConfigurationInvalid"
+)
+
 const (
 	// EKSIdentityProviderConfiguredCondition condition reports on the successful association of identity provider config.
 	EKSIdentityProviderConfiguredCondition clusterv1.ConditionType = "EKSIdentityProviderConfigured"
@@ -24,6 +24,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 // ControlPlaneLoggingSpec defines what EKS control plane logs that should be enabled.
@@ -128,7 +129,10 @@ type Addon struct {
 	// +kubebuilder:validation:MinLength:=2
 	// +kubebuilder:validation:Required
 	Name string `json:"name"`
-	// Version is the version of the addon to use
+	// Version is the version of the addon to use. Instead of a version it can be a version
+	// selector, which is resolved for the Kubernetes version of the control plane: "latest"
+	// for the latest compatible version, "default" for the version EKS marks as the default
+	// or a semver range such as ">=1.15.0 <1.16.0" for the latest compatible version in the range.
 	Version string `json:"version"`
 	// Configuration of the EKS addon
 	// +optional
@@ -143,6 +147,52 @@ type Addon struct {
 	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
 }
 
+// AddonMigration defines the EKS addon which a self-managed component is handed over to.
+// The addon adopts the resources of the component with the overwrite conflict resolution.
+type AddonMigration struct {
+	// Version is the version of the addon, or a version selector. Defaults to the version
+	// EKS marks as the default for the Kubernetes version of the cluster.
+	// +kubebuilder:default=default
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Configuration of the EKS addon. Defaults to the configuration of the self-managed
+	// component.
+	// +optional
+	Configuration string `json:"configuration,omitempty"`
+	// ServiceAccountRoleArn is the ARN of an IAM role to bind to the addons service account
+	// +optional
+	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
+}
+
+// ComponentOwner is the owner of a cluster component which can be managed by CAPA or by an
+// EKS addon.
+type ComponentOwner string
+
+var (
+	// ComponentOwnerSelfManaged indicates that the component is managed by CAPA.
+	ComponentOwnerSelfManaged = ComponentOwner("SelfManaged")
+
+	// ComponentOwnerMigrating indicates that the component is being handed over to its EKS
+	// addon, and isn't managed by CAPA anymore.
+	ComponentOwnerMigrating = ComponentOwner("Migrating")
+
+	// ComponentOwnerAddon indicates that the component is managed by its EKS addon.
+	ComponentOwnerAddon = ComponentOwner("Addon")
+
+	// ComponentOwnerDisabled indicates that the component is removed from the cluster.
+	ComponentOwnerDisabled = ComponentOwner("Disabled")
+)
+
+// ComponentOwnership records the owners of the cluster components.
+type ComponentOwnership struct {
+	// VpcCni is the owner of the Amazon VPC CNI.
+	// +optional
+	VpcCni ComponentOwner `json:"vpcCni,omitempty"`
+	// KubeProxy is the owner of kube-proxy.
+	// +optional
+	KubeProxy ComponentOwner `json:"kubeProxy,omitempty"`
+}
+
 // AddonResolution defines the method for resolving parameter conflicts.
 type AddonResolution string
 
@@ -190,6 +240,10 @@ type AddonState struct {
 	Version string `json:"version"`
 	// ARN is the AWS ARN of the addon
 	ARN string `json:"arn"`
+	// ResolvedVersion is the version the version selector of the addon resolved to
+	// for the Kubernetes version of the control plane.
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
 	// ServiceAccountRoleArn is the ARN of the IAM role used for the service account
 	ServiceAccountRoleArn *string `json:"serviceAccountRoleARN,omitempty"`
 	// CreatedAt is the date and time the addon was created at
@@ -200,6 +254,9 @@ type AddonState struct {
 	Status *string `json:"status,omitempty"`
 	// Issues is a list of issue associated with the addon
 	Issues []AddonIssue `json:"issues,omitempty"`
+	// Conditions defines current service state of the addon.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
 // AddonIssue represents an issue with an addon.
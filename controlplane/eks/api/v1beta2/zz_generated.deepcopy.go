@@ -149,6 +149,11 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 	in.EndpointAccess.DeepCopyInto(&out.EndpointAccess)
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	in.Bastion.DeepCopyInto(&out.Bastion)
+	if in.S3Bucket != nil {
+		in, out := &in.S3Bucket, &out.S3Bucket
+		*out = new(apiv1beta2.S3Bucket)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TokenMethod != nil {
 		in, out := &in.TokenMethod, &out.TokenMethod
 		*out = new(EKSTokenMethod)
@@ -171,7 +176,7 @@ func (in *AWSManagedControlPlaneSpec) DeepCopyInto(out *AWSManagedControlPlaneSp
 		(*in).DeepCopyInto(*out)
 	}
 	in.VpcCni.DeepCopyInto(&out.VpcCni)
-	out.KubeProxy = in.KubeProxy
+	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSManagedControlPlaneSpec.
@@ -218,6 +223,11 @@ func (in *AWSManagedControlPlaneStatus) DeepCopyInto(out *AWSManagedControlPlane
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ComponentOwnership != nil {
+		in, out := &in.ComponentOwnership, &out.ComponentOwnership
+		*out = new(ComponentOwnership)
+		**out = **in
+	}
 	if in.Addons != nil {
 		in, out := &in.Addons, &out.Addons
 		*out = make([]AddonState, len(*in))
@@ -293,6 +303,26 @@ func (in *AddonIssue) DeepCopy() *AddonIssue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonMigration) DeepCopyInto(out *AddonMigration) {
+	*out = *in
+	if in.ServiceAccountRoleArn != nil {
+		in, out := &in.ServiceAccountRoleArn, &out.ServiceAccountRoleArn
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonMigration.
+func (in *AddonMigration) DeepCopy() *AddonMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonState) DeepCopyInto(out *AddonState) {
 	*out = *in
@@ -315,6 +345,13 @@ func (in *AddonState) DeepCopyInto(out *AddonState) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(v1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonState.
@@ -327,6 +364,21 @@ func (in *AddonState) DeepCopy() *AddonState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentOwnership) DeepCopyInto(out *ComponentOwnership) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentOwnership.
+func (in *ComponentOwnership) DeepCopy() *ComponentOwnership {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentOwnership)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneLoggingSpec) DeepCopyInto(out *ControlPlaneLoggingSpec) {
 	*out = *in
@@ -456,6 +508,11 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeProxy) DeepCopyInto(out *KubeProxy) {
 	*out = *in
+	if in.MigrateToAddon != nil {
+		in, out := &in.MigrateToAddon, &out.MigrateToAddon
+		*out = new(AddonMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeProxy.
@@ -594,6 +651,11 @@ func (in *VpcCni) DeepCopyInto(out *VpcCni) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MigrateToAddon != nil {
+		in, out := &in.MigrateToAddon, &out.MigrateToAddon
+		*out = new(AddonMigration)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VpcCni.
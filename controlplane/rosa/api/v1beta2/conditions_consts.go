@@ -31,6 +31,21 @@ const (
 	// ExternalAuthConfiguredCondition condition reports whether external auth has beed correctly configured.
 	ExternalAuthConfiguredCondition clusterv1.ConditionType = "ExternalAuthConfigured"
 
+	// NodePoolConfigsReadyCondition condition reports whether the tuning configs and kubelet configs of the
+	// ROSAControlPlane have been applied to the cluster.
+	NodePoolConfigsReadyCondition clusterv1.ConditionType = "NodePoolConfigsReady"
+
+	// LogForwardingReadyCondition condition reports whether the log forwarders of the ROSAControlPlane have been applied to the cluster.
+	LogForwardingReadyCondition clusterv1.ConditionType = "LogForwardingReady"
+
+	// IdentityProvidersReadyCondition condition reports whether the identity providers and the group memberships of the
+	// ROSAControlPlane have been applied to the cluster.
+	IdentityProvidersReadyCondition clusterv1.ConditionType = "IdentityProvidersReady"
+
+	// ROSARoleConfigReadyCondition condition reports whether the roles and the OIDC provider of a ROSARoleConfig
+	// have been created.
+	ROSARoleConfigReadyCondition clusterv1.ConditionType = "ROSARoleConfigReady"
+
 	// ReconciliationFailedReason used to report reconciliation failures.
 	ReconciliationFailedReason = "ReconciliationFailed"
 
@@ -39,4 +54,13 @@ const (
 
 	// ROSAControlPlaneInvalidConfigurationReason used to report invalid user input.
 	ROSAControlPlaneInvalidConfigurationReason = "InvalidConfiguration"
+
+	// ROSARoleConfigNotReadyReason used to report that the ROSARoleConfig referenced by a ROSAControlPlane isn't ready yet.
+	ROSARoleConfigNotReadyReason = "ROSARoleConfigNotReady"
+
+	// ROSARoleConfigNotFoundReason used to report that the ROSARoleConfig referenced by a ROSAControlPlane doesn't exist.
+	ROSARoleConfigNotFoundReason = "ROSARoleConfigNotFound"
+
+	// ROSARoleConfigInUseReason used to report that a ROSARoleConfig can't be deleted while ROSAControlPlanes reference it.
+	ROSARoleConfigInUseReason = "InUse"
 )
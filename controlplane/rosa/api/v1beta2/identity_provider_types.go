@@ -0,0 +1,295 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+// IdentityProviderType is the type of an identity provider.
+// +kubebuilder:validation:Enum=GitHub;GitLab;Google;OpenID;LDAP;HTPasswd
+type IdentityProviderType string
+
+const (
+	// IdentityProviderTypeGitHub authenticates users with GitHub or GitHub Enterprise.
+	IdentityProviderTypeGitHub IdentityProviderType = "GitHub"
+
+	// IdentityProviderTypeGitLab authenticates users with GitLab.
+	IdentityProviderTypeGitLab IdentityProviderType = "GitLab"
+
+	// IdentityProviderTypeGoogle authenticates users with Google.
+	IdentityProviderTypeGoogle IdentityProviderType = "Google"
+
+	// IdentityProviderTypeOpenID authenticates users with an OpenID Connect provider.
+	IdentityProviderTypeOpenID IdentityProviderType = "OpenID"
+
+	// IdentityProviderTypeLDAP authenticates users with an LDAP server.
+	IdentityProviderTypeLDAP IdentityProviderType = "LDAP"
+
+	// IdentityProviderTypeHTPasswd authenticates users with user names and passwords.
+	IdentityProviderTypeHTPasswd IdentityProviderType = "HTPasswd"
+)
+
+// IdentityProviderMappingMethod defines how the identities of an identity provider are mapped to users.
+// +kubebuilder:validation:Enum=claim;add;generate;lookup
+type IdentityProviderMappingMethod string
+
+// IdentityProvider is an identity provider users log in to the cluster with.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.github) == (self.type == 'GitHub')", message="github must be set if and only if type is GitHub"
+// +kubebuilder:validation:XValidation:rule="has(self.gitlab) == (self.type == 'GitLab')", message="gitlab must be set if and only if type is GitLab"
+// +kubebuilder:validation:XValidation:rule="has(self.google) == (self.type == 'Google')", message="google must be set if and only if type is Google"
+// +kubebuilder:validation:XValidation:rule="has(self.openID) == (self.type == 'OpenID')", message="openID must be set if and only if type is OpenID"
+// +kubebuilder:validation:XValidation:rule="has(self.ldap) == (self.type == 'LDAP')", message="ldap must be set if and only if type is LDAP"
+// +kubebuilder:validation:XValidation:rule="has(self.htpasswd) == (self.type == 'HTPasswd')", message="htpasswd must be set if and only if type is HTPasswd"
+type IdentityProvider struct {
+	// Name of the identity provider, which is shown on the login page and prefixes the user identities.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=50
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	// +kubebuilder:validation:XValidation:rule="self != 'cluster-admin'", message="cluster-admin is reserved for the identity provider of the generated kubeconfig"
+	// +required
+	Name string `json:"name"`
+
+	// Type of the identity provider. The settings of the identity provider are set in the field matching the type.
+	// +required
+	Type IdentityProviderType `json:"type"`
+
+	// MappingMethod defines how the identities of the identity provider are mapped to users.
+	// +kubebuilder:default=claim
+	// +optional
+	MappingMethod IdentityProviderMappingMethod `json:"mappingMethod,omitempty"`
+
+	// GitHub configures a GitHub identity provider.
+	// +optional
+	GitHub *GitHubIdentityProvider `json:"github,omitempty"`
+
+	// GitLab configures a GitLab identity provider.
+	// +optional
+	GitLab *GitLabIdentityProvider `json:"gitlab,omitempty"`
+
+	// Google configures a Google identity provider.
+	// +optional
+	Google *GoogleIdentityProvider `json:"google,omitempty"`
+
+	// OpenID configures an OpenID Connect identity provider.
+	// +optional
+	OpenID *OpenIDIdentityProvider `json:"openID,omitempty"`
+
+	// LDAP configures an LDAP identity provider.
+	// +optional
+	LDAP *LDAPIdentityProvider `json:"ldap,omitempty"`
+
+	// HTPasswd configures an identity provider with user names and passwords.
+	// +optional
+	HTPasswd *HTPasswdIdentityProvider `json:"htpasswd,omitempty"`
+}
+
+// GitHubIdentityProvider configures a GitHub identity provider.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.organizations) != has(self.teams)", message="exactly one of organizations or teams must be set"
+type GitHubIdentityProvider struct {
+	// ClientID of the registered GitHub OAuth application.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+
+	// ClientSecret refers to a secret that contains the client secret of the GitHub OAuth application
+	// in the `clientSecret` key of the `.data` field.
+	// +required
+	ClientSecret LocalObjectReference `json:"clientSecret"`
+
+	// Hostname of the GitHub Enterprise instance. If not set, github.com is used.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// Organizations restricts which organizations the users must be a member of.
+	// +listType=set
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Teams restricts which teams the users must be a member of, in the `<organization>/<team>` format.
+	// +listType=set
+	// +optional
+	Teams []string `json:"teams,omitempty"`
+}
+
+// GitLabIdentityProvider configures a GitLab identity provider.
+type GitLabIdentityProvider struct {
+	// URL of the GitLab instance.
+	// +kubebuilder:validation:Pattern=`^https:\/\/[^\s]`
+	// +required
+	URL string `json:"url"`
+
+	// ClientID of the registered GitLab OAuth application.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+
+	// ClientSecret refers to a secret that contains the client secret of the GitLab OAuth application
+	// in the `clientSecret` key of the `.data` field.
+	// +required
+	ClientSecret LocalObjectReference `json:"clientSecret"`
+}
+
+// GoogleIdentityProvider configures a Google identity provider.
+type GoogleIdentityProvider struct {
+	// ClientID of the registered Google project.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+
+	// ClientSecret refers to a secret that contains the client secret of the Google project
+	// in the `clientSecret` key of the `.data` field.
+	// +required
+	ClientSecret LocalObjectReference `json:"clientSecret"`
+
+	// HostedDomain restricts the users to a Google Apps domain.
+	// +optional
+	HostedDomain string `json:"hostedDomain,omitempty"`
+}
+
+// OpenIDIdentityProvider configures an OpenID Connect identity provider.
+type OpenIDIdentityProvider struct {
+	// IssuerURL is the URL of the OpenID Connect issuer.
+	// +kubebuilder:validation:Pattern=`^https:\/\/[^\s]`
+	// +required
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientID of the client registered with the OpenID Connect provider.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClientID string `json:"clientID"`
+
+	// ClientSecret refers to a secret that contains the client secret registered with the OpenID Connect
+	// provider in the `clientSecret` key of the `.data` field.
+	// +required
+	ClientSecret LocalObjectReference `json:"clientSecret"`
+
+	// Claims defines the claims the identities are built from.
+	// +required
+	Claims OpenIDClaims `json:"claims"`
+
+	// ExtraScopes are requested in addition to the `openid` scope.
+	// +listType=set
+	// +optional
+	ExtraScopes []string `json:"extraScopes,omitempty"`
+}
+
+// OpenIDClaims defines the claims the identities of an OpenID Connect identity provider are built from.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.email) || has(self.name) || has(self.preferredUsername)", message="at least one of email, name or preferredUsername must be set"
+type OpenIDClaims struct {
+	// Email are the claims used as the email address of the users.
+	// +listType=atomic
+	// +optional
+	Email []string `json:"email,omitempty"`
+
+	// Name are the claims used as the display name of the users.
+	// +listType=atomic
+	// +optional
+	Name []string `json:"name,omitempty"`
+
+	// PreferredUsername are the claims used as the preferred user name of the users.
+	// +listType=atomic
+	// +optional
+	PreferredUsername []string `json:"preferredUsername,omitempty"`
+
+	// Groups are the claims used as the groups of the users.
+	// +listType=atomic
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+}
+
+// LDAPIdentityProvider configures an LDAP identity provider.
+type LDAPIdentityProvider struct {
+	// URL is an RFC 2255 URL which specifies the LDAP server and the search parameters, for example
+	// `ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid`.
+	// +kubebuilder:validation:Pattern=`^ldaps?:\/\/[^\s]`
+	// +required
+	URL string `json:"url"`
+
+	// BindDN is the DN to bind with during the search phase. If not set, an anonymous bind is used.
+	// +optional
+	BindDN string `json:"bindDN,omitempty"`
+
+	// BindPassword refers to a secret that contains the password to bind with during the search phase
+	// in the `bindPassword` key of the `.data` field.
+	// +optional
+	BindPassword *LocalObjectReference `json:"bindPassword,omitempty"`
+
+	// Insecure disables TLS, which is only allowed with `ldap://` URLs.
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+
+	// Attributes defines the LDAP attributes the identities are built from.
+	// +required
+	Attributes LDAPAttributes `json:"attributes"`
+}
+
+// LDAPAttributes defines the LDAP attributes the identities of an LDAP identity provider are built from.
+type LDAPAttributes struct {
+	// ID are the attributes used as the identity of the users.
+	// +kubebuilder:validation:MinItems=1
+	// +listType=atomic
+	// +required
+	ID []string `json:"id"`
+
+	// Email are the attributes used as the email address of the users.
+	// +listType=atomic
+	// +optional
+	Email []string `json:"email,omitempty"`
+
+	// Name are the attributes used as the display name of the users.
+	// +listType=atomic
+	// +optional
+	Name []string `json:"name,omitempty"`
+
+	// PreferredUsername are the attributes used as the preferred user name of the users.
+	// +listType=atomic
+	// +optional
+	PreferredUsername []string `json:"preferredUsername,omitempty"`
+}
+
+// HTPasswdIdentityProvider configures an identity provider with user names and passwords.
+type HTPasswdIdentityProvider struct {
+	// Users refers to a secret whose `.data` field maps the user names to their passwords.
+	// +required
+	Users LocalObjectReference `json:"users"`
+}
+
+// GroupMemberships are the users of the groups granting administrative privileges on the cluster.
+type GroupMemberships struct {
+	// DedicatedAdmins are the users of the `dedicated-admins` group.
+	// +listType=set
+	// +optional
+	DedicatedAdmins []string `json:"dedicatedAdmins,omitempty"`
+
+	// ClusterAdmins are the users of the `cluster-admins` group.
+	// +listType=set
+	// +optional
+	ClusterAdmins []string `json:"clusterAdmins,omitempty"`
+}
+
+// IdentityProviderStatus is the last applied configuration of an identity provider created from the
+// ROSAControlPlane.
+type IdentityProviderStatus struct {
+	// Name of the identity provider.
+	Name string `json:"name"`
+
+	// ConfigHash is a hash of the spec of the identity provider and of the UID and resource
+	// version of its secrets. The content of the secrets, which can't be read back from OpenShift
+	// Cluster Manager, is never hashed. The identity provider is only updated when the hash changes.
+	ConfigHash string `json:"configHash"`
+}
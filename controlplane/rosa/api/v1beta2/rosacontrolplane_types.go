@@ -19,6 +19,7 @@ package v1beta2
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
@@ -77,13 +78,32 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// OpenShift semantic version, for example "4.14.5".
 	Version string `json:"version"`
 
+	// UpgradePolicy defines how and when the control plane is upgraded. By default, an upgrade to
+	// the version is started as soon as the version changes.
+	// +optional
+	UpgradePolicy *UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// RosaRoleConfigRef references a ROSARoleConfig in the same namespace providing the account roles,
+	// the OIDC config and the operator roles of the cluster. When set, the installer, support and worker
+	// roles, the OIDC config ID and the operator roles are taken from the status of the ROSARoleConfig
+	// and rolesRef must not be set.
+	//
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="rosaRoleConfigRef is immutable"
+	// +optional
+	RosaRoleConfigRef *corev1.LocalObjectReference `json:"rosaRoleConfigRef,omitempty"`
+
 	// AWS IAM roles used to perform credential requests by the openshift operators.
-	RolesRef AWSRolesRef `json:"rolesRef"`
+	// Required unless rosaRoleConfigRef is set.
+	// +optional
+	RolesRef AWSRolesRef `json:"rolesRef,omitempty"`
 
 	// The ID of the internal OpenID Connect Provider.
+	// Required unless rosaRoleConfigRef is set.
 	//
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="oidcID is immutable"
-	OIDCID string `json:"oidcID"`
+	// +optional
+	OIDCID string `json:"oidcID,omitempty"`
 
 	// EnableExternalAuthProviders enables external authentication configuration for the cluster.
 	//
@@ -103,12 +123,34 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	ExternalAuthProviders []ExternalAuthProvider `json:"externalAuthProviders,omitempty"`
 
 	// InstallerRoleARN is an AWS IAM role that OpenShift Cluster Manager will assume to create the cluster..
-	InstallerRoleARN string `json:"installerRoleARN"`
+	// Required unless rosaRoleConfigRef is set.
+	// +optional
+	InstallerRoleARN string `json:"installerRoleARN,omitempty"`
 	// SupportRoleARN is an AWS IAM role used by Red Hat SREs to enable
 	// access to the cluster account in order to provide support.
-	SupportRoleARN string `json:"supportRoleARN"`
+	// Required unless rosaRoleConfigRef is set.
+	// +optional
+	SupportRoleARN string `json:"supportRoleARN,omitempty"`
 	// WorkerRoleARN is an AWS IAM role that will be attached to worker instances.
-	WorkerRoleARN string `json:"workerRoleARN"`
+	// Required unless rosaRoleConfigRef is set.
+	// +optional
+	WorkerRoleARN string `json:"workerRoleARN,omitempty"`
+
+	// IdentityProviders are the identity providers users log in to the cluster with. Identity providers removed
+	// from the list are deleted, while identity providers created outside of CAPA are left alone.
+	// Can't be set if "enableExternalAuthProviders" is set to "True".
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	IdentityProviders []IdentityProvider `json:"identityProviders,omitempty"`
+
+	// GroupMemberships are the users of the `dedicated-admins` and `cluster-admins` groups of the cluster.
+	// When set, users not in the lists are removed from the groups, except the user of the kubeconfig generated by CAPA.
+	// Can't be set if "enableExternalAuthProviders" is set to "True".
+	//
+	// +optional
+	GroupMemberships *GroupMemberships `json:"groupMemberships,omitempty"`
 
 	// BillingAccount is an optional AWS account to use for billing the subscription fees for ROSA clusters.
 	// The cost of running each ROSA cluster will be billed to the infrastructure account in which the cluster
@@ -136,6 +178,13 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	Network *NetworkSpec `json:"network,omitempty"`
 
+	// SharedVPC configures the cluster to be installed into a VPC shared from another AWS account, using a
+	// Route 53 private hosted zone owned by that account for the cluster ingress.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="sharedVPC is immutable"
+	// +optional
+	SharedVPC *SharedVPC `json:"sharedVPC,omitempty"`
+
 	// EndpointAccess specifies the publishing scope of cluster endpoints. The
 	// default is Public.
 	//
@@ -144,6 +193,32 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	EndpointAccess RosaEndpointAccessType `json:"endpointAccess,omitempty"`
 
+	// ClusterProxy is the cluster-wide proxy used by the cluster for egress traffic.
+	// +optional
+	ClusterProxy *ClusterProxy `json:"clusterProxy,omitempty"`
+
+	// AdditionalTrustBundle references a ConfigMap in the same namespace holding PEM-encoded
+	// X.509 certificates in the `ca-bundle.crt` key, which are added to the trusted certificates
+	// of the cluster. It's required when the proxy intercepts TLS connections.
+	// +optional
+	AdditionalTrustBundle *corev1.LocalObjectReference `json:"additionalTrustBundle,omitempty"`
+
+	// TuningConfigs are tuning configs created on the cluster, which ROSAMachinePools reference by name
+	// in their `tuningConfigs`.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	TuningConfigs []TuningConfig `json:"tuningConfigs,omitempty"`
+
+	// KubeletConfigs are kubelet configs created on the cluster, which ROSAMachinePools reference by name
+	// in their `kubeletConfig`.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	KubeletConfigs []KubeletConfig `json:"kubeletConfigs,omitempty"`
+
 	// AdditionalTags are user-defined tags to be added on the AWS resources associated with the control plane.
 	// +optional
 	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
@@ -158,6 +233,12 @@ type RosaControlPlaneSpec struct { //nolint: maligned
 	// +optional
 	AuditLogRoleARN string `json:"auditLogRoleARN,omitempty"`
 
+	// LogForwarding configures the forwarding of the logs of the control plane applications to CloudWatch
+	// and S3. Unlike AuditLogRoleARN, it can be changed after the cluster is created. It requires the
+	// ROSALogForwarding feature gate.
+	// +optional
+	LogForwarding *LogForwarding `json:"logForwarding,omitempty"`
+
 	// ProvisionShardID defines the shard where rosa control plane components will be hosted.
 	//
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="provisionShardID is immutable"
@@ -211,6 +292,249 @@ type NetworkSpec struct {
 	NetworkType string `json:"networkType,omitempty"`
 }
 
+// UpgradeMode specifies how the control plane is upgraded.
+type UpgradeMode string
+
+const (
+	// UpgradeModeManual upgrades the control plane to the version when the version changes.
+	UpgradeModeManual UpgradeMode = "Manual"
+
+	// UpgradeModeAutomatic lets OpenShift Cluster Manager upgrade the control plane to the latest
+	// patch version of its channel group in every maintenance window.
+	UpgradeModeAutomatic UpgradeMode = "Automatic"
+)
+
+// UpgradePolicy defines how and when the control plane of a ROSA HCP cluster is upgraded.
+// +kubebuilder:validation:XValidation:rule="self.mode != 'Automatic' || has(self.maintenanceWindow)", message="maintenanceWindow is required with the Automatic mode"
+type UpgradePolicy struct {
+	// Mode specifies how the control plane is upgraded. With the Manual mode, the control plane is
+	// upgraded to the version when the version changes. With the Automatic mode, the control plane
+	// is upgraded to the latest patch version of the channel group in every maintenance window,
+	// and the version is only used to create the cluster.
+	//
+	// +kubebuilder:validation:Enum=Manual;Automatic
+	// +kubebuilder:default=Manual
+	// +optional
+	Mode UpgradeMode `json:"mode,omitempty"`
+
+	// ChannelGroup is the channel group of the OpenShift versions the cluster is installed and
+	// upgraded with.
+	//
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="channelGroup is immutable"
+	// +kubebuilder:validation:Enum=stable;eus;fast;candidate;nightly
+	// +kubebuilder:default=stable
+	// +optional
+	ChannelGroup string `json:"channelGroup,omitempty"`
+
+	// MaintenanceWindow restricts the times at which upgrades start. If not set, upgrades start as
+	// soon as possible.
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+}
+
+// MaintenanceWindowDay is a day of the week of a maintenance window.
+// +kubebuilder:validation:Enum=Sunday;Monday;Tuesday;Wednesday;Thursday;Friday;Saturday
+type MaintenanceWindowDay string
+
+// MaintenanceWindow defines the times at which upgrades start, either with a cron schedule or
+// with days of the week and a range of hours. All times are in UTC.
+// +kubebuilder:validation:XValidation:rule="!has(self.schedule) || (!has(self.days) && !has(self.startHour) && !has(self.endHour))", message="schedule is mutually exclusive with days, startHour and endHour"
+// +kubebuilder:validation:XValidation:rule="has(self.schedule) || has(self.days) || has(self.startHour) || has(self.endHour)", message="either schedule or days and hours must be set"
+type MaintenanceWindow struct {
+	// Schedule is a cron expression of the times at which upgrades start, for example `0 2 * * 6`
+	// for Saturdays at 02:00 UTC.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Days are the days of the week on which upgrades start. If not set, upgrades start on any day.
+	// +optional
+	Days []MaintenanceWindowDay `json:"days,omitempty"`
+
+	// StartHour is the hour of the day from which upgrades start.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	StartHour *int32 `json:"startHour,omitempty"`
+
+	// EndHour is the hour of the day until which upgrades start. It's after StartHour and defaults
+	// to the hour after StartHour, windows crossing midnight aren't supported. It's only supported
+	// with the Manual mode, automatic upgrades start at StartHour.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=24
+	// +optional
+	EndHour *int32 `json:"endHour,omitempty"`
+}
+
+// ScheduledUpgrade is an upgrade of the control plane scheduled in OpenShift Cluster Manager.
+type ScheduledUpgrade struct {
+	// State is the state of the upgrade, for example `scheduled` or `started`.
+	State string `json:"state"`
+
+	// Version is the version the control plane is upgraded to. It's empty for automatic upgrades
+	// which aren't scheduled yet.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// NextRun is the time at which the upgrade starts.
+	// +optional
+	NextRun *metav1.Time `json:"nextRun,omitempty"`
+}
+
+// SharedVPC defines the resources in the account of the VPC owner used by a ROSA HCP cluster installed into a shared VPC.
+type SharedVPC struct {
+	// RouteRoleARN is the ARN of the IAM role in the account of the VPC owner which OpenShift Cluster Manager
+	// assumes to manage the records of the ingress private hosted zone.
+	//
+	// +kubebuilder:validation:Pattern:=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	RouteRoleARN string `json:"routeRoleARN"`
+
+	// IngressPrivateHostedZoneID is the ID of the Route 53 private hosted zone for the cluster ingress,
+	// for example `Z05327581O0D8Y2VMTJAN`. The zone must be associated with the shared VPC.
+	//
+	// +kubebuilder:validation:Pattern:=`^Z[0-9A-Z]{1,32}$`
+	IngressPrivateHostedZoneID string `json:"ingressPrivateHostedZoneID"`
+
+	// VPCEndpointRoleARN is the ARN of the IAM role in the account of the VPC owner which OpenShift Cluster
+	// Manager assumes to manage the VPC endpoint of the hosted control plane in the shared VPC.
+	//
+	// +kubebuilder:validation:Pattern:=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	VPCEndpointRoleARN string `json:"vpcEndpointRoleARN"`
+
+	// InternalCommunicationPrivateHostedZoneID is the ID of the Route 53 private hosted zone for the
+	// communication between the hosted control plane and the nodes, for example `Z0123456789ABCDEFGHIJ`.
+	// The zone must be associated with the shared VPC.
+	//
+	// +kubebuilder:validation:Pattern:=`^Z[0-9A-Z]{1,32}$`
+	InternalCommunicationPrivateHostedZoneID string `json:"internalCommunicationPrivateHostedZoneID"`
+
+	// BaseDomain is the domain of the ingress private hosted zone. The DNS names of the cluster are
+	// subdomains of `<domainPrefix>.<baseDomain>`.
+	//
+	// +kubebuilder:validation:MinLength=1
+	BaseDomain string `json:"baseDomain"`
+}
+
+// ClusterProxy defines the cluster-wide proxy of a ROSA HCP cluster.
+type ClusterProxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests, for example `http://proxy.example.com:3128`.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests, for example `http://proxy.example.com:3128`.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a list of domains, IP addresses and CIDR blocks which bypass the proxy. A domain
+	// prefixed with `.` matches its subdomains only.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// LogForwarding configures the forwarding of the logs of the control plane applications of a ROSA HCP cluster.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.cloudWatch) || has(self.s3)", message="at least one of cloudWatch or s3 must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.groups) || has(self.applications)", message="at least one of groups or applications must be set"
+type LogForwarding struct {
+	// CloudWatch forwards the logs to a CloudWatch log group.
+	// +optional
+	CloudWatch *CloudWatchLogForwarding `json:"cloudWatch,omitempty"`
+
+	// S3 forwards the logs to an S3 bucket.
+	// +optional
+	S3 *S3LogForwarding `json:"s3,omitempty"`
+
+	// Groups are the groups of control plane applications whose logs are forwarded, for example
+	// `api`, `authentication`, `controller manager` or `scheduler`.
+	// +listType=set
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Applications are the control plane applications whose logs are forwarded in addition to the
+	// applications of the groups, for example `kube-apiserver`.
+	// +listType=set
+	// +optional
+	Applications []string `json:"applications,omitempty"`
+}
+
+// CloudWatchLogForwarding is a CloudWatch log group the logs of the control plane are forwarded to.
+type CloudWatchLogForwarding struct {
+	// LogGroupName is the name of the CloudWatch log group.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +required
+	LogGroupName string `json:"logGroupName"`
+
+	// LogDistributionRoleARN is the ARN of the role assumed to write the logs to the log group.
+	// +kubebuilder:validation:Pattern:=`^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$`
+	// +required
+	LogDistributionRoleARN string `json:"logDistributionRoleARN"`
+}
+
+// S3LogForwarding is an S3 bucket the logs of the control plane are forwarded to.
+type S3LogForwarding struct {
+	// BucketName is the name of the S3 bucket.
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	BucketName string `json:"bucketName"`
+
+	// BucketPrefix is the prefix of the keys of the log objects.
+	// +optional
+	BucketPrefix string `json:"bucketPrefix,omitempty"`
+}
+
+// LogForwarderStatus is the state of a log forwarder of a ROSA HCP cluster.
+type LogForwarderStatus struct {
+	// Destination is the type of destination of the log forwarder, `CloudWatch` or `S3`.
+	Destination string `json:"destination"`
+
+	// ID is the ID of the log forwarder in OpenShift Cluster Manager.
+	ID string `json:"id"`
+
+	// State is the state of the log forwarder reported by OpenShift Cluster Manager.
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Message explains the state of the log forwarder.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TuningConfig is a node tuning config of a ROSA HCP cluster.
+type TuningConfig struct {
+	// Name of the tuning config.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Spec is the spec of the Node Tuning Operator `Tuned` resource, with its `profile` and
+	// `recommend` fields.
+	//
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
+}
+
+// KubeletConfig is a kubelet config of a ROSA HCP cluster.
+type KubeletConfig struct {
+	// Name of the kubelet config.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern:=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// PodPidsLimit is the maximum number of processes in a pod. Values above 16384 require the
+	// organization to be allowed unsafe pod PIDs limits.
+	//
+	// +kubebuilder:validation:Minimum=4096
+	// +kubebuilder:validation:Maximum=3694303
+	PodPidsLimit int32 `json:"podPidsLimit"`
+}
+
 // DefaultMachinePoolSpec defines the configuration for the required worker nodes provisioned as part of the cluster creation.
 type DefaultMachinePoolSpec struct {
 	// The instance type to use, for example `r5.xlarge`. Instance type ref; https://aws.amazon.com/ec2/instance-types/
@@ -634,6 +958,41 @@ type RosaControlPlaneStatus struct {
 	ConsoleURL string `json:"consoleURL,omitempty"`
 	// OIDCEndpointURL is the endpoint url for the managed OIDC provider.
 	OIDCEndpointURL string `json:"oidcEndpointURL,omitempty"`
+	// ScheduledUpgrade is the upgrade of the control plane scheduled in OpenShift Cluster Manager.
+	// +optional
+	ScheduledUpgrade *ScheduledUpgrade `json:"scheduledUpgrade,omitempty"`
+	// AutomaticUpgradePolicyID is the ID of the automatic upgrade policy created in OpenShift Cluster
+	// Manager with the Automatic mode. Only this policy is cancelled when switching to the Manual mode.
+	// +optional
+	AutomaticUpgradePolicyID string `json:"automaticUpgradePolicyID,omitempty"`
+	// ClusterProxy is the cluster-wide proxy applied to the cluster.
+	// +optional
+	ClusterProxy *ClusterProxy `json:"clusterProxy,omitempty"`
+	// AdditionalTrustBundleHash is the SHA-256 hash of the additional trust bundle applied to
+	// the cluster. The trust bundle itself can't be read back from OpenShift Cluster Manager.
+	// +optional
+	AdditionalTrustBundleHash string `json:"additionalTrustBundleHash,omitempty"`
+	// LogForwarders are the log forwarders created from the logForwarding spec. Only these log
+	// forwarders are updated and deleted, log forwarders created outside of CAPA are left alone.
+	// +optional
+	LogForwarders []LogForwarderStatus `json:"logForwarders,omitempty"`
+	// TuningConfigs are the names of the tuning configs created from the tuningConfigs spec. Only
+	// these tuning configs are updated and deleted, tuning configs created outside of CAPA are left alone.
+	// +listType=set
+	// +optional
+	TuningConfigs []string `json:"tuningConfigs,omitempty"`
+	// KubeletConfigs are the names of the kubelet configs created from the kubeletConfigs spec. Only
+	// these kubelet configs are updated and deleted, kubelet configs created outside of CAPA are left alone.
+	// +listType=set
+	// +optional
+	KubeletConfigs []string `json:"kubeletConfigs,omitempty"`
+	// IdentityProviders are the identity providers created from the identityProviders spec. Only
+	// these identity providers are updated and deleted, identity providers created outside of CAPA
+	// are left alone.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	IdentityProviders []IdentityProviderStatus `json:"identityProviders,omitempty"`
 }
 
 // +kubebuilder:object:root=true
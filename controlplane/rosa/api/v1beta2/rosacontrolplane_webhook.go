@@ -1,7 +1,11 @@
 package v1beta2
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"net/url"
+	"strings"
 
 	"github.com/blang/semver"
 	kmsArnRegexpValidator "github.com/openshift-online/ocm-common/pkg/resource/validations"
@@ -11,6 +15,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa/schedule"
 )
 
 // SetupWebhookWithManager will setup the webhooks for the ROSAControlPlane.
@@ -42,7 +49,14 @@ func (r *ROSAControlPlane) ValidateCreate() (warnings admission.Warnings, err er
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateLogForwarding(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRoles()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateClusterProxy()...)
+	allErrs = append(allErrs, r.validateUpgradePolicy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	if len(allErrs) == 0 {
@@ -68,7 +82,18 @@ func (r *ROSAControlPlane) ValidateUpdate(old runtime.Object) (warnings admissio
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateExternalAuthProviders(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateLogForwarding(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRoles()...)
 	allErrs = append(allErrs, r.validateNetwork()...)
+	allErrs = append(allErrs, r.validateClusterProxy()...)
+	allErrs = append(allErrs, r.validateUpgradePolicy()...)
 	allErrs = append(allErrs, r.Spec.AdditionalTags.Validate()...)
 
 	if len(allErrs) == 0 {
@@ -128,6 +153,77 @@ func (r *ROSAControlPlane) validateNetwork() field.ErrorList {
 	return allErrs
 }
 
+func (r *ROSAControlPlane) validateClusterProxy() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.ClusterProxy == nil {
+		return allErrs
+	}
+
+	rootPath := field.NewPath("spec", "clusterProxy")
+	proxy := r.Spec.ClusterProxy
+
+	if proxy.HTTPProxy != "" {
+		if err := validateProxyURL(proxy.HTTPProxy, "http"); err != nil {
+			allErrs = append(allErrs, field.Invalid(rootPath.Child("httpProxy"), proxy.HTTPProxy, err.Error()))
+		}
+	}
+
+	if proxy.HTTPSProxy != "" {
+		if err := validateProxyURL(proxy.HTTPSProxy, "http", "https"); err != nil {
+			allErrs = append(allErrs, field.Invalid(rootPath.Child("httpsProxy"), proxy.HTTPSProxy, err.Error()))
+		}
+	}
+
+	if len(proxy.NoProxy) > 0 && proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Invalid(rootPath.Child("noProxy"), proxy.NoProxy, "can only be set if httpProxy or httpsProxy is set"))
+	}
+
+	return allErrs
+}
+
+func (r *ROSAControlPlane) validateUpgradePolicy() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.UpgradePolicy == nil || r.Spec.UpgradePolicy.MaintenanceWindow == nil {
+		return allErrs
+	}
+
+	rootPath := field.NewPath("spec", "upgradePolicy", "maintenanceWindow")
+	window := r.Spec.UpgradePolicy.MaintenanceWindow
+
+	if window.Schedule != "" {
+		if cron, err := schedule.ParseCron(window.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(rootPath.Child("schedule"), window.Schedule, err.Error()))
+		} else if !cron.Occurs() {
+			allErrs = append(allErrs, field.Invalid(rootPath.Child("schedule"), window.Schedule, "schedule never occurs"))
+		}
+	}
+
+	// automatic upgrades start at the start hour, OCM schedules them with a single cron schedule.
+	if r.Spec.UpgradePolicy.Mode == UpgradeModeAutomatic && window.EndHour != nil {
+		allErrs = append(allErrs, field.Forbidden(rootPath.Child("endHour"), "endHour is not supported with the Automatic mode"))
+	}
+
+	if window.StartHour != nil && window.EndHour != nil && *window.EndHour <= *window.StartHour {
+		allErrs = append(allErrs, field.Invalid(rootPath.Child("endHour"), *window.EndHour, "must be after startHour, windows crossing midnight aren't supported"))
+	}
+
+	return allErrs
+}
+
+func validateProxyURL(proxyURL string, schemes ...string) error {
+	parsedURL, err := url.ParseRequestURI(proxyURL)
+	if err != nil {
+		return errors.New("must be a valid URL")
+	}
+	for _, scheme := range schemes {
+		if parsedURL.Scheme == scheme {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must use the %s scheme", strings.Join(schemes, " or "))
+}
+
 func (r *ROSAControlPlane) validateEtcdEncryptionKMSArn() *field.Error {
 	err := kmsArnRegexpValidator.ValidateKMSKeyARN(&r.Spec.EtcdEncryptionKMSARN)
 	if err != nil {
@@ -137,12 +233,61 @@ func (r *ROSAControlPlane) validateEtcdEncryptionKMSArn() *field.Error {
 	return nil
 }
 
+// validateRoles ensures the roles and the OIDC config are set, unless they are provided by a ROSARoleConfig.
+func (r *ROSAControlPlane) validateRoles() field.ErrorList {
+	rolesRefPath := field.NewPath("spec", "rolesRef")
+	if r.Spec.RosaRoleConfigRef != nil {
+		if r.Spec.RolesRef != (AWSRolesRef{}) {
+			return field.ErrorList{field.Forbidden(rolesRefPath, "must not be set when spec.rosaRoleConfigRef is set")}
+		}
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if r.Spec.RolesRef == (AWSRolesRef{}) {
+		allErrs = append(allErrs, field.Required(rolesRefPath, "must be set unless spec.rosaRoleConfigRef is set"))
+	}
+	for _, required := range []struct {
+		name  string
+		value string
+	}{
+		{name: "installerRoleARN", value: r.Spec.InstallerRoleARN},
+		{name: "supportRoleARN", value: r.Spec.SupportRoleARN},
+		{name: "workerRoleARN", value: r.Spec.WorkerRoleARN},
+		{name: "oidcID", value: r.Spec.OIDCID},
+	} {
+		if required.value == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", required.name), "must be set unless spec.rosaRoleConfigRef is set"))
+		}
+	}
+
+	return allErrs
+}
+
+func (r *ROSAControlPlane) validateLogForwarding() *field.Error {
+	if r.Spec.LogForwarding != nil && !feature.Gates.Enabled(feature.ROSALogForwarding) {
+		return field.Forbidden(field.NewPath("spec", "logForwarding"), "can be set only if the ROSALogForwarding feature gate is enabled")
+	}
+
+	return nil
+}
+
 func (r *ROSAControlPlane) validateExternalAuthProviders() *field.Error {
 	if !r.Spec.EnableExternalAuthProviders && len(r.Spec.ExternalAuthProviders) > 0 {
 		return field.Invalid(field.NewPath("spec.ExternalAuthProviders"), r.Spec.ExternalAuthProviders,
 			"can only be set if spec.EnableExternalAuthProviders is set to 'True'")
 	}
 
+	if r.Spec.EnableExternalAuthProviders && len(r.Spec.IdentityProviders) > 0 {
+		return field.Invalid(field.NewPath("spec.identityProviders"), r.Spec.IdentityProviders,
+			"can't be set if spec.EnableExternalAuthProviders is set to 'True'")
+	}
+
+	if r.Spec.EnableExternalAuthProviders && r.Spec.GroupMemberships != nil {
+		return field.Invalid(field.NewPath("spec.groupMemberships"), r.Spec.GroupMemberships,
+			"can't be set if spec.EnableExternalAuthProviders is set to 'True'")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
+)
+
+func TestROSAControlPlaneValidateClusterProxy(t *testing.T) {
+	tests := []struct {
+		name          string
+		proxy         *ClusterProxy
+		expectErrPath []string
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name: "valid proxies",
+			proxy: &ClusterProxy{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "https://proxy.example.com:3129",
+				NoProxy:    []string{"example.com", "10.0.0.0/16"},
+			},
+		},
+		{
+			name:  "http proxy for https",
+			proxy: &ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128"},
+		},
+		{
+			name:          "https scheme for http proxy",
+			proxy:         &ClusterProxy{HTTPProxy: "https://proxy.example.com:3128"},
+			expectErrPath: []string{"spec.clusterProxy.httpProxy"},
+		},
+		{
+			name:          "invalid urls",
+			proxy:         &ClusterProxy{HTTPProxy: "proxy.example.com", HTTPSProxy: "ftp://proxy.example.com"},
+			expectErrPath: []string{"spec.clusterProxy.httpProxy", "spec.clusterProxy.httpsProxy"},
+		},
+		{
+			name:          "no proxy without proxies",
+			proxy:         &ClusterProxy{NoProxy: []string{"example.com"}},
+			expectErrPath: []string{"spec.clusterProxy.noProxy"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ROSAControlPlane{Spec: RosaControlPlaneSpec{ClusterProxy: tt.proxy}}
+			errs := controlPlane.validateClusterProxy()
+
+			paths := []string{}
+			for _, err := range errs {
+				paths = append(paths, err.Field)
+			}
+			g.Expect(paths).To(ConsistOf(tt.expectErrPath))
+		})
+	}
+}
+
+func TestROSAControlPlaneValidateUpgradePolicy(t *testing.T) {
+	tests := []struct {
+		name          string
+		upgradePolicy *UpgradePolicy
+		expectErrPath []string
+	}{
+		{
+			name: "no upgrade policy",
+		},
+		{
+			name: "manual mode with hours",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeManual,
+				MaintenanceWindow: &MaintenanceWindow{StartHour: ptr.To[int32](1), EndHour: ptr.To[int32](5)},
+			},
+		},
+		{
+			name: "manual mode with end hour before start hour",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeManual,
+				MaintenanceWindow: &MaintenanceWindow{StartHour: ptr.To[int32](5), EndHour: ptr.To[int32](1)},
+			},
+			expectErrPath: []string{"spec.upgradePolicy.maintenanceWindow.endHour"},
+		},
+		{
+			name: "automatic mode with start hour",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeAutomatic,
+				MaintenanceWindow: &MaintenanceWindow{Days: []MaintenanceWindowDay{"Saturday"}, StartHour: ptr.To[int32](1)},
+			},
+		},
+		{
+			name: "automatic mode with end hour",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeAutomatic,
+				MaintenanceWindow: &MaintenanceWindow{StartHour: ptr.To[int32](1), EndHour: ptr.To[int32](5)},
+			},
+			expectErrPath: []string{"spec.upgradePolicy.maintenanceWindow.endHour"},
+		},
+		{
+			name: "invalid schedule",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeAutomatic,
+				MaintenanceWindow: &MaintenanceWindow{Schedule: "0 2 * *"},
+			},
+			expectErrPath: []string{"spec.upgradePolicy.maintenanceWindow.schedule"},
+		},
+		{
+			name: "schedule which never occurs",
+			upgradePolicy: &UpgradePolicy{
+				Mode:              UpgradeModeAutomatic,
+				MaintenanceWindow: &MaintenanceWindow{Schedule: "0 2 30 2 *"},
+			},
+			expectErrPath: []string{"spec.upgradePolicy.maintenanceWindow.schedule"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ROSAControlPlane{Spec: RosaControlPlaneSpec{UpgradePolicy: tt.upgradePolicy}}
+			errs := controlPlane.validateUpgradePolicy()
+
+			paths := []string{}
+			for _, err := range errs {
+				paths = append(paths, err.Field)
+			}
+			g.Expect(paths).To(ConsistOf(tt.expectErrPath))
+		})
+	}
+}
+
+func TestROSAControlPlaneValidateLogForwarding(t *testing.T) {
+	tests := []struct {
+		name           string
+		logForwarding  *LogForwarding
+		featureEnabled bool
+		expectErr      bool
+	}{
+		{
+			name: "no log forwarding",
+		},
+		{
+			name:          "log forwarding without the feature gate",
+			logForwarding: &LogForwarding{S3: &S3LogForwarding{BucketName: "rosa-logs"}},
+			expectErr:     true,
+		},
+		{
+			name:           "log forwarding with the feature gate",
+			logForwarding:  &LogForwarding{S3: &S3LogForwarding{BucketName: "rosa-logs"}},
+			featureEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ROSALogForwarding, tt.featureEnabled)()
+
+			controlPlane := &ROSAControlPlane{Spec: RosaControlPlaneSpec{LogForwarding: tt.logForwarding}}
+			err := controlPlane.validateLogForwarding()
+			if tt.expectErr {
+				g.Expect(err).ToNot(BeNil())
+				g.Expect(err.Field).To(Equal("spec.logForwarding"))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestROSAControlPlaneValidateRoles(t *testing.T) {
+	roles := RosaControlPlaneSpec{
+		InstallerRoleARN: "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Installer-Role",
+		SupportRoleARN:   "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Support-Role",
+		WorkerRoleARN:    "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Worker-Role",
+		OIDCID:           "2a3b4c5d6e7f8g9h0i1j2k3l4m5n6o7p",
+		RolesRef:         AWSRolesRef{IngressARN: "arn:aws:iam::123456789012:role/capa-openshift-ingress-operator-cloud-credentials"},
+	}
+	roleConfigRef := &corev1.LocalObjectReference{Name: "capa-roles"}
+
+	tests := []struct {
+		name         string
+		spec         func() RosaControlPlaneSpec
+		expectFields []string
+	}{
+		{
+			name: "roles set",
+			spec: func() RosaControlPlaneSpec { return roles },
+		},
+		{
+			name: "rosaRoleConfigRef set",
+			spec: func() RosaControlPlaneSpec { return RosaControlPlaneSpec{RosaRoleConfigRef: roleConfigRef} },
+		},
+		{
+			name:         "neither roles nor rosaRoleConfigRef set",
+			spec:         func() RosaControlPlaneSpec { return RosaControlPlaneSpec{} },
+			expectFields: []string{"spec.rolesRef", "spec.installerRoleARN", "spec.supportRoleARN", "spec.workerRoleARN", "spec.oidcID"},
+		},
+		{
+			name: "rolesRef and rosaRoleConfigRef set",
+			spec: func() RosaControlPlaneSpec {
+				spec := roles
+				spec.RosaRoleConfigRef = roleConfigRef
+				return spec
+			},
+			expectFields: []string{"spec.rolesRef"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &ROSAControlPlane{Spec: tt.spec()}
+			errs := controlPlane.validateRoles()
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			g.Expect(fields).To(ConsistOf(tt.expectFields))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ROSARoleConfigSpec defines the desired state of ROSARoleConfig.
+type ROSARoleConfigSpec struct {
+	// AccountRoleConfig configures the installer, support and worker account roles.
+	AccountRoleConfig AccountRoleConfig `json:"accountRoleConfig"`
+
+	// OperatorRoleConfig configures the roles assumed by the cluster operators.
+	OperatorRoleConfig OperatorRoleConfig `json:"operatorRoleConfig"`
+
+	// The AWS Region used for the AWS API calls. IAM resources are global, so any region of the
+	// AWS partition can be used.
+	//
+	// +kubebuilder:default=us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// AdditionalTags are user-defined tags to be added on the IAM roles.
+	// +optional
+	AdditionalTags infrav1.Tags `json:"additionalTags,omitempty"`
+
+	// CredentialsSecretRef references a secret with necessary credentials to connect to the OCM API.
+	// The secret should contain the following data keys:
+	// - ocmToken: eyJhbGciOiJIUzI1NiIsI....
+	// - ocmApiUrl: Optional, defaults to 'https://api.openshift.com'
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// IdentityRef is a reference to an identity to be used when reconciling the roles.
+	// If no identity is specified, the default identity for this controller will be used.
+	//
+	// +optional
+	IdentityRef *infrav1.AWSIdentityReference `json:"identityRef,omitempty"`
+}
+
+// AccountRoleConfig configures the account roles of ROSA HCP clusters.
+type AccountRoleConfig struct {
+	// Prefix of the account roles, which are named `<prefix>-HCP-ROSA-Installer-Role`,
+	// `<prefix>-HCP-ROSA-Support-Role` and `<prefix>-HCP-ROSA-Worker-Role`.
+	//
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="prefix is immutable"
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:Pattern:=`^[\w+=,.@-]+$`
+	Prefix string `json:"prefix"`
+}
+
+// OperatorRoleConfig configures the operator roles of ROSA HCP clusters.
+type OperatorRoleConfig struct {
+	// Prefix of the operator roles, which are named `<prefix>-<operator namespace>-<operator name>`.
+	//
+	// +immutable
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="prefix is immutable"
+	// +kubebuilder:validation:MaxLength:=32
+	// +kubebuilder:validation:Pattern:=`^[\w+=,.@-]+$`
+	Prefix string `json:"prefix"`
+}
+
+// ROSARoleConfigStatus defines the observed state of ROSARoleConfig.
+type ROSARoleConfigStatus struct {
+	// Ready denotes that all the roles and the OIDC provider have been created.
+	// +kubebuilder:default=false
+	Ready bool `json:"ready"`
+
+	// OIDCID is the ID of the managed OIDC config created in OpenShift Cluster Manager.
+	// +optional
+	OIDCID string `json:"oidcID,omitempty"`
+
+	// OIDCProviderARN is the ARN of the IAM OIDC provider trusting the issuer of the OIDC config.
+	// +optional
+	OIDCProviderARN string `json:"oidcProviderARN,omitempty"`
+
+	// AccountRolesRef holds the ARNs of the account roles.
+	// +optional
+	AccountRolesRef AccountRolesRef `json:"accountRolesRef,omitempty"`
+
+	// OperatorRolesRef holds the ARNs of the operator roles.
+	// +optional
+	OperatorRolesRef AWSRolesRef `json:"operatorRolesRef,omitempty"`
+
+	// Conditions specifies the conditions of the ROSARoleConfig.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// AccountRolesRef holds the ARNs of the account roles of ROSA HCP clusters.
+type AccountRolesRef struct {
+	// InstallerRoleARN is the ARN of the role that OpenShift Cluster Manager assumes to create the cluster.
+	// +optional
+	InstallerRoleARN string `json:"installerRoleARN,omitempty"`
+
+	// SupportRoleARN is the ARN of the role used by Red Hat SREs to access the cluster account.
+	// +optional
+	SupportRoleARN string `json:"supportRoleARN,omitempty"`
+
+	// WorkerRoleARN is the ARN of the role attached to worker instances.
+	// +optional
+	WorkerRoleARN string `json:"workerRoleARN,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=rosaroleconfigs,shortName=rosarole,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Roles and OIDC provider are ready to be used by ROSA control planes"
+// +kubebuilder:printcolumn:name="OIDC ID",type="string",JSONPath=".status.oidcID",description="ID of the OIDC config"
+
+// ROSARoleConfig is the Schema for the rosaroleconfigs API. It creates the account roles, the OIDC config and
+// provider and the operator roles needed by ROSA HCP clusters, which ROSAControlPlanes can reference instead
+// of the ARNs of roles created out-of-band.
+type ROSARoleConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ROSARoleConfigSpec   `json:"spec,omitempty"`
+	Status ROSARoleConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ROSARoleConfigList contains a list of ROSARoleConfig.
+type ROSARoleConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ROSARoleConfig `json:"items"`
+}
+
+// GetConditions returns the conditions of the ROSARoleConfig.
+func (r *ROSARoleConfig) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the conditions of the ROSARoleConfig.
+func (r *ROSARoleConfig) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&ROSARoleConfig{}, &ROSARoleConfigList{})
+}
@@ -19,6 +19,9 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +31,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/google/go-cmp/cmp"
 	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -39,6 +43,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apiserver/pkg/storage/names"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -54,6 +59,7 @@ import (
 
 	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/feature"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/annotations"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
@@ -76,6 +82,9 @@ const (
 	// ROSAControlPlaneForceDeleteAnnotation annotation can be set to force the deletion of ROSAControlPlane bypassing any deletion validations/errors.
 	ROSAControlPlaneForceDeleteAnnotation = "controlplane.cluster.x-k8s.io/rosacontrolplane-force-delete"
 
+	// caBundleKey is the key of the PEM-encoded certificates in a ConfigMap holding a CA bundle.
+	caBundleKey = "ca-bundle.crt"
+
 	// ExternalAuthProviderLastAppliedAnnotation annotation tracks the last applied external auth configuration to inform if an update is required.
 	ExternalAuthProviderLastAppliedAnnotation = "controlplane.cluster.x-k8s.io/rosacontrolplane-last-applied-external-auth-provider"
 )
@@ -131,6 +140,7 @@ func (r *ROSAControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr c
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosacontrolplanes/finalizers,verbs=update
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosaroleconfigs,verbs=get;list;watch
 
 // Reconcile will reconcile RosaControlPlane Resources.
 func (r *ROSAControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
@@ -200,6 +210,18 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 		}
 	}
 
+	var roleConfig *rosacontrolplanev1.ROSARoleConfig
+	if rosaScope.ControlPlane.Spec.RosaRoleConfigRef != nil {
+		var err error
+		roleConfig, err = r.reconcileRosaRoleConfig(ctx, rosaScope)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if roleConfig == nil {
+			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		}
+	}
+
 	ocmClient, err := rosa.NewOCMClient(ctx, rosaScope)
 	if err != nil {
 		// TODO: need to expose in status, as likely the credentials are invalid
@@ -211,7 +233,7 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 		return ctrl.Result{}, fmt.Errorf("failed to transform caller identity to creator: %w", err)
 	}
 
-	validationMessage, err := validateControlPlaneSpec(ocmClient, rosaScope)
+	validationMessage, err := validateControlPlaneSpec(ocmClient, rosaScope, creator)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to validate ROSAControlPlane.spec: %w", err)
 	}
@@ -250,13 +272,23 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 			}
 			rosaScope.ControlPlane.Spec.ControlPlaneEndpoint = *apiEndpoint
 
-			if err := r.updateOCMCluster(rosaScope, ocmClient, cluster, creator); err != nil {
+			if err := r.updateOCMCluster(ctx, rosaScope, ocmClient, cluster, creator); err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to update rosa control plane: %w", err)
 			}
 			if err := r.reconcileClusterVersion(rosaScope, ocmClient, cluster); err != nil {
 				return ctrl.Result{}, err
 			}
 
+			if err := r.reconcileNodePoolConfigs(ctx, rosaScope, ocmClient, cluster); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to reconcile node pool configs: %w", err)
+			}
+
+			if feature.Gates.Enabled(feature.ROSALogForwarding) {
+				if err := r.reconcileLogForwarding(ctx, rosaScope, cluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to reconcile log forwarding: %w", err)
+				}
+			}
+
 			if rosaScope.ControlPlane.Spec.EnableExternalAuthProviders {
 				if err := r.reconcileExternalAuth(ctx, rosaScope, cluster); err != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to reconcile external auth: %w", err)
@@ -267,8 +299,16 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 				if err := r.reconcileKubeconfig(ctx, rosaScope, ocmClient, cluster); err != nil {
 					return ctrl.Result{}, fmt.Errorf("failed to reconcile kubeconfig: %w", err)
 				}
+
+				if err := r.reconcileIdentityProviders(ctx, rosaScope, ocmClient, cluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to reconcile identity providers: %w", err)
+				}
 			}
 
+			if rosaScope.ControlPlane.Status.ScheduledUpgrade != nil {
+				// Requeue so that the status of the scheduled upgrade is kept up to date.
+				return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+			}
 			return ctrl.Result{}, nil
 		case cmv1.ClusterStateError:
 			errorMessage := cluster.Status().ProvisionErrorMessage()
@@ -294,12 +334,22 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 		return ctrl.Result{RequeueAfter: time.Second * 60}, nil
 	}
 
-	ocmClusterSpec, err := buildOCMClusterSpec(rosaScope.ControlPlane.Spec, creator)
+	additionalTrustBundle, err := getAdditionalTrustBundle(ctx, rosaScope)
+	if err != nil {
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneValidCondition,
+			rosacontrolplanev1.ROSAControlPlaneInvalidConfigurationReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return ctrl.Result{}, err
+	}
+
+	ocmClusterSpec, err := buildOCMClusterSpec(controlPlaneSpecWithRoles(rosaScope.ControlPlane.Spec, roleConfig), creator, additionalTrustBundle)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	cluster, err = ocmClient.CreateCluster(ocmClusterSpec)
+	cluster, err = createOCMCluster(ctx, rosaScope, ocmClient, ocmClusterSpec, awsClusterSettings(rosaScope.ControlPlane.Spec))
 	if err != nil {
 		conditions.MarkFalse(rosaScope.ControlPlane,
 			rosacontrolplanev1.ROSAControlPlaneReadyCondition,
@@ -311,10 +361,60 @@ func (r *ROSAControlPlaneReconciler) reconcileNormal(ctx context.Context, rosaSc
 
 	rosaScope.Info("cluster created", "state", cluster.Status().State())
 	rosaScope.ControlPlane.Status.ID = cluster.ID()
+	rosaScope.ControlPlane.Status.ClusterProxy = rosaScope.ControlPlane.Spec.ClusterProxy.DeepCopy()
+	rosaScope.ControlPlane.Status.AdditionalTrustBundleHash = trustBundleHash(additionalTrustBundle)
 
 	return ctrl.Result{}, nil
 }
 
+// reconcileRosaRoleConfig returns the ROSARoleConfig referenced by the control plane. It returns nil when the
+// ROSARoleConfig doesn't exist or isn't ready yet.
+func (r *ROSAControlPlaneReconciler) reconcileRosaRoleConfig(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*rosacontrolplanev1.ROSARoleConfig, error) {
+	roleConfigName := rosaScope.ControlPlane.Spec.RosaRoleConfigRef.Name
+	roleConfig := &rosacontrolplanev1.ROSARoleConfig{}
+	key := types.NamespacedName{Namespace: rosaScope.ControlPlane.Namespace, Name: roleConfigName}
+	if err := r.Client.Get(ctx, key, roleConfig); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get ROSARoleConfig %q: %w", roleConfigName, err)
+		}
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneValidCondition,
+			rosacontrolplanev1.ROSARoleConfigNotFoundReason,
+			clusterv1.ConditionSeverityError,
+			"ROSARoleConfig %q not found", roleConfigName)
+		rosaScope.Info("ROSARoleConfig not found", "rosaRoleConfig", roleConfigName)
+		return nil, nil
+	}
+
+	if !roleConfig.Status.Ready {
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneValidCondition,
+			rosacontrolplanev1.ROSARoleConfigNotReadyReason,
+			clusterv1.ConditionSeverityInfo,
+			"ROSARoleConfig %q is not ready", roleConfigName)
+		rosaScope.Info("waiting for ROSARoleConfig to become ready", "rosaRoleConfig", roleConfigName)
+		return nil, nil
+	}
+
+	return roleConfig, nil
+}
+
+// controlPlaneSpecWithRoles returns the control plane spec with the roles and the OIDC config from the status of the
+// ROSARoleConfig, if any. The roles are never written back to the spec, which holds either rolesRef or rosaRoleConfigRef.
+func controlPlaneSpecWithRoles(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec, roleConfig *rosacontrolplanev1.ROSARoleConfig) rosacontrolplanev1.RosaControlPlaneSpec {
+	if roleConfig == nil {
+		return controlPlaneSpec
+	}
+
+	spec := *controlPlaneSpec.DeepCopy()
+	spec.InstallerRoleARN = roleConfig.Status.AccountRolesRef.InstallerRoleARN
+	spec.SupportRoleARN = roleConfig.Status.AccountRolesRef.SupportRoleARN
+	spec.WorkerRoleARN = roleConfig.Status.AccountRolesRef.WorkerRoleARN
+	spec.RolesRef = roleConfig.Status.OperatorRolesRef
+	spec.OIDCID = roleConfig.Status.OIDCID
+	return spec
+}
+
 func (r *ROSAControlPlaneReconciler) reconcileDelete(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (res ctrl.Result, reterr error) {
 	rosaScope.Info("Reconciling ROSAControlPlane delete")
 
@@ -404,10 +504,9 @@ func (r *ROSAControlPlaneReconciler) deleteMachinePools(ctx context.Context, ros
 }
 
 func (r *ROSAControlPlaneReconciler) reconcileClusterVersion(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
-	version := rosaScope.ControlPlane.Spec.Version
-	if version == rosa.RawVersionID(cluster.Version()) {
-		conditions.MarkFalse(rosaScope.ControlPlane, rosacontrolplanev1.ROSAControlPlaneUpgradingCondition, "upgraded", clusterv1.ConditionSeverityInfo, "")
-		return nil
+	upgradePolicy := rosaScope.ControlPlane.Spec.UpgradePolicy
+	if upgradePolicy != nil && upgradePolicy.Mode == rosacontrolplanev1.UpgradeModeAutomatic {
+		return r.reconcileAutomaticUpgrades(rosaScope, ocmClient, cluster)
 	}
 
 	scheduledUpgrade, err := rosa.CheckExistingScheduledUpgrade(ocmClient, cluster)
@@ -415,12 +514,51 @@ func (r *ROSAControlPlaneReconciler) reconcileClusterVersion(rosaScope *scope.RO
 		return fmt.Errorf("failed to get existing scheduled upgrades: %w", err)
 	}
 
+	version := rosaScope.ControlPlane.Spec.Version
+	upgraded := version == rosa.RawVersionID(cluster.Version())
+
+	if scheduledUpgrade != nil && scheduledUpgrade.ScheduleType() == cmv1.ScheduleTypeAutomatic {
+		switch {
+		case scheduledUpgrade.ID() == rosaScope.ControlPlane.Status.AutomaticUpgradePolicyID:
+			// automatic upgrades scheduled by the Automatic mode before switching to the Manual mode are cancelled.
+			if isUpgradeInProgress(scheduledUpgrade) {
+				rosaScope.ControlPlane.Status.ScheduledUpgrade = scheduledUpgradeStatus(scheduledUpgrade)
+				return fmt.Errorf("there is already a %s upgrade to version %s", scheduledUpgrade.State().Value(), scheduledUpgrade.Version())
+			}
+			if err := cancelScheduledUpgrade(ocmClient, cluster, scheduledUpgrade); err != nil {
+				return err
+			}
+			scheduledUpgrade = nil
+		case !upgraded:
+			// automatic upgrades scheduled outside of CAPA are left untouched.
+			rosaScope.ControlPlane.Status.ScheduledUpgrade = scheduledUpgradeStatus(scheduledUpgrade)
+			return fmt.Errorf("automatic upgrades at %q not scheduled by CAPA prevent upgrading to version %s", scheduledUpgrade.Schedule(), version)
+		}
+	}
+	rosaScope.ControlPlane.Status.AutomaticUpgradePolicyID = ""
+
+	if upgraded {
+		conditions.MarkFalse(rosaScope.ControlPlane, rosacontrolplanev1.ROSAControlPlaneUpgradingCondition, "upgraded", clusterv1.ConditionSeverityInfo, "")
+		rosaScope.ControlPlane.Status.ScheduledUpgrade = nil
+		return nil
+	}
+
 	if scheduledUpgrade == nil {
-		scheduledUpgrade, err = rosa.ScheduleControlPlaneUpgrade(ocmClient, cluster, version, time.Now())
+		nextRun := time.Now()
+		if upgradePolicy != nil && upgradePolicy.MaintenanceWindow != nil {
+			// OCM requires the next run to be at least 5 minutes ahead.
+			nextRun, err = rosa.NextMaintenanceWindowRun(upgradePolicy.MaintenanceWindow, time.Now().Add(time.Minute*7))
+			if err != nil {
+				return err
+			}
+		}
+
+		scheduledUpgrade, err = rosa.ScheduleControlPlaneUpgrade(ocmClient, cluster, version, nextRun)
 		if err != nil {
 			return fmt.Errorf("failed to schedule control plane upgrade to version %s: %w", version, err)
 		}
 	}
+	rosaScope.ControlPlane.Status.ScheduledUpgrade = scheduledUpgradeStatus(scheduledUpgrade)
 
 	condition := &clusterv1.Condition{
 		Type:    rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
@@ -438,17 +576,133 @@ func (r *ROSAControlPlaneReconciler) reconcileClusterVersion(rosaScope *scope.RO
 	return nil
 }
 
-func (r *ROSAControlPlaneReconciler) updateOCMCluster(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster, creator *rosaaws.Creator) error {
-	currentAuditLogRole := cluster.AWS().AuditLog().RoleArn()
-	if currentAuditLogRole == rosaScope.ControlPlane.Spec.AuditLogRoleARN {
+// reconcileAutomaticUpgrades ensures OCM upgrades the control plane automatically in the maintenance window.
+func (r *ROSAControlPlaneReconciler) reconcileAutomaticUpgrades(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
+	cronSchedule := rosa.MaintenanceWindowSchedule(rosaScope.ControlPlane.Spec.UpgradePolicy.MaintenanceWindow)
+
+	scheduledUpgrade, err := rosa.CheckExistingScheduledUpgrade(ocmClient, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get existing scheduled upgrades: %w", err)
+	}
+
+	// manual upgrades and automatic upgrades with another schedule are replaced.
+	if scheduledUpgrade != nil && (scheduledUpgrade.ScheduleType() != cmv1.ScheduleTypeAutomatic || scheduledUpgrade.Schedule() != cronSchedule) {
+		if isUpgradeInProgress(scheduledUpgrade) {
+			rosaScope.ControlPlane.Status.ScheduledUpgrade = scheduledUpgradeStatus(scheduledUpgrade)
+			return fmt.Errorf("there is already a %s upgrade to version %s", scheduledUpgrade.State().Value(), scheduledUpgrade.Version())
+		}
+		if err := cancelScheduledUpgrade(ocmClient, cluster, scheduledUpgrade); err != nil {
+			return err
+		}
+		scheduledUpgrade = nil
+	}
+
+	if scheduledUpgrade == nil {
+		scheduledUpgrade, err = rosa.ScheduleAutomaticControlPlaneUpgrade(ocmClient, cluster, cronSchedule)
+		if err != nil {
+			return fmt.Errorf("failed to schedule automatic control plane upgrades at %q: %w", cronSchedule, err)
+		}
+	}
+	rosaScope.ControlPlane.Status.AutomaticUpgradePolicyID = scheduledUpgrade.ID()
+	rosaScope.ControlPlane.Status.ScheduledUpgrade = scheduledUpgradeStatus(scheduledUpgrade)
+
+	if isUpgradeInProgress(scheduledUpgrade) {
+		conditions.Set(rosaScope.ControlPlane, &clusterv1.Condition{
+			Type:    rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  string(scheduledUpgrade.State().Value()),
+			Message: fmt.Sprintf("Upgrading to version %s", scheduledUpgrade.Version()),
+		})
 		return nil
 	}
 
-	ocmClusterSpec := ocm.Spec{
-		AuditLogRoleARN: ptr.To(rosaScope.ControlPlane.Spec.AuditLogRoleARN),
+	conditions.MarkFalse(rosaScope.ControlPlane,
+		rosacontrolplanev1.ROSAControlPlaneUpgradingCondition,
+		string(scheduledUpgrade.State().Value()),
+		clusterv1.ConditionSeverityInfo,
+		"Automatic upgrades are scheduled at %q", cronSchedule)
+
+	return nil
+}
+
+func cancelScheduledUpgrade(ocmClient *ocm.Client, cluster *cmv1.Cluster, scheduledUpgrade *cmv1.ControlPlaneUpgradePolicy) error {
+	if _, err := ocmClient.CancelControlPlaneUpgrade(cluster.ID(), scheduledUpgrade.ID()); err != nil {
+		return fmt.Errorf("failed to cancel scheduled upgrade %s: %w", scheduledUpgrade.ID(), err)
+	}
+
+	return nil
+}
+
+func isUpgradeInProgress(scheduledUpgrade *cmv1.ControlPlaneUpgradePolicy) bool {
+	state := scheduledUpgrade.State().Value()
+	return state == cmv1.UpgradePolicyStateValueStarted || state == cmv1.UpgradePolicyStateValueDelayed
+}
+
+func scheduledUpgradeStatus(scheduledUpgrade *cmv1.ControlPlaneUpgradePolicy) *rosacontrolplanev1.ScheduledUpgrade {
+	status := &rosacontrolplanev1.ScheduledUpgrade{
+		State:   string(scheduledUpgrade.State().Value()),
+		Version: scheduledUpgrade.Version(),
+	}
+	if nextRun, ok := scheduledUpgrade.GetNextRun(); ok {
+		status.NextRun = &metav1.Time{Time: nextRun}
+	}
+
+	return status
+}
+
+// channelGroup returns the channel group of the OpenShift versions of the cluster.
+func channelGroup(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec) string {
+	if controlPlaneSpec.UpgradePolicy != nil && controlPlaneSpec.UpgradePolicy.ChannelGroup != "" {
+		return controlPlaneSpec.UpgradePolicy.ChannelGroup
+	}
+
+	return ocm.DefaultChannelGroup
+}
+
+func (r *ROSAControlPlaneReconciler) updateOCMCluster(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster, creator *rosaaws.Creator) error {
+	ocmClusterSpec := ocm.Spec{}
+	needsUpdate := false
+
+	currentAuditLogRole := cluster.AWS().AuditLog().RoleArn()
+	if currentAuditLogRole != rosaScope.ControlPlane.Spec.AuditLogRoleARN {
+		ocmClusterSpec.AuditLogRoleARN = ptr.To(rosaScope.ControlPlane.Spec.AuditLogRoleARN)
+		needsUpdate = true
+	}
+
+	currentProxy := clusterProxyFromOCM(cluster)
+	desiredProxy := rosaScope.ControlPlane.Spec.ClusterProxy
+	if !equalClusterProxy(currentProxy, desiredProxy) {
+		// Empty values remove the proxy settings from the cluster.
+		if desiredProxy == nil {
+			desiredProxy = &rosacontrolplanev1.ClusterProxy{}
+		}
+		ocmClusterSpec.HTTPProxy = ptr.To(desiredProxy.HTTPProxy)
+		ocmClusterSpec.HTTPSProxy = ptr.To(desiredProxy.HTTPSProxy)
+		ocmClusterSpec.NoProxy = ptr.To(strings.Join(desiredProxy.NoProxy, ","))
+		needsUpdate = true
+	}
+
+	additionalTrustBundle, err := getAdditionalTrustBundle(ctx, rosaScope)
+	if err != nil {
+		conditions.MarkFalse(rosaScope.ControlPlane,
+			rosacontrolplanev1.ROSAControlPlaneValidCondition,
+			rosacontrolplanev1.ROSAControlPlaneInvalidConfigurationReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return err
+	}
+	additionalTrustBundleHash := trustBundleHash(additionalTrustBundle)
+	if additionalTrustBundleHash != rosaScope.ControlPlane.Status.AdditionalTrustBundleHash {
+		ocmClusterSpec.AdditionalTrustBundle = ptr.To(additionalTrustBundle)
+		needsUpdate = true
+	}
+
+	if !needsUpdate {
+		rosaScope.ControlPlane.Status.ClusterProxy = currentProxy
+		return nil
 	}
 
-	// if this fails, the provided role is likely invalid or it doesn't have the required permissions.
+	// if this fails, the provided role, proxy or trust bundle are likely invalid.
 	if err := ocmClient.UpdateCluster(cluster.ID(), creator, ocmClusterSpec); err != nil {
 		conditions.MarkFalse(rosaScope.ControlPlane,
 			rosacontrolplanev1.ROSAControlPlaneValidCondition,
@@ -458,9 +712,75 @@ func (r *ROSAControlPlaneReconciler) updateOCMCluster(rosaScope *scope.ROSAContr
 		return err
 	}
 
+	rosaScope.ControlPlane.Status.ClusterProxy = rosaScope.ControlPlane.Spec.ClusterProxy.DeepCopy()
+	rosaScope.ControlPlane.Status.AdditionalTrustBundleHash = additionalTrustBundleHash
+
 	return nil
 }
 
+// getAdditionalTrustBundle returns the additional trust bundle of the cluster from the
+// referenced ConfigMap, or an empty string if no trust bundle is referenced.
+func getAdditionalTrustBundle(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (string, error) {
+	ref := rosaScope.ControlPlane.Spec.AdditionalTrustBundle
+	if ref == nil {
+		return "", nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := rosaScope.Client.Get(ctx, types.NamespacedName{Namespace: rosaScope.Namespace(), Name: ref.Name}, configMap); err != nil {
+		return "", fmt.Errorf("failed to get additional trust bundle configMap %s: %w", ref.Name, err)
+	}
+
+	bundle, ok := configMap.Data[caBundleKey]
+	if !ok || bundle == "" {
+		return "", fmt.Errorf("additional trust bundle configMap %s has no %s key", ref.Name, caBundleKey)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(bundle)) {
+		return "", fmt.Errorf("additional trust bundle configMap %s contains no valid PEM-encoded certificates", ref.Name)
+	}
+
+	return bundle, nil
+}
+
+func trustBundleHash(bundle string) string {
+	if bundle == "" {
+		return ""
+	}
+
+	hash := sha256.Sum256([]byte(bundle))
+	return hex.EncodeToString(hash[:])
+}
+
+// clusterProxyFromOCM returns the cluster-wide proxy of the cluster, or nil if the cluster
+// has no proxy.
+func clusterProxyFromOCM(cluster *cmv1.Cluster) *rosacontrolplanev1.ClusterProxy {
+	proxy := cluster.Proxy()
+	if proxy.HTTPProxy() == "" && proxy.HTTPSProxy() == "" && proxy.NoProxy() == "" {
+		return nil
+	}
+
+	clusterProxy := &rosacontrolplanev1.ClusterProxy{
+		HTTPProxy:  proxy.HTTPProxy(),
+		HTTPSProxy: proxy.HTTPSProxy(),
+	}
+	if proxy.NoProxy() != "" {
+		clusterProxy.NoProxy = strings.Split(proxy.NoProxy(), ",")
+	}
+
+	return clusterProxy
+}
+
+func equalClusterProxy(a, b *rosacontrolplanev1.ClusterProxy) bool {
+	if a == nil {
+		a = &rosacontrolplanev1.ClusterProxy{}
+	}
+	if b == nil {
+		b = &rosacontrolplanev1.ClusterProxy{}
+	}
+
+	return a.HTTPProxy == b.HTTPProxy && a.HTTPSProxy == b.HTTPSProxy && strings.Join(a.NoProxy, ",") == strings.Join(b.NoProxy, ",")
+}
+
 func (r *ROSAControlPlaneReconciler) reconcileExternalAuth(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, cluster *cmv1.Cluster) error {
 	externalAuthClient, err := rosa.NewExternalAuthClient(ctx, rosaScope)
 	if err != nil {
@@ -547,7 +867,7 @@ func (r *ROSAControlPlaneReconciler) reconcileExternalAuthProviders(ctx context.
 		if err != nil {
 			return fmt.Errorf("failed to get issuer CertificateAuthority configMap %s: %v", authProvider.Issuer.CertificateAuthority.Name, err)
 		}
-		CertificateAuthorityValue := CertificateAuthorityConfigMap.Data["ca-bundle.crt"]
+		CertificateAuthorityValue := CertificateAuthorityConfigMap.Data[caBundleKey]
 
 		tokenIssuerBuilder.CA(CertificateAuthorityValue)
 	}
@@ -631,6 +951,11 @@ func (r *ROSAControlPlaneReconciler) reconcileExternalAuthProviders(ctx context.
 	return nil
 }
 
+// clusterAdminUserName returns the name of the cluster admin user used by the kubeconfig generated by CAPA.
+func clusterAdminUserName(rosaScope *scope.ROSAControlPlaneScope) string {
+	return fmt.Sprintf("%s-capi-admin", rosaScope.RosaClusterName())
+}
+
 // Generates a temporarily admin kubeconfig using break-glass credentials for the user to bootstreap their environment like setting up RBAC for oidc users/groups.
 // This Kubeonconfig will be created only once initially and be valid for only 24h.
 // The kubeconfig secret will not be autoamticallty rotated and will be invalid after the 24h. However, users can opt to manually delete the secret to trigger the generation of a new one which will be valid for another 24h.
@@ -691,7 +1016,7 @@ func (r *ROSAControlPlaneReconciler) reconcileKubeconfig(ctx context.Context, ro
 	}
 
 	clusterName := rosaScope.RosaClusterName()
-	userName := fmt.Sprintf("%s-capi-admin", clusterName)
+	userName := clusterAdminUserName(rosaScope)
 	apiServerURL := cluster.API().URL()
 
 	// create new user with admin privileges in the ROSA cluster if 'userName' doesn't already exist.
@@ -785,9 +1110,9 @@ func (r *ROSAControlPlaneReconciler) reconcileClusterAdminPassword(ctx context.C
 	return password, nil
 }
 
-func validateControlPlaneSpec(ocmClient *ocm.Client, rosaScope *scope.ROSAControlPlaneScope) (string, error) {
+func validateControlPlaneSpec(ocmClient *ocm.Client, rosaScope *scope.ROSAControlPlaneScope, creator *rosaaws.Creator) (string, error) {
 	version := rosaScope.ControlPlane.Spec.Version
-	valid, err := ocmClient.ValidateHypershiftVersion(version, ocm.DefaultChannelGroup)
+	valid, err := ocmClient.ValidateHypershiftVersion(version, channelGroup(rosaScope.ControlPlane.Spec))
 	if err != nil {
 		return "", fmt.Errorf("failed to check if version is valid: %w", err)
 	}
@@ -795,11 +1120,70 @@ func validateControlPlaneSpec(ocmClient *ocm.Client, rosaScope *scope.ROSAContro
 		return fmt.Sprintf("version %s is not supported", version), nil
 	}
 
+	if sharedVPC := rosaScope.ControlPlane.Spec.SharedVPC; sharedVPC != nil {
+		if message := validateSharedVPC(sharedVPC, creator.AccountID); message != "" {
+			return message, nil
+		}
+	}
+
 	// TODO: add more input validations
 	return "", nil
 }
 
-func buildOCMClusterSpec(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec, creator *rosaaws.Creator) (ocm.Spec, error) {
+// validateSharedVPC validates what the CRD can't: the roles must be in the account of the VPC owner.
+func validateSharedVPC(sharedVPC *rosacontrolplanev1.SharedVPC, clusterAccountID string) string {
+	roles := []struct {
+		field string
+		arn   string
+	}{
+		{field: "routeRoleARN", arn: sharedVPC.RouteRoleARN},
+		{field: "vpcEndpointRoleARN", arn: sharedVPC.VPCEndpointRoleARN},
+	}
+	for _, role := range roles {
+		roleARN, err := arn.Parse(role.arn)
+		if err != nil {
+			return fmt.Sprintf("sharedVPC.%s %q is not a valid ARN: %v", role.field, role.arn, err)
+		}
+		if roleARN.AccountID == clusterAccountID {
+			return fmt.Sprintf("sharedVPC.%s %q must be in the account of the VPC owner, not in the account %s of the cluster",
+				role.field, role.arn, clusterAccountID)
+		}
+	}
+
+	if errs := validation.IsDNS1123Subdomain(sharedVPC.BaseDomain); len(errs) > 0 {
+		return fmt.Sprintf("sharedVPC.baseDomain %q is not a valid domain: %s", sharedVPC.BaseDomain, strings.Join(errs, ", "))
+	}
+
+	return ""
+}
+
+// createOCMCluster creates the cluster with the OCM client, unless it has AWS settings which the OCM client doesn't support.
+func createOCMCluster(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, ocmClusterSpec ocm.Spec,
+	awsSettings rosa.AWSClusterSettings) (*cmv1.Cluster, error) {
+	if awsSettings.IsEmpty() {
+		return ocmClient.CreateCluster(ocmClusterSpec)
+	}
+
+	clusterClient, err := rosa.NewClusterClient(ctx, rosaScope, awsSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cluster client: %w", err)
+	}
+	defer clusterClient.Close()
+
+	return clusterClient.CreateCluster(ocmClusterSpec)
+}
+
+// awsClusterSettings returns the AWS settings of the cluster which aren't supported by buildOCMClusterSpec.
+func awsClusterSettings(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec) rosa.AWSClusterSettings {
+	settings := rosa.AWSClusterSettings{}
+	if sharedVPC := controlPlaneSpec.SharedVPC; sharedVPC != nil {
+		settings.VPCEndpointRoleARN = sharedVPC.VPCEndpointRoleARN
+		settings.HCPInternalCommunicationHostedZoneID = sharedVPC.InternalCommunicationPrivateHostedZoneID
+	}
+	return settings
+}
+
+func buildOCMClusterSpec(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpec, creator *rosaaws.Creator, additionalTrustBundle string) (ocm.Spec, error) {
 	billingAccount := controlPlaneSpec.BillingAccount
 	if billingAccount == "" {
 		billingAccount = creator.AccountID
@@ -811,8 +1195,8 @@ func buildOCMClusterSpec(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpe
 		DomainPrefix:              controlPlaneSpec.DomainPrefix,
 		Region:                    controlPlaneSpec.Region,
 		MultiAZ:                   true,
-		Version:                   ocm.CreateVersionID(controlPlaneSpec.Version, ocm.DefaultChannelGroup),
-		ChannelGroup:              ocm.DefaultChannelGroup,
+		Version:                   ocm.CreateVersionID(controlPlaneSpec.Version, channelGroup(controlPlaneSpec)),
+		ChannelGroup:              channelGroup(controlPlaneSpec),
 		DisableWorkloadMonitoring: ptr.To(true),
 		DefaultIngress:            ocm.NewDefaultIngressSpec(), // n.b. this is a no-op when it's set to the default value
 		ComputeMachineType:        controlPlaneSpec.DefaultMachinePoolSpec.InstanceType,
@@ -872,6 +1256,29 @@ func buildOCMClusterSpec(controlPlaneSpec rosacontrolplanev1.RosaControlPlaneSpe
 		ocmClusterSpec.NetworkType = networkSpec.NetworkType
 	}
 
+	if sharedVPC := controlPlaneSpec.SharedVPC; sharedVPC != nil {
+		ocmClusterSpec.SharedVPCRoleArn = sharedVPC.RouteRoleARN
+		ocmClusterSpec.PrivateHostedZoneID = sharedVPC.IngressPrivateHostedZoneID
+		ocmClusterSpec.BaseDomain = sharedVPC.BaseDomain
+	}
+
+	if proxy := controlPlaneSpec.ClusterProxy; proxy != nil {
+		ocmClusterSpec.EnableProxy = true
+		if proxy.HTTPProxy != "" {
+			ocmClusterSpec.HTTPProxy = ptr.To(proxy.HTTPProxy)
+		}
+		if proxy.HTTPSProxy != "" {
+			ocmClusterSpec.HTTPSProxy = ptr.To(proxy.HTTPSProxy)
+		}
+		if len(proxy.NoProxy) > 0 {
+			ocmClusterSpec.NoProxy = ptr.To(strings.Join(proxy.NoProxy, ","))
+		}
+	}
+
+	if additionalTrustBundle != "" {
+		ocmClusterSpec.AdditionalTrustBundle = ptr.To(additionalTrustBundle)
+	}
+
 	// Set cluster compute autoscaling replicas
 	// In case autoscaling is not defined and multiple zones defined, set the compute nodes equal to the zones count.
 	if computeAutoscaling := controlPlaneSpec.DefaultMachinePoolSpec.Autoscaling; computeAutoscaling != nil {
@@ -0,0 +1,307 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/helpers/fakeocm"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestROSAControlPlaneReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	// the AWS sessions of the default controller identity use the credentials from the environment.
+	t.Setenv("AWS_ACCESS_KEY_ID", "fake")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "fake")
+
+	ocmServer := fakeocm.NewServer()
+	defer ocmServer.Close()
+	for _, rawID := range []string{"4.14.5", "4.14.6"} {
+		version, err := cmv1.NewVersion().ID("openshift-v" + rawID).RawID(rawID).ChannelGroup("stable").
+			HostedControlPlaneEnabled(true).Build()
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = ocmServer.AddVersion(version)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	controllerIdentity := createControllerIdentity(g)
+	ns, err := testEnv.CreateNamespace(ctx, fmt.Sprintf("rosa-%s", util.RandomString(5)))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	credentialsSecret := ocmServer.CredentialsSecret("ocm-credentials", ns.Name)
+	g.Expect(testEnv.Create(ctx, credentialsSecret)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capa-rosa",
+			Namespace: ns.Name,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: rosacontrolplanev1.GroupVersion.String(),
+				Kind:       rosaControlPlaneKind,
+				Name:       "capa-rosa-control-plane",
+			},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, cluster)).To(Succeed())
+
+	rosaControlPlane := &rosacontrolplanev1.ROSAControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capa-rosa-control-plane",
+			Namespace: ns.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "Cluster",
+					Name:       cluster.Name,
+					UID:        cluster.UID,
+				},
+			},
+		},
+		Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+			RosaClusterName:   "capa-rosa",
+			Subnets:           []string{"subnet-0ac99a6230b408813", "subnet-1ac99a6230b408811"},
+			AvailabilityZones: []string{"us-east-1a"},
+			Region:            fakeocm.DefaultRegion,
+			Version:           "4.14.5",
+			InstallerRoleARN:  "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Installer-Role",
+			SupportRoleARN:    "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Support-Role",
+			WorkerRoleARN:     "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Worker-Role",
+			OIDCID:            "2a3b4c5d6e7f8g9h0i1j2k3l4m5n6o7p",
+			RolesRef:          rosacontrolplanev1.AWSRolesRef{IngressARN: "arn:aws:iam::123456789012:role/capa-openshift-ingress-operator-cloud-credentials"},
+			// the kubeconfig of clusters without external auth providers is requested from the cluster OAuth
+			// server, which the fake OCM server doesn't provide.
+			EnableExternalAuthProviders: true,
+			ExternalAuthProviders: []rosacontrolplanev1.ExternalAuthProvider{
+				{
+					Name: "capa-oidc",
+					Issuer: rosacontrolplanev1.TokenIssuer{
+						URL:       "https://oidc.example.com",
+						Audiences: []rosacontrolplanev1.TokenAudience{"capa"},
+					},
+				},
+			},
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecret.Name},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, rosaControlPlane)).To(Succeed())
+
+	defer func() {
+		g.Expect(testEnv.Cleanup(ctx, rosaControlPlane, cluster, credentialsSecret, controllerIdentity, ns)).To(Succeed())
+	}()
+
+	r := &ROSAControlPlaneReconciler{
+		Client:    testEnv,
+		Endpoints: ocmServer.ServiceEndpoints(),
+	}
+	key := client.ObjectKeyFromObject(rosaControlPlane)
+	reconcile := func() (ctrl.Result, error) {
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	}
+	// the reconciler reads the control plane from the cache of the manager, so the changes are awaited before
+	// reconciling again.
+	expectControlPlane := func(assertions func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane)) {
+		g.Eventually(func(g Gomega) {
+			controlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+			g.Expect(testEnv.Get(ctx, key, controlPlane)).To(Succeed())
+			assertions(g, controlPlane)
+		}, 10*time.Second).Should(Succeed())
+	}
+	updateControlPlane := func(update func(controlPlane *rosacontrolplanev1.ROSAControlPlane)) {
+		g.Eventually(func(g Gomega) {
+			controlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+			g.Expect(testEnv.Get(ctx, key, controlPlane)).To(Succeed())
+			update(controlPlane)
+			g.Expect(testEnv.Update(ctx, controlPlane)).To(Succeed())
+		}, 10*time.Second).Should(Succeed())
+	}
+
+	// the cluster is created.
+	expectControlPlane(func(Gomega, *rosacontrolplanev1.ROSAControlPlane) {})
+	_, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var clusterID string
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Finalizers).To(ContainElement(ROSAControlPlaneFinalizer))
+		g.Expect(controlPlane.Status.ID).ToNot(BeEmpty())
+		clusterID = controlPlane.Status.ID
+	})
+	ocmCluster, found, err := ocmServer.GetCluster(clusterID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(ocmCluster.Name()).To(Equal("capa-rosa"))
+	g.Expect(ocmCluster.Version().ID()).To(Equal("openshift-v4.14.5"))
+	g.Expect(ocmCluster.Status().State()).To(Equal(cmv1.ClusterStateInstalling))
+
+	// the control plane isn't ready until the cluster is installed.
+	result, err := reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Second * 60))
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Status.Ready).To(BeFalse())
+		g.Expect(conditions.IsFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition)).To(Equal(string(cmv1.ClusterStateInstalling)))
+	})
+
+	installedCluster, err := cmv1.NewCluster().
+		Status(cmv1.NewClusterStatus().State(cmv1.ClusterStateReady)).
+		API(cmv1.NewClusterAPI().URL("https://api.capa-rosa.example.com:443")).
+		Console(cmv1.NewClusterConsole().URL("https://console.capa-rosa.example.com")).
+		Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5")).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = ocmServer.UpdateCluster(clusterID, installedCluster)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Status.Ready).To(BeTrue())
+		g.Expect(controlPlane.Status.ConsoleURL).To(Equal("https://console.capa-rosa.example.com"))
+		g.Expect(controlPlane.Status.ScheduledUpgrade).To(BeNil())
+		g.Expect(controlPlane.Spec.ControlPlaneEndpoint).To(Equal(clusterv1.APIEndpoint{Host: "api.capa-rosa.example.com", Port: 443}))
+		g.Expect(conditions.IsTrue(controlPlane, rosacontrolplanev1.ROSAControlPlaneReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(controlPlane, rosacontrolplanev1.ExternalAuthConfiguredCondition)).To(BeTrue())
+	})
+
+	kubeconfigSecret := &corev1.Secret{}
+	g.Eventually(func() error {
+		return testEnv.Get(ctx, client.ObjectKey{Namespace: ns.Name, Name: cluster.Name + "-bootstrap-kubeconfig"}, kubeconfigSecret)
+	}, 10*time.Second).Should(Succeed())
+	g.Expect(kubeconfigSecret.Data["value"]).To(ContainSubstring("https://api.example.com:443"))
+
+	externalAuths, err := ocmServer.ExternalAuths(clusterID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(externalAuths).To(HaveLen(1))
+	g.Expect(externalAuths[0].ID()).To(Equal("capa-oidc"))
+	g.Expect(externalAuths[0].Issuer().URL()).To(Equal("https://oidc.example.com"))
+
+	upgradePolicies, err := ocmServer.ControlPlaneUpgradePolicies(clusterID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(upgradePolicies).To(BeEmpty())
+
+	// changing the version schedules an upgrade.
+	updateControlPlane(func(controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		controlPlane.Spec.Version = "4.14.6"
+	})
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Spec.Version).To(Equal("4.14.6"))
+	})
+	for i := 0; i < 2; i++ {
+		result, err = reconcile()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(time.Minute * 5))
+
+		// scheduled upgrades aren't scheduled again.
+		upgradePolicies, err = ocmServer.ControlPlaneUpgradePolicies(clusterID)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(upgradePolicies).To(HaveLen(1))
+		g.Expect(upgradePolicies[0].Version()).To(Equal("4.14.6"))
+		g.Expect(upgradePolicies[0].ScheduleType()).To(Equal(cmv1.ScheduleTypeManual))
+		g.Expect(upgradePolicies[0].NextRun()).To(BeTemporally(">", time.Now().Add(time.Minute*5)))
+	}
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Status.ScheduledUpgrade).ToNot(BeNil())
+		g.Expect(controlPlane.Status.ScheduledUpgrade.Version).To(Equal("4.14.6"))
+		g.Expect(controlPlane.Status.ScheduledUpgrade.State).To(Equal(string(cmv1.UpgradePolicyStateValueScheduled)))
+		g.Expect(conditions.IsTrue(controlPlane, rosacontrolplanev1.ROSAControlPlaneUpgradingCondition)).To(BeTrue())
+	})
+
+	// the manual upgrade is replaced by automatic upgrades in the maintenance window.
+	updateControlPlane(func(controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		controlPlane.Spec.UpgradePolicy = &rosacontrolplanev1.UpgradePolicy{
+			Mode: rosacontrolplanev1.UpgradeModeAutomatic,
+			MaintenanceWindow: &rosacontrolplanev1.MaintenanceWindow{
+				Schedule: "0 2 * * 6",
+			},
+		}
+	})
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.Spec.UpgradePolicy).ToNot(BeNil())
+	})
+	_, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	upgradePolicies, err = ocmServer.ControlPlaneUpgradePolicies(clusterID)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(upgradePolicies).To(HaveLen(1))
+	g.Expect(upgradePolicies[0].ScheduleType()).To(Equal(cmv1.ScheduleTypeAutomatic))
+	g.Expect(upgradePolicies[0].Schedule()).To(Equal("0 2 * * 6"))
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(conditions.IsFalse(controlPlane, rosacontrolplanev1.ROSAControlPlaneUpgradingCondition)).To(BeTrue())
+	})
+
+	// the finalizer is removed once the cluster is uninstalled.
+	g.Expect(testEnv.Delete(ctx, rosaControlPlane)).To(Succeed())
+	expectControlPlane(func(g Gomega, controlPlane *rosacontrolplanev1.ROSAControlPlane) {
+		g.Expect(controlPlane.DeletionTimestamp.IsZero()).To(BeFalse())
+	})
+	for i := 0; i < 2; i++ {
+		result, err = reconcile()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(time.Second * 60))
+
+		ocmCluster, found, err = ocmServer.GetCluster(clusterID)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(ocmCluster.Status().State()).To(Equal(cmv1.ClusterStateUninstalling))
+	}
+
+	g.Expect(ocmServer.RemoveCluster(clusterID)).To(Succeed())
+	_, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Eventually(func() bool {
+		return apierrors.IsNotFound(testEnv.Get(ctx, key, &rosacontrolplanev1.ROSAControlPlane{}))
+	}, 10*time.Second).Should(BeTrue())
+}
+
+func createControllerIdentity(g *WithT) *infrav1.AWSClusterControllerIdentity {
+	controllerIdentity := &infrav1.AWSClusterControllerIdentity{
+		TypeMeta: metav1.TypeMeta{
+			Kind: string(infrav1.ControllerIdentityKind),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+		Spec: infrav1.AWSClusterControllerIdentitySpec{
+			AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+				AllowedNamespaces: &infrav1.AllowedNamespaces{},
+			},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, controllerIdentity)).To(Succeed())
+	return controllerIdentity
+}
@@ -0,0 +1,624 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rosaaws "github.com/openshift/rosa/pkg/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/helpers/fakeocm"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestBuildOCMClusterSpecClusterProxy(t *testing.T) {
+	tests := []struct {
+		name                  string
+		proxy                 *rosacontrolplanev1.ClusterProxy
+		additionalTrustBundle string
+		expectEnableProxy     bool
+		expectHTTPProxy       *string
+		expectHTTPSProxy      *string
+		expectNoProxy         *string
+		expectTrustBundle     *string
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:              "http proxy",
+			proxy:             &rosacontrolplanev1.ClusterProxy{HTTPProxy: "http://proxy.example.com:3128"},
+			expectEnableProxy: true,
+			expectHTTPProxy:   ptr.To("http://proxy.example.com:3128"),
+		},
+		{
+			name: "https proxy with no proxy",
+			proxy: &rosacontrolplanev1.ClusterProxy{
+				HTTPSProxy: "https://proxy.example.com:3129",
+				NoProxy:    []string{"example.com", "10.0.0.0/16"},
+			},
+			expectEnableProxy: true,
+			expectHTTPSProxy:  ptr.To("https://proxy.example.com:3129"),
+			expectNoProxy:     ptr.To("example.com,10.0.0.0/16"),
+		},
+		{
+			name:                  "trust bundle without proxy",
+			additionalTrustBundle: "bundle",
+			expectTrustBundle:     ptr.To("bundle"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			spec, err := buildOCMClusterSpec(rosacontrolplanev1.RosaControlPlaneSpec{
+				RosaClusterName: "capa-rosa",
+				Version:         "4.14.5",
+				ClusterProxy:    tt.proxy,
+			}, &rosaaws.Creator{AccountID: "123456789012"}, tt.additionalTrustBundle)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec.EnableProxy).To(Equal(tt.expectEnableProxy))
+			g.Expect(spec.HTTPProxy).To(Equal(tt.expectHTTPProxy))
+			g.Expect(spec.HTTPSProxy).To(Equal(tt.expectHTTPSProxy))
+			g.Expect(spec.NoProxy).To(Equal(tt.expectNoProxy))
+			g.Expect(spec.AdditionalTrustBundle).To(Equal(tt.expectTrustBundle))
+		})
+	}
+}
+
+func TestGetAdditionalTrustBundle(t *testing.T) {
+	certificate, _, err := certutil.GenerateSelfSignedCertKey("proxy.example.com", nil, nil)
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name         string
+		ref          *corev1.LocalObjectReference
+		data         map[string]string
+		expectBundle string
+		expectErr    bool
+	}{
+		{
+			name: "no trust bundle",
+		},
+		{
+			name:      "missing configmap",
+			ref:       &corev1.LocalObjectReference{Name: "missing"},
+			expectErr: true,
+		},
+		{
+			name:      "missing key",
+			ref:       &corev1.LocalObjectReference{Name: "trust-bundle"},
+			data:      map[string]string{"ca.crt": string(certificate)},
+			expectErr: true,
+		},
+		{
+			name:      "invalid certificate",
+			ref:       &corev1.LocalObjectReference{Name: "trust-bundle"},
+			data:      map[string]string{caBundleKey: "not a certificate"},
+			expectErr: true,
+		},
+		{
+			name:         "valid certificate",
+			ref:          &corev1.LocalObjectReference{Name: "trust-bundle"},
+			data:         map[string]string{caBundleKey: string(certificate)},
+			expectBundle: string(certificate),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "trust-bundle", Namespace: "default"},
+				Data:       tt.data,
+			}
+			rosaScope := &scope.ROSAControlPlaneScope{
+				Client:  fake.NewClientBuilder().WithObjects(configMap).Build(),
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}},
+				ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+					Spec: rosacontrolplanev1.RosaControlPlaneSpec{AdditionalTrustBundle: tt.ref},
+				},
+			}
+
+			bundle, err := getAdditionalTrustBundle(ctx, rosaScope)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(bundle).To(Equal(tt.expectBundle))
+		})
+	}
+}
+
+func TestClusterProxyFromOCM(t *testing.T) {
+	tests := []struct {
+		name     string
+		proxy    *cmv1.ProxyBuilder
+		expected *rosacontrolplanev1.ClusterProxy
+	}{
+		{
+			name: "no proxy",
+		},
+		{
+			name:  "empty proxy",
+			proxy: cmv1.NewProxy().HTTPProxy("").HTTPSProxy("").NoProxy(""),
+		},
+		{
+			name:     "http proxy",
+			proxy:    cmv1.NewProxy().HTTPProxy("http://proxy.example.com:3128"),
+			expected: &rosacontrolplanev1.ClusterProxy{HTTPProxy: "http://proxy.example.com:3128"},
+		},
+		{
+			name:  "all settings",
+			proxy: cmv1.NewProxy().HTTPProxy("http://proxy.example.com:3128").HTTPSProxy("https://proxy.example.com:3129").NoProxy("example.com,10.0.0.0/16"),
+			expected: &rosacontrolplanev1.ClusterProxy{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "https://proxy.example.com:3129",
+				NoProxy:    []string{"example.com", "10.0.0.0/16"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			builder := cmv1.NewCluster()
+			if tt.proxy != nil {
+				builder = builder.Proxy(tt.proxy)
+			}
+			cluster, err := builder.Build()
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(clusterProxyFromOCM(cluster)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestEqualClusterProxy(t *testing.T) {
+	proxy := &rosacontrolplanev1.ClusterProxy{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "https://proxy.example.com:3129",
+		NoProxy:    []string{"example.com", "10.0.0.0/16"},
+	}
+
+	tests := []struct {
+		name     string
+		a        *rosacontrolplanev1.ClusterProxy
+		b        *rosacontrolplanev1.ClusterProxy
+		expected bool
+	}{
+		{
+			name:     "both nil",
+			expected: true,
+		},
+		{
+			name:     "nil and empty",
+			b:        &rosacontrolplanev1.ClusterProxy{},
+			expected: true,
+		},
+		{
+			name:     "nil and set",
+			b:        proxy,
+			expected: false,
+		},
+		{
+			name:     "equal",
+			a:        proxy,
+			b:        proxy.DeepCopy(),
+			expected: true,
+		},
+		{
+			name:     "different http proxy",
+			a:        proxy,
+			b:        &rosacontrolplanev1.ClusterProxy{HTTPProxy: "http://other.example.com:3128", HTTPSProxy: proxy.HTTPSProxy, NoProxy: proxy.NoProxy},
+			expected: false,
+		},
+		{
+			name:     "different https proxy",
+			a:        proxy,
+			b:        &rosacontrolplanev1.ClusterProxy{HTTPProxy: proxy.HTTPProxy, HTTPSProxy: "https://other.example.com:3129", NoProxy: proxy.NoProxy},
+			expected: false,
+		},
+		{
+			name:     "different no proxy",
+			a:        proxy,
+			b:        &rosacontrolplanev1.ClusterProxy{HTTPProxy: proxy.HTTPProxy, HTTPSProxy: proxy.HTTPSProxy, NoProxy: []string{"example.com"}},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(equalClusterProxy(tt.a, tt.b)).To(Equal(tt.expected))
+			g.Expect(equalClusterProxy(tt.b, tt.a)).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestUpdateOCMClusterProxy(t *testing.T) {
+	g := NewWithT(t)
+
+	ocmServer := fakeocm.NewServer()
+	defer ocmServer.Close()
+
+	certificate, _, err := certutil.GenerateSelfSignedCertKey("proxy.example.com", nil, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	credentialsSecret := ocmServer.CredentialsSecret("ocm-credentials", "default")
+	trustBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "trust-bundle", Namespace: "default"},
+		Data:       map[string]string{caBundleKey: string(certificate)},
+	}
+	rosaScope := &scope.ROSAControlPlaneScope{
+		Client:  fake.NewClientBuilder().WithObjects(credentialsSecret, trustBundle).Build(),
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}},
+		ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa-control-plane", Namespace: "default"},
+			Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+				ClusterProxy: &rosacontrolplanev1.ClusterProxy{
+					HTTPProxy: "http://proxy.example.com:3128",
+					NoProxy:   []string{"example.com", "10.0.0.0/16"},
+				},
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecret.Name},
+			},
+		},
+	}
+	ocmClient, err := rosa.NewOCMClient(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer ocmClient.Close()
+
+	ocmCluster, err := cmv1.NewCluster().Name("capa-rosa").Product(cmv1.NewProduct().ID("rosa")).
+		Proxy(cmv1.NewProxy().HTTPProxy("http://proxy.example.com:3128").NoProxy("example.com,10.0.0.0/16")).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	ocmCluster, err = ocmServer.AddCluster(ocmCluster)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &ROSAControlPlaneReconciler{}
+	updateOCMCluster := func() *cmv1.Cluster {
+		current, found, err := ocmServer.GetCluster(ocmCluster.ID())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(r.updateOCMCluster(ctx, rosaScope, ocmClient, current, nil)).To(Succeed())
+
+		updated, _, err := ocmServer.GetCluster(ocmCluster.ID())
+		g.Expect(err).ToNot(HaveOccurred())
+		return updated
+	}
+
+	// the proxy of the cluster matches the spec.
+	updated := updateOCMCluster()
+	g.Expect(updated.Proxy().HTTPProxy()).To(Equal("http://proxy.example.com:3128"))
+	g.Expect(rosaScope.ControlPlane.Status.ClusterProxy).To(Equal(rosaScope.ControlPlane.Spec.ClusterProxy))
+
+	// the proxy is changed.
+	rosaScope.ControlPlane.Spec.ClusterProxy.HTTPSProxy = "https://proxy.example.com:3129"
+	updated = updateOCMCluster()
+	g.Expect(updated.Proxy().HTTPSProxy()).To(Equal("https://proxy.example.com:3129"))
+	g.Expect(updated.Proxy().NoProxy()).To(Equal("example.com,10.0.0.0/16"))
+	g.Expect(rosaScope.ControlPlane.Status.ClusterProxy.HTTPSProxy).To(Equal("https://proxy.example.com:3129"))
+
+	// the proxy is changed outside of CAPA and reverted.
+	_, err = ocmServer.UpdateCluster(ocmCluster.ID(), mustBuildCluster(g, cmv1.NewCluster().Proxy(cmv1.NewProxy().HTTPProxy("http://other.example.com:3128"))))
+	g.Expect(err).ToNot(HaveOccurred())
+	updated = updateOCMCluster()
+	g.Expect(updated.Proxy().HTTPProxy()).To(Equal("http://proxy.example.com:3128"))
+
+	// the trust bundle is added.
+	rosaScope.ControlPlane.Spec.AdditionalTrustBundle = &corev1.LocalObjectReference{Name: trustBundle.Name}
+	updated = updateOCMCluster()
+	g.Expect(updated.AdditionalTrustBundle()).To(Equal(string(certificate)))
+	g.Expect(rosaScope.ControlPlane.Status.AdditionalTrustBundleHash).To(Equal(trustBundleHash(string(certificate))))
+
+	// the proxy is removed.
+	rosaScope.ControlPlane.Spec.ClusterProxy = nil
+	updated = updateOCMCluster()
+	g.Expect(clusterProxyFromOCM(updated)).To(BeNil())
+	g.Expect(rosaScope.ControlPlane.Status.ClusterProxy).To(BeNil())
+}
+
+func mustBuildCluster(g *WithT, builder *cmv1.ClusterBuilder) *cmv1.Cluster {
+	cluster, err := builder.Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	return cluster
+}
+
+func TestReconcileClusterVersionManualMode(t *testing.T) {
+	tests := []struct {
+		name              string
+		version           string
+		capaPolicy        bool
+		expectErr         bool
+		expectCancelled   bool
+		expectScheduledTo string
+	}{
+		{
+			name:            "automatic upgrades scheduled by CAPA are cancelled",
+			version:         "4.14.5",
+			capaPolicy:      true,
+			expectCancelled: true,
+		},
+		{
+			name:              "automatic upgrades scheduled by CAPA are replaced by a manual upgrade",
+			version:           "4.14.6",
+			capaPolicy:        true,
+			expectCancelled:   true,
+			expectScheduledTo: "4.14.6",
+		},
+		{
+			name:    "automatic upgrades scheduled outside of CAPA are kept",
+			version: "4.14.5",
+		},
+		{
+			name:      "automatic upgrades scheduled outside of CAPA block manual upgrades",
+			version:   "4.14.6",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ocmServer := fakeocm.NewServer()
+			defer ocmServer.Close()
+
+			credentialsSecret := ocmServer.CredentialsSecret("ocm-credentials", "default")
+			rosaScope := &scope.ROSAControlPlaneScope{
+				Client:  fake.NewClientBuilder().WithObjects(credentialsSecret).Build(),
+				Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}},
+				ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa-control-plane", Namespace: "default"},
+					Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+						Version:              tt.version,
+						CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecret.Name},
+					},
+				},
+			}
+			ocmClient, err := rosa.NewOCMClient(ctx, rosaScope)
+			g.Expect(err).ToNot(HaveOccurred())
+			defer ocmClient.Close()
+
+			cluster, err := ocmServer.AddCluster(mustBuildCluster(g, cmv1.NewCluster().Name("capa-rosa").
+				Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5"))))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			automaticPolicy, err := cmv1.NewControlPlaneUpgradePolicy().
+				UpgradeType(cmv1.UpgradeTypeControlPlane).
+				ScheduleType(cmv1.ScheduleTypeAutomatic).
+				Schedule("0 2 * * 6").
+				Build()
+			g.Expect(err).ToNot(HaveOccurred())
+			automaticPolicy, err = ocmServer.AddControlPlaneUpgradePolicy(cluster.ID(), automaticPolicy)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.capaPolicy {
+				rosaScope.ControlPlane.Status.AutomaticUpgradePolicyID = automaticPolicy.ID()
+			}
+
+			r := &ROSAControlPlaneReconciler{}
+			err = r.reconcileClusterVersion(rosaScope, ocmClient, cluster)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(rosaScope.ControlPlane.Status.AutomaticUpgradePolicyID).To(BeEmpty())
+
+			upgradePolicies, err := ocmServer.ControlPlaneUpgradePolicies(cluster.ID())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ids := []string{}
+			versions := []string{}
+			for _, upgradePolicy := range upgradePolicies {
+				ids = append(ids, upgradePolicy.ID())
+				if upgradePolicy.ScheduleType() == cmv1.ScheduleTypeManual {
+					versions = append(versions, upgradePolicy.Version())
+				}
+			}
+			if tt.expectCancelled {
+				g.Expect(ids).ToNot(ContainElement(automaticPolicy.ID()))
+			} else {
+				g.Expect(ids).To(ContainElement(automaticPolicy.ID()))
+			}
+			if tt.expectScheduledTo != "" {
+				g.Expect(versions).To(ConsistOf(tt.expectScheduledTo))
+			} else {
+				g.Expect(versions).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestReconcileRosaRoleConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(rosacontrolplanev1.AddToScheme(scheme)).To(Succeed())
+	roleConfig := &rosacontrolplanev1.ROSARoleConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-config", Namespace: "default"},
+	}
+	r := &ROSAControlPlaneReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(roleConfig).WithStatusSubresource(roleConfig).Build(),
+	}
+	rosaScope := &scope.ROSAControlPlaneScope{
+		Logger: *logger.FromContext(ctx),
+		ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa-control-plane", Namespace: "default"},
+			Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+				RosaRoleConfigRef: &corev1.LocalObjectReference{Name: "missing"},
+			},
+		},
+	}
+
+	// a missing ROSARoleConfig is reported in the Valid condition.
+	current, err := r.reconcileRosaRoleConfig(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(current).To(BeNil())
+	g.Expect(conditions.GetReason(rosaScope.ControlPlane, rosacontrolplanev1.ROSAControlPlaneValidCondition)).To(Equal(rosacontrolplanev1.ROSARoleConfigNotFoundReason))
+
+	rosaScope.ControlPlane.Spec.RosaRoleConfigRef.Name = roleConfig.Name
+	current, err = r.reconcileRosaRoleConfig(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(current).To(BeNil())
+	g.Expect(conditions.GetReason(rosaScope.ControlPlane, rosacontrolplanev1.ROSAControlPlaneValidCondition)).To(Equal(rosacontrolplanev1.ROSARoleConfigNotReadyReason))
+
+	roleConfig.Status = rosacontrolplanev1.ROSARoleConfigStatus{
+		Ready:  true,
+		OIDCID: "oidc-id",
+		AccountRolesRef: rosacontrolplanev1.AccountRolesRef{
+			InstallerRoleARN: "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Installer-Role",
+		},
+	}
+	g.Expect(r.Client.Status().Update(ctx, roleConfig)).To(Succeed())
+	current, err = r.reconcileRosaRoleConfig(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(current).ToNot(BeNil())
+
+	// the roles are only used to create the cluster, they aren't written back to the spec.
+	spec := controlPlaneSpecWithRoles(rosaScope.ControlPlane.Spec, current)
+	g.Expect(spec.InstallerRoleARN).To(Equal("arn:aws:iam::123456789012:role/capa-HCP-ROSA-Installer-Role"))
+	g.Expect(spec.OIDCID).To(Equal("oidc-id"))
+	g.Expect(rosaScope.ControlPlane.Spec.InstallerRoleARN).To(BeEmpty())
+	g.Expect(rosaScope.ControlPlane.Spec.OIDCID).To(BeEmpty())
+}
+
+func TestValidateSharedVPC(t *testing.T) {
+	validSharedVPC := rosacontrolplanev1.SharedVPC{
+		RouteRoleARN:                             "arn:aws:iam::210987654321:role/shared-vpc-route",
+		IngressPrivateHostedZoneID:               "Z05327581O0D8Y2VMTJAN",
+		VPCEndpointRoleARN:                       "arn:aws:iam::210987654321:role/shared-vpc-endpoint",
+		InternalCommunicationPrivateHostedZoneID: "Z0123456789ABCDEFGHIJ",
+		BaseDomain:                               "shared.example.com",
+	}
+
+	tests := []struct {
+		name          string
+		modify        func(sharedVPC *rosacontrolplanev1.SharedVPC)
+		expectMessage string
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:          "missing role ARN",
+			modify:        func(sharedVPC *rosacontrolplanev1.SharedVPC) { sharedVPC.RouteRoleARN = "" },
+			expectMessage: "sharedVPC.routeRoleARN \"\" is not a valid ARN",
+		},
+		{
+			name: "route role ARN in the account of the cluster",
+			modify: func(sharedVPC *rosacontrolplanev1.SharedVPC) {
+				sharedVPC.RouteRoleARN = "arn:aws:iam::123456789012:role/shared-vpc-route"
+			},
+			expectMessage: "sharedVPC.routeRoleARN \"arn:aws:iam::123456789012:role/shared-vpc-route\" must be in the account of the VPC owner",
+		},
+		{
+			name: "VPC endpoint role ARN in the account of the cluster",
+			modify: func(sharedVPC *rosacontrolplanev1.SharedVPC) {
+				sharedVPC.VPCEndpointRoleARN = "arn:aws:iam::123456789012:role/shared-vpc-endpoint"
+			},
+			expectMessage: "sharedVPC.vpcEndpointRoleARN \"arn:aws:iam::123456789012:role/shared-vpc-endpoint\" must be in the account of the VPC owner",
+		},
+		{
+			name:          "missing base domain",
+			modify:        func(sharedVPC *rosacontrolplanev1.SharedVPC) { sharedVPC.BaseDomain = "" },
+			expectMessage: "is not a valid domain",
+		},
+		{
+			name:          "invalid base domain",
+			modify:        func(sharedVPC *rosacontrolplanev1.SharedVPC) { sharedVPC.BaseDomain = "Shared_Example.com" },
+			expectMessage: "is not a valid domain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			sharedVPC := validSharedVPC.DeepCopy()
+			if tt.modify != nil {
+				tt.modify(sharedVPC)
+			}
+
+			message := validateSharedVPC(sharedVPC, "123456789012")
+			if tt.expectMessage == "" {
+				g.Expect(message).To(BeEmpty())
+			} else {
+				g.Expect(message).To(ContainSubstring(tt.expectMessage))
+			}
+		})
+	}
+}
+
+func TestBuildOCMClusterSpecSharedVPC(t *testing.T) {
+	tests := []struct {
+		name                      string
+		sharedVPC                 *rosacontrolplanev1.SharedVPC
+		expectSharedVPCRoleArn    string
+		expectPrivateHostedZoneID string
+		expectBaseDomain          string
+		expectAWSSettings         rosa.AWSClusterSettings
+	}{
+		{
+			name: "no shared VPC",
+		},
+		{
+			name: "shared VPC",
+			sharedVPC: &rosacontrolplanev1.SharedVPC{
+				RouteRoleARN:                             "arn:aws:iam::210987654321:role/shared-vpc-route",
+				IngressPrivateHostedZoneID:               "Z05327581O0D8Y2VMTJAN",
+				VPCEndpointRoleARN:                       "arn:aws:iam::210987654321:role/shared-vpc-endpoint",
+				InternalCommunicationPrivateHostedZoneID: "Z0123456789ABCDEFGHIJ",
+				BaseDomain:                               "shared.example.com",
+			},
+			expectSharedVPCRoleArn:    "arn:aws:iam::210987654321:role/shared-vpc-route",
+			expectPrivateHostedZoneID: "Z05327581O0D8Y2VMTJAN",
+			expectBaseDomain:          "shared.example.com",
+			expectAWSSettings: rosa.AWSClusterSettings{
+				VPCEndpointRoleARN:                   "arn:aws:iam::210987654321:role/shared-vpc-endpoint",
+				HCPInternalCommunicationHostedZoneID: "Z0123456789ABCDEFGHIJ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlaneSpec := rosacontrolplanev1.RosaControlPlaneSpec{
+				RosaClusterName: "capa-rosa",
+				Version:         "4.14.5",
+				SharedVPC:       tt.sharedVPC,
+			}
+			spec, err := buildOCMClusterSpec(controlPlaneSpec, &rosaaws.Creator{AccountID: "123456789012"}, "")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec.SharedVPCRoleArn).To(Equal(tt.expectSharedVPCRoleArn))
+			g.Expect(spec.PrivateHostedZoneID).To(Equal(tt.expectPrivateHostedZoneID))
+			g.Expect(spec.BaseDomain).To(Equal(tt.expectBaseDomain))
+			// the settings which buildOCMClusterSpec doesn't support are sent over the raw OCM connection.
+			g.Expect(awsClusterSettings(controlPlaneSpec)).To(Equal(tt.expectAWSSettings))
+		})
+	}
+}
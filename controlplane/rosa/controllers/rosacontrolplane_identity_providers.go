@@ -0,0 +1,429 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/ocm"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileIdentityProviders creates, updates and deletes the identity providers of the cluster to match the ROSAControlPlane spec
+// and syncs the members of the admin groups. Identity providers which weren't created from the ROSAControlPlane are left alone.
+func (r *ROSAControlPlaneReconciler) reconcileIdentityProviders(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
+	controlPlane := rosaScope.ControlPlane
+	if len(controlPlane.Spec.IdentityProviders) == 0 && controlPlane.Spec.GroupMemberships == nil &&
+		len(controlPlane.Status.IdentityProviders) == 0 {
+		return nil
+	}
+
+	err := r.reconcileIdentityProviderConfigs(ctx, rosaScope, cluster)
+	if err == nil {
+		err = reconcileGroupMemberships(rosaScope, ocmClient, cluster)
+	}
+	if err != nil {
+		conditions.MarkFalse(controlPlane,
+			rosacontrolplanev1.IdentityProvidersReadyCondition,
+			rosacontrolplanev1.ReconciliationFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return err
+	}
+
+	conditions.MarkTrue(controlPlane, rosacontrolplanev1.IdentityProvidersReadyCondition)
+	return nil
+}
+
+func (r *ROSAControlPlaneReconciler) reconcileIdentityProviderConfigs(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, cluster *cmv1.Cluster) error {
+	idpClient, err := rosa.NewIdentityProviderClient(ctx, rosaScope)
+	if err != nil {
+		return fmt.Errorf("failed to create identity provider client: %w", err)
+	}
+	defer idpClient.Close()
+
+	idps, err := idpClient.ListIdentityProviders(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list identity providers: %w", err)
+	}
+	existingIDPs := make(map[string]*cmv1.IdentityProvider, len(idps))
+	for _, idp := range idps {
+		existingIDPs[idp.Name()] = idp
+	}
+
+	applied := map[string]string{}
+	for _, status := range rosaScope.ControlPlane.Status.IdentityProviders {
+		applied[status.Name] = status.ConfigHash
+	}
+	// record the identity providers applied so far even if a later one fails, so that they are deleted once removed from the spec.
+	defer setManagedIdentityProviders(rosaScope, applied)
+
+	desiredNames := make([]string, 0, len(rosaScope.ControlPlane.Spec.IdentityProviders))
+	for _, idp := range rosaScope.ControlPlane.Spec.IdentityProviders {
+		desiredNames = append(desiredNames, idp.Name)
+
+		secrets, secretVersions, err := r.identityProviderSecrets(ctx, rosaScope, idp)
+		if err != nil {
+			return err
+		}
+		hash, err := identityProviderHash(idp, secretVersions)
+		if err != nil {
+			return fmt.Errorf("failed to hash identity provider %q: %w", idp.Name, err)
+		}
+
+		existing, found := existingIDPs[idp.Name]
+		if found && applied[idp.Name] == hash {
+			continue
+		}
+
+		ocmIDP, err := buildIdentityProvider(idp, secrets)
+		if err != nil {
+			return fmt.Errorf("failed to build identity provider %q: %w", idp.Name, err)
+		}
+
+		if found && existing.Type() != ocmIDP.Type() {
+			// the type of an identity provider can't be changed, it has to be recreated.
+			rosaScope.Info("deleting identity provider to change its type", "name", idp.Name)
+			if err := idpClient.DeleteIdentityProvider(cluster.ID(), existing.ID()); err != nil {
+				return fmt.Errorf("failed to delete identity provider %q: %w", idp.Name, err)
+			}
+			found = false
+		}
+
+		switch {
+		case !found:
+			rosaScope.Info("creating identity provider", "name", idp.Name)
+			if _, err := idpClient.CreateIdentityProvider(cluster.ID(), ocmIDP); err != nil {
+				return fmt.Errorf("failed to create identity provider %q: %w", idp.Name, err)
+			}
+		case idp.Type == rosacontrolplanev1.IdentityProviderTypeHTPasswd:
+			rosaScope.Info("updating identity provider", "name", idp.Name)
+			if err := updateHTPasswdIdentityProvider(idpClient, cluster.ID(), existing.ID(), idp, secrets); err != nil {
+				return fmt.Errorf("failed to update identity provider %q: %w", idp.Name, err)
+			}
+		default:
+			rosaScope.Info("updating identity provider", "name", idp.Name)
+			if _, err := idpClient.UpdateIdentityProvider(cluster.ID(), existing.ID(), ocmIDP); err != nil {
+				return fmt.Errorf("failed to update identity provider %q: %w", idp.Name, err)
+			}
+		}
+		applied[idp.Name] = hash
+	}
+
+	for name := range applied {
+		if slices.Contains(desiredNames, name) {
+			continue
+		}
+		if existing, found := existingIDPs[name]; found {
+			rosaScope.Info("deleting identity provider", "name", name)
+			if err := idpClient.DeleteIdentityProvider(cluster.ID(), existing.ID()); err != nil {
+				return fmt.Errorf("failed to delete identity provider %q: %w", name, err)
+			}
+		}
+		delete(applied, name)
+	}
+
+	return nil
+}
+
+// identityProviderSecrets returns the values of the secrets referenced by the identity provider, and the versions of the secrets
+// by name. The users of htpasswd identity providers are returned as a map of user names to passwords.
+func (r *ROSAControlPlaneReconciler) identityProviderSecrets(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, idp rosacontrolplanev1.IdentityProvider) (map[string]string, map[string]string, error) {
+	secretVersions := map[string]string{}
+	getSecret := func(name string) (*corev1.Secret, error) {
+		secretObj := &corev1.Secret{}
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: rosaScope.Namespace(), Name: name}, secretObj); err != nil {
+			return nil, fmt.Errorf("failed to get secret %s of identity provider %q: %w", name, idp.Name, err)
+		}
+		secretVersions[name] = secretVersion(secretObj)
+		return secretObj, nil
+	}
+	getSecretKey := func(name, key string) (map[string]string, error) {
+		secretObj, err := getSecret(name)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := secretObj.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s of identity provider %q has no %q key", name, idp.Name, key)
+		}
+		return map[string]string{key: string(value)}, nil
+	}
+
+	var secrets map[string]string
+	var err error
+	switch idp.Type {
+	case rosacontrolplanev1.IdentityProviderTypeGitHub:
+		secrets, err = getSecretKey(idp.GitHub.ClientSecret.Name, "clientSecret")
+	case rosacontrolplanev1.IdentityProviderTypeGitLab:
+		secrets, err = getSecretKey(idp.GitLab.ClientSecret.Name, "clientSecret")
+	case rosacontrolplanev1.IdentityProviderTypeGoogle:
+		secrets, err = getSecretKey(idp.Google.ClientSecret.Name, "clientSecret")
+	case rosacontrolplanev1.IdentityProviderTypeOpenID:
+		secrets, err = getSecretKey(idp.OpenID.ClientSecret.Name, "clientSecret")
+	case rosacontrolplanev1.IdentityProviderTypeLDAP:
+		if idp.LDAP.BindPassword == nil {
+			return map[string]string{}, secretVersions, nil
+		}
+		secrets, err = getSecretKey(idp.LDAP.BindPassword.Name, "bindPassword")
+	case rosacontrolplanev1.IdentityProviderTypeHTPasswd:
+		secretObj, getErr := getSecret(idp.HTPasswd.Users.Name)
+		if getErr != nil {
+			return nil, nil, getErr
+		}
+		if len(secretObj.Data) == 0 {
+			return nil, nil, fmt.Errorf("secret %s of identity provider %q has no users", idp.HTPasswd.Users.Name, idp.Name)
+		}
+		secrets = make(map[string]string, len(secretObj.Data))
+		for username, password := range secretObj.Data {
+			secrets[username] = string(password)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported type %q of identity provider %q", idp.Type, idp.Name)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secrets, secretVersions, nil
+}
+
+// secretVersion identifies the content of the secret without revealing it: the resource version changes whenever the secret is
+// updated, and the UID whenever it's recreated.
+func secretVersion(secret *corev1.Secret) string {
+	return fmt.Sprintf("%s/%s", secret.UID, secret.ResourceVersion)
+}
+
+// identityProviderHash hashes the identity provider spec together with the versions of its secrets. The values of the secrets
+// are never hashed, as the hash is published in the status.
+func identityProviderHash(idp rosacontrolplanev1.IdentityProvider, secretVersions map[string]string) (string, error) {
+	data, err := json.Marshal(struct {
+		IdentityProvider rosacontrolplanev1.IdentityProvider `json:"identityProvider"`
+		SecretVersions   map[string]string                   `json:"secretVersions"`
+	}{idp, secretVersions})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func buildIdentityProvider(idp rosacontrolplanev1.IdentityProvider, secrets map[string]string) (*cmv1.IdentityProvider, error) {
+	builder := cmv1.NewIdentityProvider().
+		Name(idp.Name).
+		MappingMethod(cmv1.IdentityProviderMappingMethod(idp.MappingMethod))
+
+	switch idp.Type {
+	case rosacontrolplanev1.IdentityProviderTypeGitHub:
+		builder.Type(cmv1.IdentityProviderTypeGithub).Github(cmv1.NewGithubIdentityProvider().
+			ClientID(idp.GitHub.ClientID).
+			ClientSecret(secrets["clientSecret"]).
+			Hostname(idp.GitHub.Hostname).
+			Organizations(idp.GitHub.Organizations...).
+			Teams(idp.GitHub.Teams...))
+	case rosacontrolplanev1.IdentityProviderTypeGitLab:
+		builder.Type(cmv1.IdentityProviderTypeGitlab).Gitlab(cmv1.NewGitlabIdentityProvider().
+			URL(idp.GitLab.URL).
+			ClientID(idp.GitLab.ClientID).
+			ClientSecret(secrets["clientSecret"]))
+	case rosacontrolplanev1.IdentityProviderTypeGoogle:
+		builder.Type(cmv1.IdentityProviderTypeGoogle).Google(cmv1.NewGoogleIdentityProvider().
+			ClientID(idp.Google.ClientID).
+			ClientSecret(secrets["clientSecret"]).
+			HostedDomain(idp.Google.HostedDomain))
+	case rosacontrolplanev1.IdentityProviderTypeOpenID:
+		builder.Type(cmv1.IdentityProviderTypeOpenID).OpenID(cmv1.NewOpenIDIdentityProvider().
+			Issuer(idp.OpenID.IssuerURL).
+			ClientID(idp.OpenID.ClientID).
+			ClientSecret(secrets["clientSecret"]).
+			ExtraScopes(idp.OpenID.ExtraScopes...).
+			Claims(cmv1.NewOpenIDClaims().
+				Email(idp.OpenID.Claims.Email...).
+				Name(idp.OpenID.Claims.Name...).
+				PreferredUsername(idp.OpenID.Claims.PreferredUsername...).
+				Groups(idp.OpenID.Claims.Groups...)))
+	case rosacontrolplanev1.IdentityProviderTypeLDAP:
+		ldapBuilder := cmv1.NewLDAPIdentityProvider().
+			URL(idp.LDAP.URL).
+			Insecure(idp.LDAP.Insecure).
+			Attributes(cmv1.NewLDAPAttributes().
+				ID(idp.LDAP.Attributes.ID...).
+				Email(idp.LDAP.Attributes.Email...).
+				Name(idp.LDAP.Attributes.Name...).
+				PreferredUsername(idp.LDAP.Attributes.PreferredUsername...))
+		if idp.LDAP.BindDN != "" {
+			ldapBuilder.BindDN(idp.LDAP.BindDN)
+		}
+		if bindPassword, ok := secrets["bindPassword"]; ok {
+			ldapBuilder.BindPassword(bindPassword)
+		}
+		builder.Type(cmv1.IdentityProviderTypeLDAP).LDAP(ldapBuilder)
+	case rosacontrolplanev1.IdentityProviderTypeHTPasswd:
+		usernames := make([]string, 0, len(secrets))
+		for username := range secrets {
+			usernames = append(usernames, username)
+		}
+		slices.Sort(usernames)
+
+		users := make([]*cmv1.HTPasswdUserBuilder, 0, len(usernames))
+		for _, username := range usernames {
+			users = append(users, cmv1.NewHTPasswdUser().Username(username).Password(secrets[username]))
+		}
+		builder.Type(cmv1.IdentityProviderTypeHtpasswd).Htpasswd(cmv1.NewHTPasswdIdentityProvider().
+			Users(cmv1.NewHTPasswdUserList().Items(users...)))
+	default:
+		return nil, fmt.Errorf("unsupported identity provider type %q", idp.Type)
+	}
+
+	return builder.Build()
+}
+
+// updateHTPasswdIdentityProvider updates the mapping method and syncs the users of an htpasswd identity provider, as the users
+// are a sub-resource which can't be updated with the identity provider. The passwords of the users can't be read back from OCM,
+// so they are all updated.
+func updateHTPasswdIdentityProvider(idpClient *rosa.IdentityProviderClient, clusterID, idpID string, idp rosacontrolplanev1.IdentityProvider, users map[string]string) error {
+	ocmIDP, err := cmv1.NewIdentityProvider().
+		Type(cmv1.IdentityProviderTypeHtpasswd).
+		MappingMethod(cmv1.IdentityProviderMappingMethod(idp.MappingMethod)).
+		Build()
+	if err != nil {
+		return err
+	}
+	if _, err := idpClient.UpdateIdentityProvider(clusterID, idpID, ocmIDP); err != nil {
+		return err
+	}
+
+	existingUsers, err := idpClient.ListHTPasswdUsers(clusterID, idpID)
+	if err != nil {
+		return fmt.Errorf("failed to list htpasswd users: %w", err)
+	}
+	existingUserIDs := make(map[string]string, len(existingUsers))
+	for _, user := range existingUsers {
+		existingUserIDs[user.Username()] = user.ID()
+	}
+
+	for username, password := range users {
+		if userID, found := existingUserIDs[username]; found {
+			user, err := cmv1.NewHTPasswdUser().Password(password).Build()
+			if err != nil {
+				return err
+			}
+			if err := idpClient.UpdateHTPasswdUser(clusterID, idpID, userID, user); err != nil {
+				return fmt.Errorf("failed to update htpasswd user %q: %w", username, err)
+			}
+			continue
+		}
+
+		user, err := cmv1.NewHTPasswdUser().Username(username).Password(password).Build()
+		if err != nil {
+			return err
+		}
+		if err := idpClient.AddHTPasswdUser(clusterID, idpID, user); err != nil {
+			return fmt.Errorf("failed to add htpasswd user %q: %w", username, err)
+		}
+	}
+
+	for username, userID := range existingUserIDs {
+		if _, found := users[username]; found {
+			continue
+		}
+		if err := idpClient.DeleteHTPasswdUser(clusterID, idpID, userID); err != nil {
+			return fmt.Errorf("failed to delete htpasswd user %q: %w", username, err)
+		}
+	}
+
+	return nil
+}
+
+// setManagedIdentityProviders sets the status of the managed identity providers, sorted by name.
+func setManagedIdentityProviders(rosaScope *scope.ROSAControlPlaneScope, applied map[string]string) {
+	statuses := make([]rosacontrolplanev1.IdentityProviderStatus, 0, len(applied))
+	for name, hash := range applied {
+		statuses = append(statuses, rosacontrolplanev1.IdentityProviderStatus{Name: name, ConfigHash: hash})
+	}
+	slices.SortFunc(statuses, func(a, b rosacontrolplanev1.IdentityProviderStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	rosaScope.ControlPlane.Status.IdentityProviders = statuses
+}
+
+// reconcileGroupMemberships syncs the members of the `dedicated-admins` and `cluster-admins` groups with the spec. The user of
+// the kubeconfig generated by CAPA is always kept in the `cluster-admins` group.
+func reconcileGroupMemberships(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
+	groupMemberships := rosaScope.ControlPlane.Spec.GroupMemberships
+	if groupMemberships == nil {
+		return nil
+	}
+
+	groups := []struct {
+		name  string
+		users []string
+	}{
+		{name: rosa.DedicatedAdminsGroup, users: groupMemberships.DedicatedAdmins},
+		{name: rosa.ClusterAdminsGroup, users: append([]string{clusterAdminUserName(rosaScope)}, groupMemberships.ClusterAdmins...)},
+	}
+
+	for _, group := range groups {
+		members, err := ocmClient.GetUsers(cluster.ID(), group.name)
+		if err != nil {
+			return fmt.Errorf("failed to list users of group %q: %w", group.name, err)
+		}
+
+		existing := make([]string, 0, len(members))
+		for _, member := range members {
+			existing = append(existing, member.ID())
+			if slices.Contains(group.users, member.ID()) {
+				continue
+			}
+			rosaScope.Info("removing user from group", "user", member.ID(), "group", group.name)
+			if err := ocmClient.DeleteUser(cluster.ID(), group.name, member.ID()); err != nil {
+				return fmt.Errorf("failed to remove user %q from group %q: %w", member.ID(), group.name, err)
+			}
+		}
+
+		for _, username := range group.users {
+			if slices.Contains(existing, username) {
+				continue
+			}
+			user, err := cmv1.NewUser().ID(username).Build()
+			if err != nil {
+				return fmt.Errorf("failed to build user %q: %w", username, err)
+			}
+			rosaScope.Info("adding user to group", "user", username, "group", group.name)
+			if _, err := ocmClient.CreateUser(cluster.ID(), group.name, user); err != nil {
+				return fmt.Errorf("failed to add user %q to group %q: %w", username, group.name, err)
+			}
+		}
+	}
+
+	return nil
+}
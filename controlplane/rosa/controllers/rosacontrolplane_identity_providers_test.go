@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestBuildIdentityProvider(t *testing.T) {
+	g := NewWithT(t)
+
+	github, err := buildIdentityProvider(rosacontrolplanev1.IdentityProvider{
+		Name:          "github",
+		Type:          rosacontrolplanev1.IdentityProviderTypeGitHub,
+		MappingMethod: "claim",
+		GitHub: &rosacontrolplanev1.GitHubIdentityProvider{
+			ClientID:      "client-id",
+			ClientSecret:  rosacontrolplanev1.LocalObjectReference{Name: "github-secret"},
+			Organizations: []string{"my-org"},
+		},
+	}, map[string]string{"clientSecret": "secret"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(github.Type()).To(Equal(cmv1.IdentityProviderTypeGithub))
+	g.Expect(github.MappingMethod()).To(Equal(cmv1.IdentityProviderMappingMethodClaim))
+	g.Expect(github.Github().ClientSecret()).To(Equal("secret"))
+	g.Expect(github.Github().Organizations()).To(ConsistOf("my-org"))
+
+	ldap, err := buildIdentityProvider(rosacontrolplanev1.IdentityProvider{
+		Name: "ldap",
+		Type: rosacontrolplanev1.IdentityProviderTypeLDAP,
+		LDAP: &rosacontrolplanev1.LDAPIdentityProvider{
+			URL:        "ldaps://ldap.example.com/ou=users,dc=example,dc=com?uid",
+			BindDN:     "cn=admin,dc=example,dc=com",
+			Attributes: rosacontrolplanev1.LDAPAttributes{ID: []string{"dn"}},
+		},
+	}, map[string]string{"bindPassword": "password"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ldap.Type()).To(Equal(cmv1.IdentityProviderTypeLDAP))
+	g.Expect(ldap.LDAP().BindPassword()).To(Equal("password"))
+	g.Expect(ldap.LDAP().Attributes().ID()).To(ConsistOf("dn"))
+
+	htpasswd, err := buildIdentityProvider(rosacontrolplanev1.IdentityProvider{
+		Name:     "htpasswd",
+		Type:     rosacontrolplanev1.IdentityProviderTypeHTPasswd,
+		HTPasswd: &rosacontrolplanev1.HTPasswdIdentityProvider{Users: rosacontrolplanev1.LocalObjectReference{Name: "users"}},
+	}, map[string]string{"bob": "bob-password", "alice": "alice-password"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(htpasswd.Type()).To(Equal(cmv1.IdentityProviderTypeHtpasswd))
+	users := htpasswd.Htpasswd().Users().Slice()
+	g.Expect(users).To(HaveLen(2))
+	g.Expect(users[0].Username()).To(Equal("alice"))
+	g.Expect(users[0].Password()).To(Equal("alice-password"))
+	g.Expect(users[1].Username()).To(Equal("bob"))
+}
+
+func TestIdentityProviderHash(t *testing.T) {
+	g := NewWithT(t)
+
+	idp := rosacontrolplanev1.IdentityProvider{
+		Name: "google",
+		Type: rosacontrolplanev1.IdentityProviderTypeGoogle,
+		Google: &rosacontrolplanev1.GoogleIdentityProvider{
+			ClientID:     "client-id",
+			ClientSecret: rosacontrolplanev1.LocalObjectReference{Name: "google-secret"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "google-secret", Namespace: "default"},
+		Data:       map[string][]byte{"clientSecret": []byte("secret")},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(secret).Build()
+	r := &ROSAControlPlaneReconciler{Client: fakeClient}
+	rosaScope := &scope.ROSAControlPlaneScope{
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}},
+	}
+
+	secrets, secretVersions, err := r.identityProviderSecrets(ctx, rosaScope, idp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(secrets).To(Equal(map[string]string{"clientSecret": "secret"}))
+	g.Expect(secretVersions).To(HaveKey("google-secret"))
+	g.Expect(secretVersions["google-secret"]).ToNot(ContainSubstring("secret"))
+
+	hash, err := identityProviderHash(idp, secretVersions)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sameHash, err := identityProviderHash(idp, secretVersions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sameHash).To(Equal(hash))
+
+	// the hash doesn't depend on the content of the secret, only on its version.
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	secret.Data["clientSecret"] = []byte("rotated")
+	g.Expect(fakeClient.Update(ctx, secret)).To(Succeed())
+	_, rotatedSecretVersions, err := r.identityProviderSecrets(ctx, rosaScope, idp)
+	g.Expect(err).ToNot(HaveOccurred())
+	rotatedSecretHash, err := identityProviderHash(idp, rotatedSecretVersions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotatedSecretHash).ToNot(Equal(hash))
+
+	idp.Google.HostedDomain = "example.com"
+	changedSpecHash, err := identityProviderHash(idp, secretVersions)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(changedSpecHash).ToNot(Equal(hash))
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	logForwarderDestinationCloudWatch = "CloudWatch"
+	logForwarderDestinationS3         = "S3"
+)
+
+// logForwarderDestinations lists the destinations of the log forwarders in the order they are reconciled and reported in the status.
+var logForwarderDestinations = []string{logForwarderDestinationCloudWatch, logForwarderDestinationS3}
+
+// reconcileLogForwarding creates, updates and deletes the log forwarders of the cluster to match the ROSAControlPlane spec, with one
+// log forwarder per destination. Log forwarders which weren't created from the ROSAControlPlane are left alone.
+func (r *ROSAControlPlaneReconciler) reconcileLogForwarding(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, cluster *cmv1.Cluster) error {
+	controlPlane := rosaScope.ControlPlane
+	if controlPlane.Spec.LogForwarding == nil && len(controlPlane.Status.LogForwarders) == 0 {
+		return nil
+	}
+
+	if err := reconcileLogForwarders(ctx, rosaScope, cluster); err != nil {
+		conditions.MarkFalse(controlPlane,
+			rosacontrolplanev1.LogForwardingReadyCondition,
+			rosacontrolplanev1.ReconciliationFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return err
+	}
+
+	conditions.MarkTrue(controlPlane, rosacontrolplanev1.LogForwardingReadyCondition)
+	return nil
+}
+
+func reconcileLogForwarders(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, cluster *cmv1.Cluster) error {
+	logForwarderClient, err := rosa.NewLogForwarderClient(ctx, rosaScope)
+	if err != nil {
+		return fmt.Errorf("failed to create log forwarder client: %w", err)
+	}
+	defer logForwarderClient.Close()
+
+	logForwarders, err := logForwarderClient.ListLogForwarders(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list log forwarders: %w", err)
+	}
+	existingLogForwarders := make(map[string]*rosa.LogForwarder, len(logForwarders))
+	for _, logForwarder := range logForwarders {
+		existingLogForwarders[logForwarder.ID] = logForwarder
+	}
+
+	managed := map[string]rosacontrolplanev1.LogForwarderStatus{}
+	for _, status := range rosaScope.ControlPlane.Status.LogForwarders {
+		managed[status.Destination] = status
+	}
+	// record the log forwarders created so far even if a later one fails, so that they aren't created twice.
+	defer setManagedLogForwarders(rosaScope, managed)
+
+	desiredLogForwarders := buildLogForwarders(rosaScope.ControlPlane.Spec.LogForwarding)
+	for _, destination := range logForwarderDestinations {
+		desired := desiredLogForwarders[destination]
+		current, found := existingLogForwarders[managed[destination].ID]
+
+		switch {
+		case desired == nil:
+			if found {
+				rosaScope.Info("deleting log forwarder", "destination", destination, "id", current.ID)
+				if err := logForwarderClient.DeleteLogForwarder(cluster.ID(), current.ID); err != nil {
+					return fmt.Errorf("failed to delete %s log forwarder: %w", destination, err)
+				}
+			}
+			delete(managed, destination)
+			continue
+		case !found:
+			rosaScope.Info("creating log forwarder", "destination", destination)
+			current, err = logForwarderClient.CreateLogForwarder(cluster.ID(), desired)
+			if err != nil {
+				return fmt.Errorf("failed to create %s log forwarder: %w", destination, err)
+			}
+		case !equalLogForwarders(desired, current):
+			rosaScope.Info("updating log forwarder", "destination", destination, "id", current.ID)
+			id := current.ID
+			current, err = logForwarderClient.UpdateLogForwarder(cluster.ID(), id, desired)
+			if err != nil {
+				return fmt.Errorf("failed to update %s log forwarder: %w", destination, err)
+			}
+			current.ID = id
+		}
+
+		status := rosacontrolplanev1.LogForwarderStatus{
+			Destination: destination,
+			ID:          current.ID,
+		}
+		if current.Status != nil {
+			status.State = current.Status.State
+			status.Message = current.Status.Message
+		}
+		managed[destination] = status
+	}
+
+	return nil
+}
+
+// buildLogForwarders returns the desired log forwarders by destination.
+func buildLogForwarders(logForwarding *rosacontrolplanev1.LogForwarding) map[string]*rosa.LogForwarder {
+	logForwarders := map[string]*rosa.LogForwarder{}
+	if logForwarding == nil {
+		return logForwarders
+	}
+
+	groups := make([]rosa.LogForwarderGroup, 0, len(logForwarding.Groups))
+	for _, group := range logForwarding.Groups {
+		groups = append(groups, rosa.LogForwarderGroup{ID: group})
+	}
+
+	if logForwarding.CloudWatch != nil {
+		logForwarders[logForwarderDestinationCloudWatch] = &rosa.LogForwarder{
+			Applications: logForwarding.Applications,
+			Groups:       groups,
+			CloudWatch: &rosa.LogForwarderCloudWatch{
+				LogGroupName:           logForwarding.CloudWatch.LogGroupName,
+				LogDistributionRoleARN: logForwarding.CloudWatch.LogDistributionRoleARN,
+			},
+		}
+	}
+	if logForwarding.S3 != nil {
+		logForwarders[logForwarderDestinationS3] = &rosa.LogForwarder{
+			Applications: logForwarding.Applications,
+			Groups:       groups,
+			S3: &rosa.LogForwarderS3{
+				BucketName:   logForwarding.S3.BucketName,
+				BucketPrefix: logForwarding.S3.BucketPrefix,
+			},
+		}
+	}
+
+	return logForwarders
+}
+
+// equalLogForwarders compares the destinations, applications and groups of the log forwarders. The versions of the groups are
+// ignored as OCM picks the latest version of the groups.
+func equalLogForwarders(desired, current *rosa.LogForwarder) bool {
+	groupIDs := func(logForwarder *rosa.LogForwarder) sets.Set[string] {
+		ids := sets.New[string]()
+		for _, group := range logForwarder.Groups {
+			ids.Insert(group.ID)
+		}
+		return ids
+	}
+
+	return cmp.Equal(desired.CloudWatch, current.CloudWatch) &&
+		cmp.Equal(desired.S3, current.S3) &&
+		sets.New(desired.Applications...).Equal(sets.New(current.Applications...)) &&
+		groupIDs(desired).Equal(groupIDs(current))
+}
+
+// setManagedLogForwarders sets the status of the managed log forwarders in the order of their destinations.
+func setManagedLogForwarders(rosaScope *scope.ROSAControlPlaneScope, managed map[string]rosacontrolplanev1.LogForwarderStatus) {
+	statuses := make([]rosacontrolplanev1.LogForwarderStatus, 0, len(managed))
+	for _, destination := range logForwarderDestinations {
+		if status, ok := managed[destination]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	rosaScope.ControlPlane.Status.LogForwarders = statuses
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+)
+
+func TestBuildLogForwarders(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(buildLogForwarders(nil)).To(BeEmpty())
+
+	logForwarders := buildLogForwarders(&rosacontrolplanev1.LogForwarding{
+		CloudWatch: &rosacontrolplanev1.CloudWatchLogForwarding{
+			LogGroupName:           "logs",
+			LogDistributionRoleARN: "arn:aws:iam::123456789012:role/log-distribution",
+		},
+		Groups:       []string{"api"},
+		Applications: []string{"kube-scheduler"},
+	})
+	g.Expect(logForwarders).To(HaveLen(1))
+	g.Expect(logForwarders).To(HaveKey(logForwarderDestinationCloudWatch))
+
+	cloudWatch := logForwarders[logForwarderDestinationCloudWatch]
+	g.Expect(cloudWatch.S3).To(BeNil())
+	g.Expect(cloudWatch.CloudWatch.LogGroupName).To(Equal("logs"))
+	g.Expect(cloudWatch.Groups).To(Equal([]rosa.LogForwarderGroup{{ID: "api"}}))
+	g.Expect(cloudWatch.Applications).To(Equal([]string{"kube-scheduler"}))
+}
+
+func TestEqualLogForwarders(t *testing.T) {
+	g := NewWithT(t)
+
+	desired := &rosa.LogForwarder{
+		Applications: []string{"kube-scheduler", "kube-apiserver"},
+		Groups:       []rosa.LogForwarderGroup{{ID: "api"}},
+		S3:           &rosa.LogForwarderS3{BucketName: "logs"},
+	}
+	current := &rosa.LogForwarder{
+		ID:           "2a3b4c",
+		Applications: []string{"kube-apiserver", "kube-scheduler"},
+		Groups:       []rosa.LogForwarderGroup{{ID: "api", Version: "v1"}},
+		S3:           &rosa.LogForwarderS3{BucketName: "logs"},
+		Status:       &rosa.LogForwarderStatus{State: "ready"},
+	}
+	g.Expect(equalLogForwarders(desired, current)).To(BeTrue())
+
+	current.S3.BucketPrefix = "cluster/"
+	g.Expect(equalLogForwarders(desired, current)).To(BeFalse())
+
+	current.S3.BucketPrefix = ""
+	current.Groups = append(current.Groups, rosa.LogForwarderGroup{ID: "scheduler"})
+	g.Expect(equalLogForwarders(desired, current)).To(BeFalse())
+}
@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-cmp/cmp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/ocm"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileNodePoolConfigs creates, updates and deletes the tuning configs and kubelet configs of the cluster to match the
+// ROSAControlPlane spec. Configs which weren't created from the ROSAControlPlane are left alone.
+func (r *ROSAControlPlaneReconciler) reconcileNodePoolConfigs(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
+	controlPlane := rosaScope.ControlPlane
+	if len(controlPlane.Spec.TuningConfigs) == 0 && len(controlPlane.Spec.KubeletConfigs) == 0 &&
+		len(controlPlane.Status.TuningConfigs) == 0 && len(controlPlane.Status.KubeletConfigs) == 0 {
+		return nil
+	}
+
+	errs := []error{}
+	if err := reconcileTuningConfigs(rosaScope, ocmClient, cluster); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.reconcileKubeletConfigs(ctx, rosaScope, cluster); err != nil {
+		errs = append(errs, err)
+	}
+	if err := kerrors.NewAggregate(errs); err != nil {
+		conditions.MarkFalse(controlPlane,
+			rosacontrolplanev1.NodePoolConfigsReadyCondition,
+			rosacontrolplanev1.ReconciliationFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return err
+	}
+
+	conditions.MarkTrue(controlPlane, rosacontrolplanev1.NodePoolConfigsReadyCondition)
+	return nil
+}
+
+func reconcileTuningConfigs(rosaScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, cluster *cmv1.Cluster) error {
+	tuningConfigs, err := ocmClient.GetTuningConfigs(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list tuning configs: %w", err)
+	}
+	existingTuningConfigs := make(map[string]*cmv1.TuningConfig, len(tuningConfigs))
+	for _, tuningConfig := range tuningConfigs {
+		existingTuningConfigs[tuningConfig.Name()] = tuningConfig
+	}
+
+	managed := &rosaScope.ControlPlane.Status.TuningConfigs
+	errs := []error{}
+	desiredNames := make([]string, 0, len(rosaScope.ControlPlane.Spec.TuningConfigs))
+	for _, tuningConfig := range rosaScope.ControlPlane.Spec.TuningConfigs {
+		desiredNames = append(desiredNames, tuningConfig.Name)
+
+		existing, found := existingTuningConfigs[tuningConfig.Name]
+		if found && !slices.Contains(*managed, tuningConfig.Name) {
+			errs = append(errs, fmt.Errorf("tuning config %q already exists and wasn't created from the ROSAControlPlane", tuningConfig.Name))
+			continue
+		}
+
+		var desiredSpec interface{}
+		if err := json.Unmarshal(tuningConfig.Spec.Raw, &desiredSpec); err != nil {
+			errs = append(errs, fmt.Errorf("invalid spec of tuning config %q: %w", tuningConfig.Name, err))
+			continue
+		}
+		if found && equalTuningConfigSpec(desiredSpec, existing.Spec()) {
+			continue
+		}
+
+		builder := cmv1.NewTuningConfig().Name(tuningConfig.Name).Spec(desiredSpec)
+		if found {
+			builder = builder.ID(existing.ID())
+		}
+		ocmTuningConfig, err := builder.Build()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to build tuning config %q: %w", tuningConfig.Name, err))
+			continue
+		}
+
+		if found {
+			rosaScope.Info("updating tuning config", "name", tuningConfig.Name)
+			if _, err := ocmClient.UpdateTuningConfig(cluster.ID(), ocmTuningConfig); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update tuning config %q: %w", tuningConfig.Name, err))
+			}
+			continue
+		}
+
+		rosaScope.Info("creating tuning config", "name", tuningConfig.Name)
+		if _, err := ocmClient.CreateTuningConfig(cluster.ID(), ocmTuningConfig); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create tuning config %q: %w", tuningConfig.Name, err))
+			continue
+		}
+		// the tuning config is tracked right away, so that it's still deleted if a later step fails.
+		addManagedNodePoolConfig(managed, tuningConfig.Name)
+	}
+
+	for _, name := range removedNodePoolConfigs(*managed, desiredNames) {
+		if existing, found := existingTuningConfigs[name]; found {
+			rosaScope.Info("deleting tuning config", "name", name)
+			if err := ocmClient.DeleteTuningConfig(cluster.ID(), existing.ID()); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete tuning config %q: %w", name, err))
+				continue
+			}
+		}
+		removeManagedNodePoolConfig(managed, name)
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// equalTuningConfigSpec compares the specs after a JSON round trip, as OCM returns the spec as decoded JSON.
+func equalTuningConfigSpec(desired, current interface{}) bool {
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return false
+	}
+	var currentSpec interface{}
+	if err := json.Unmarshal(currentJSON, &currentSpec); err != nil {
+		return false
+	}
+
+	return cmp.Equal(desired, currentSpec)
+}
+
+func (r *ROSAControlPlaneReconciler) reconcileKubeletConfigs(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, cluster *cmv1.Cluster) error {
+	kubeletConfigClient, err := rosa.NewKubeletConfigClient(ctx, rosaScope)
+	if err != nil {
+		return fmt.Errorf("failed to create kubelet config client: %w", err)
+	}
+	defer kubeletConfigClient.Close()
+
+	kubeletConfigs, err := kubeletConfigClient.ListKubeletConfigs(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("failed to list kubelet configs: %w", err)
+	}
+	existingKubeletConfigs := make(map[string]*cmv1.KubeletConfig, len(kubeletConfigs))
+	for _, kubeletConfig := range kubeletConfigs {
+		existingKubeletConfigs[kubeletConfig.Name()] = kubeletConfig
+	}
+
+	managed := &rosaScope.ControlPlane.Status.KubeletConfigs
+	errs := []error{}
+	desiredNames := make([]string, 0, len(rosaScope.ControlPlane.Spec.KubeletConfigs))
+	for _, kubeletConfig := range rosaScope.ControlPlane.Spec.KubeletConfigs {
+		desiredNames = append(desiredNames, kubeletConfig.Name)
+
+		existing, found := existingKubeletConfigs[kubeletConfig.Name]
+		if found && !slices.Contains(*managed, kubeletConfig.Name) {
+			errs = append(errs, fmt.Errorf("kubelet config %q already exists and wasn't created from the ROSAControlPlane", kubeletConfig.Name))
+			continue
+		}
+		if found && existing.PodPidsLimit() == int(kubeletConfig.PodPidsLimit) {
+			continue
+		}
+
+		builder := cmv1.NewKubeletConfig().Name(kubeletConfig.Name).PodPidsLimit(int(kubeletConfig.PodPidsLimit))
+		if found {
+			builder = builder.ID(existing.ID())
+		}
+		ocmKubeletConfig, err := builder.Build()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to build kubelet config %q: %w", kubeletConfig.Name, err))
+			continue
+		}
+
+		if found {
+			rosaScope.Info("updating kubelet config", "name", kubeletConfig.Name)
+			if _, err := kubeletConfigClient.UpdateKubeletConfig(cluster.ID(), ocmKubeletConfig); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update kubelet config %q: %w", kubeletConfig.Name, err))
+			}
+			continue
+		}
+
+		rosaScope.Info("creating kubelet config", "name", kubeletConfig.Name)
+		if _, err := kubeletConfigClient.CreateKubeletConfig(cluster.ID(), ocmKubeletConfig); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create kubelet config %q: %w", kubeletConfig.Name, err))
+			continue
+		}
+		// the kubelet config is tracked right away, so that it's still deleted if a later step fails.
+		addManagedNodePoolConfig(managed, kubeletConfig.Name)
+	}
+
+	for _, name := range removedNodePoolConfigs(*managed, desiredNames) {
+		if existing, found := existingKubeletConfigs[name]; found {
+			rosaScope.Info("deleting kubelet config", "name", name)
+			if err := kubeletConfigClient.DeleteKubeletConfig(cluster.ID(), existing.ID()); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete kubelet config %q: %w", name, err))
+				continue
+			}
+		}
+		removeManagedNodePoolConfig(managed, name)
+	}
+
+	return kerrors.NewAggregate(errs)
+}
+
+// removedNodePoolConfigs returns the names of the managed configs which are no longer desired.
+func removedNodePoolConfigs(managed []string, desiredNames []string) []string {
+	removed := []string{}
+	for _, name := range managed {
+		if !slices.Contains(desiredNames, name) {
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
+func addManagedNodePoolConfig(managed *[]string, name string) {
+	if !slices.Contains(*managed, name) {
+		*managed = append(*managed, name)
+	}
+}
+
+func removeManagedNodePoolConfig(managed *[]string, name string) {
+	*managed = slices.DeleteFunc(*managed, func(managed string) bool {
+		return managed == name
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/helpers/fakeocm"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileNodePoolConfigs(t *testing.T) {
+	g := NewWithT(t)
+
+	ocmServer := fakeocm.NewServer()
+	defer ocmServer.Close()
+
+	credentialsSecret := ocmServer.CredentialsSecret("ocm-credentials", "default")
+	rosaScope := &scope.ROSAControlPlaneScope{
+		Logger:  *logger.FromContext(ctx),
+		Client:  fake.NewClientBuilder().WithObjects(credentialsSecret).Build(),
+		Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}},
+		ControlPlane: &rosacontrolplanev1.ROSAControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa-control-plane", Namespace: "default"},
+			Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecret.Name},
+			},
+		},
+	}
+	ocmClient, err := rosa.NewOCMClient(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer ocmClient.Close()
+	kubeletConfigClient, err := rosa.NewKubeletConfigClient(ctx, rosaScope)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer kubeletConfigClient.Close()
+
+	cluster, err := ocmServer.AddCluster(mustBuildCluster(g, cmv1.NewCluster().Name("capa-rosa")))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// configs created outside of CAPA.
+	externalTuningConfig, err := cmv1.NewTuningConfig().Name("external").Spec(map[string]interface{}{"profile": "external"}).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = ocmClient.CreateTuningConfig(cluster.ID(), externalTuningConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	externalKubeletConfig, err := cmv1.NewKubeletConfig().Name("external").PodPidsLimit(4096).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = kubeletConfigClient.CreateKubeletConfig(cluster.ID(), externalKubeletConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	tuningConfigs := func() map[string]interface{} {
+		tuningConfigs, err := ocmClient.GetTuningConfigs(cluster.ID())
+		g.Expect(err).ToNot(HaveOccurred())
+		specs := map[string]interface{}{}
+		for _, tuningConfig := range tuningConfigs {
+			specs[tuningConfig.Name()] = tuningConfig.Spec()
+		}
+		return specs
+	}
+	kubeletConfigs := func() map[string]int {
+		kubeletConfigs, err := kubeletConfigClient.ListKubeletConfigs(cluster.ID())
+		g.Expect(err).ToNot(HaveOccurred())
+		limits := map[string]int{}
+		for _, kubeletConfig := range kubeletConfigs {
+			limits[kubeletConfig.Name()] = kubeletConfig.PodPidsLimit()
+		}
+		return limits
+	}
+
+	r := &ROSAControlPlaneReconciler{}
+
+	// configs are created, while configs created outside of CAPA aren't adopted and invalid configs fail.
+	rosaScope.ControlPlane.Spec.TuningConfigs = []rosacontrolplanev1.TuningConfig{
+		{Name: "tuned", Spec: runtime.RawExtension{Raw: []byte(`{"profile":"tuned"}`)}},
+		{Name: "external", Spec: runtime.RawExtension{Raw: []byte(`{"profile":"adopted"}`)}},
+		{Name: "invalid", Spec: runtime.RawExtension{Raw: []byte(`{`)}},
+	}
+	rosaScope.ControlPlane.Spec.KubeletConfigs = []rosacontrolplanev1.KubeletConfig{
+		{Name: "pids", PodPidsLimit: 8192},
+		{Name: "external", PodPidsLimit: 16384},
+	}
+	err = r.reconcileNodePoolConfigs(ctx, rosaScope, ocmClient, cluster)
+	g.Expect(err).To(MatchError(And(
+		ContainSubstring(`tuning config "external" already exists`),
+		ContainSubstring(`invalid spec of tuning config "invalid"`),
+		ContainSubstring(`kubelet config "external" already exists`),
+	)))
+	g.Expect(conditions.IsFalse(rosaScope.ControlPlane, rosacontrolplanev1.NodePoolConfigsReadyCondition)).To(BeTrue())
+	g.Expect(rosaScope.ControlPlane.Status.TuningConfigs).To(ConsistOf("tuned"))
+	g.Expect(rosaScope.ControlPlane.Status.KubeletConfigs).To(ConsistOf("pids"))
+	g.Expect(tuningConfigs()).To(Equal(map[string]interface{}{
+		"external": map[string]interface{}{"profile": "external"},
+		"tuned":    map[string]interface{}{"profile": "tuned"},
+	}))
+	g.Expect(kubeletConfigs()).To(Equal(map[string]int{"external": 4096, "pids": 8192}))
+
+	// configs are updated.
+	rosaScope.ControlPlane.Spec.TuningConfigs = []rosacontrolplanev1.TuningConfig{
+		{Name: "tuned", Spec: runtime.RawExtension{Raw: []byte(`{"profile":"updated"}`)}},
+	}
+	rosaScope.ControlPlane.Spec.KubeletConfigs = []rosacontrolplanev1.KubeletConfig{
+		{Name: "pids", PodPidsLimit: 12288},
+	}
+	g.Expect(r.reconcileNodePoolConfigs(ctx, rosaScope, ocmClient, cluster)).To(Succeed())
+	g.Expect(conditions.IsTrue(rosaScope.ControlPlane, rosacontrolplanev1.NodePoolConfigsReadyCondition)).To(BeTrue())
+	g.Expect(tuningConfigs()).To(HaveKeyWithValue("tuned", map[string]interface{}{"profile": "updated"}))
+	g.Expect(kubeletConfigs()).To(HaveKeyWithValue("pids", 12288))
+
+	// configs are deleted, configs created outside of CAPA are left alone.
+	rosaScope.ControlPlane.Spec.TuningConfigs = nil
+	rosaScope.ControlPlane.Spec.KubeletConfigs = nil
+	g.Expect(r.reconcileNodePoolConfigs(ctx, rosaScope, ocmClient, cluster)).To(Succeed())
+	g.Expect(rosaScope.ControlPlane.Status.TuningConfigs).To(BeEmpty())
+	g.Expect(rosaScope.ControlPlane.Status.KubeletConfigs).To(BeEmpty())
+	g.Expect(tuningConfigs()).To(Equal(map[string]interface{}{
+		"external": map[string]interface{}{"profile": "external"},
+	}))
+	g.Expect(kubeletConfigs()).To(Equal(map[string]int{"external": 4096}))
+}
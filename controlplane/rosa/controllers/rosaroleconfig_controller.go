@@ -0,0 +1,585 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	awsCommonValidations "github.com/openshift-online/ocm-common/pkg/aws/validations"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rosaaws "github.com/openshift/rosa/pkg/aws"
+	rosatags "github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/zgalor/weberr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	iamv1 "sigs.k8s.io/cluster-api-provider-aws/v2/iam/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
+	cloudconverters "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	eksiam "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capiannotations "sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+const (
+	rosaRoleConfigKind = "ROSARoleConfig"
+	// ROSARoleConfigFinalizer allows the controller to clean up the roles and the OIDC config on delete.
+	ROSARoleConfigFinalizer = "rosaroleconfig.controlplane.cluster.x-k8s.io"
+
+	// installerTrustedRoleName is the role of the Red Hat account that assumes the installer account role.
+	installerTrustedRoleName = "RH-Managed-OpenShift-Installer"
+	// supportTrustedRoleName is the role of the Red Hat account that assumes the support account role.
+	supportTrustedRoleName = "RH-Technical-Support-Access"
+)
+
+// ROSARoleConfigReconciler reconciles a ROSARoleConfig object.
+type ROSARoleConfigReconciler struct {
+	client.Client
+	WatchFilterValue string
+	Endpoints        []scope.ServiceEndpoint
+}
+
+// SetupWithManager is used to setup the controller.
+func (r *ROSARoleConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := logger.FromContext(ctx)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rosacontrolplanev1.ROSARoleConfig{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log.GetLogger(), r.WatchFilterValue)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosaroleconfigs,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosaroleconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=rosaroleconfigs/finalizers,verbs=update
+
+// Reconcile will reconcile ROSARoleConfig Resources.
+func (r *ROSARoleConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res ctrl.Result, reterr error) {
+	log := logger.FromContext(ctx)
+
+	roleConfig := &rosacontrolplanev1.ROSARoleConfig{}
+	if err := r.Client.Get(ctx, req.NamespacedName, roleConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if capiannotations.HasPaused(roleConfig) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	roleConfigScope, err := scope.NewRosaRoleConfigScope(scope.RosaRoleConfigScopeParams{
+		Client:         r.Client,
+		RoleConfig:     roleConfig,
+		ControllerName: strings.ToLower(rosaRoleConfigKind),
+		Endpoints:      r.Endpoints,
+		Logger:         log,
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create scope: %w", err)
+	}
+
+	// Always close the scope
+	defer func() {
+		if err := roleConfigScope.Close(); err != nil {
+			reterr = errors.Join(reterr, err)
+		}
+	}()
+
+	if !roleConfig.ObjectMeta.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, roleConfigScope)
+	}
+
+	return r.reconcileNormal(ctx, roleConfigScope)
+}
+
+func (r *ROSARoleConfigReconciler) reconcileNormal(ctx context.Context, roleConfigScope *scope.RosaRoleConfigScope) (ctrl.Result, error) {
+	roleConfigScope.Info("Reconciling ROSARoleConfig")
+
+	roleConfig := roleConfigScope.RoleConfig
+	if controllerutil.AddFinalizer(roleConfig, ROSARoleConfigFinalizer) {
+		if err := roleConfigScope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.reconcileRoles(ctx, roleConfigScope); err != nil {
+		conditions.MarkFalse(roleConfig,
+			rosacontrolplanev1.ROSARoleConfigReadyCondition,
+			rosacontrolplanev1.ReconciliationFailedReason,
+			clusterv1.ConditionSeverityError,
+			err.Error())
+		return ctrl.Result{}, err
+	}
+
+	conditions.MarkTrue(roleConfig, rosacontrolplanev1.ROSARoleConfigReadyCondition)
+	roleConfig.Status.Ready = true
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ROSARoleConfigReconciler) reconcileRoles(ctx context.Context, roleConfigScope *scope.RosaRoleConfigScope) error {
+	roleConfig := roleConfigScope.RoleConfig
+
+	ocmClient, err := rosa.NewOCMClient(ctx, roleConfigScope)
+	if err != nil {
+		return fmt.Errorf("failed to create OCM client: %w", err)
+	}
+	defer ocmClient.Close()
+
+	callerARN, err := arn.Parse(aws.StringValue(roleConfigScope.Identity.Arn))
+	if err != nil {
+		return fmt.Errorf("failed to parse the ARN of the AWS caller: %w", err)
+	}
+
+	policies, err := ocmClient.GetPolicies("")
+	if err != nil {
+		return fmt.Errorf("failed to get the managed policies: %w", err)
+	}
+
+	iamService := newRoleConfigIAMService(roleConfigScope)
+
+	accountRoles := accountRoleDefinitions(callerARN.Partition, jumpAccountID(ocmClient.GetConnectionURL()))
+	for _, roleType := range sortedKeys(accountRoles) {
+		roleName := awsCommonValidations.GetRoleName(roleConfig.Spec.AccountRoleConfig.Prefix, rosaaws.HCPAccountRoles[roleType].Name)
+		policyARN, err := rosaaws.GetManagedPolicyARN(policies, fmt.Sprintf("sts_hcp_%s_permission_policy", roleType))
+		if err != nil {
+			return err
+		}
+
+		tags := roleConfigTags(roleConfig.Spec.AdditionalTags, map[string]string{
+			rosatags.RolePrefix: roleConfig.Spec.AccountRoleConfig.Prefix,
+			rosatags.RoleType:   roleType,
+		})
+		roleARN, err := ensureRole(iamService, roleConfig, roleName, accountRoles[roleType], tags, policyARN)
+		if err != nil {
+			return err
+		}
+
+		switch roleType {
+		case rosaaws.HCPInstallerRole:
+			roleConfig.Status.AccountRolesRef.InstallerRoleARN = roleARN
+		case rosaaws.HCPSupportRole:
+			roleConfig.Status.AccountRolesRef.SupportRoleARN = roleARN
+		case rosaaws.HCPWorkerRole:
+			roleConfig.Status.AccountRolesRef.WorkerRoleARN = roleARN
+		}
+	}
+
+	issuerURL, err := reconcileOIDCConfig(ocmClient, roleConfig)
+	if err != nil {
+		return err
+	}
+
+	if roleConfig.Status.OIDCProviderARN == "" {
+		providerARN, err := iamService.FindAndVerifyOIDCProviderForIssuer(issuerURL)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile OIDC provider: %w", err)
+		}
+		if providerARN == "" {
+			providerARN, err = iamService.CreateOIDCProviderForIssuer(issuerURL)
+			if err != nil {
+				return fmt.Errorf("failed to create OIDC provider: %w", err)
+			}
+			record.Eventf(roleConfig, "SuccessfulOIDCProviderCreation", "Created OIDC provider %q", providerARN)
+		}
+		roleConfig.Status.OIDCProviderARN = providerARN
+	}
+
+	credRequests, err := ocmClient.GetCredRequests(true)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator credential requests: %w", err)
+	}
+
+	for _, credRequest := range sortedKeys(credRequests) {
+		operator := credRequests[credRequest]
+		roleName := operatorRoleName(roleConfig.Spec.OperatorRoleConfig.Prefix, operator)
+		policyARN, err := rosaaws.GetManagedPolicyARN(policies, rosaaws.GetOperatorPolicyKey(credRequest, true, false))
+		if err != nil {
+			return err
+		}
+
+		tags := roleConfigTags(roleConfig.Spec.AdditionalTags, map[string]string{
+			rosatags.RolePrefix:        roleConfig.Spec.OperatorRoleConfig.Prefix,
+			rosatags.OperatorNamespace: operator.Namespace(),
+			rosatags.OperatorName:      operator.Name(),
+		})
+		trustPolicy := operatorRoleTrustPolicy(roleConfig.Status.OIDCProviderARN, issuerURL, operator)
+		roleARN, err := ensureRole(iamService, roleConfig, roleName, trustPolicy, tags, policyARN)
+		if err != nil {
+			return err
+		}
+
+		if field := operatorRoleARNField(&roleConfig.Status.OperatorRolesRef, operator); field != nil {
+			*field = roleARN
+		} else {
+			roleConfigScope.Info("operator role is not referenced by ROSAControlPlanes", "role", roleName)
+		}
+	}
+
+	return nil
+}
+
+// reconcileOIDCConfig creates the managed OIDC config if it doesn't exist yet and returns its issuer URL.
+func reconcileOIDCConfig(ocmClient *ocm.Client, roleConfig *rosacontrolplanev1.ROSARoleConfig) (string, error) {
+	if roleConfig.Status.OIDCID != "" {
+		oidcConfig, err := ocmClient.GetOidcConfig(roleConfig.Status.OIDCID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get OIDC config %q: %w", roleConfig.Status.OIDCID, err)
+		}
+		return oidcConfig.IssuerUrl(), nil
+	}
+
+	oidcConfig, err := cmv1.NewOidcConfig().Managed(true).Build()
+	if err != nil {
+		return "", fmt.Errorf("failed to build OIDC config: %w", err)
+	}
+	oidcConfig, err = ocmClient.CreateOidcConfig(oidcConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to create OIDC config: %w", err)
+	}
+	record.Eventf(roleConfig, "SuccessfulOIDCConfigCreation", "Created OIDC config %q", oidcConfig.ID())
+	roleConfig.Status.OIDCID = oidcConfig.ID()
+
+	return oidcConfig.IssuerUrl(), nil
+}
+
+func (r *ROSARoleConfigReconciler) reconcileDelete(ctx context.Context, roleConfigScope *scope.RosaRoleConfigScope) (ctrl.Result, error) {
+	roleConfigScope.Info("Reconciling ROSARoleConfig delete")
+
+	roleConfig := roleConfigScope.RoleConfig
+
+	controlPlanes := &rosacontrolplanev1.ROSAControlPlaneList{}
+	if err := r.Client.List(ctx, controlPlanes, client.InNamespace(roleConfig.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list ROSAControlPlanes: %w", err)
+	}
+	for _, controlPlane := range controlPlanes.Items {
+		if controlPlane.Spec.RosaRoleConfigRef != nil && controlPlane.Spec.RosaRoleConfigRef.Name == roleConfig.Name {
+			conditions.MarkFalse(roleConfig,
+				rosacontrolplanev1.ROSARoleConfigReadyCondition,
+				rosacontrolplanev1.ROSARoleConfigInUseReason,
+				clusterv1.ConditionSeverityWarning,
+				"ROSAControlPlane %q references the ROSARoleConfig", controlPlane.Name)
+			roleConfigScope.Info("waiting for ROSAControlPlanes referencing the ROSARoleConfig to be deleted", "controlPlane", controlPlane.Name)
+			return ctrl.Result{RequeueAfter: time.Second * 30}, nil
+		}
+	}
+
+	conditions.MarkFalse(roleConfig, rosacontrolplanev1.ROSARoleConfigReadyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	roleConfig.Status.Ready = false
+
+	ocmClient, err := rosa.NewOCMClient(ctx, roleConfigScope)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create OCM client: %w", err)
+	}
+	defer ocmClient.Close()
+
+	iamService := newRoleConfigIAMService(roleConfigScope)
+
+	credRequests, err := ocmClient.GetCredRequests(true)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get the operator credential requests: %w", err)
+	}
+	for _, operator := range credRequests {
+		if err := deleteRole(iamService, roleConfig, operatorRoleName(roleConfig.Spec.OperatorRoleConfig.Prefix, operator)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	roleConfig.Status.OperatorRolesRef = rosacontrolplanev1.AWSRolesRef{}
+
+	if roleConfig.Status.OIDCProviderARN != "" {
+		if err := iamService.DeleteOIDCProvider(aws.String(roleConfig.Status.OIDCProviderARN)); err != nil && !isIAMNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		record.Eventf(roleConfig, "SuccessfulOIDCProviderDeletion", "Deleted OIDC provider %q", roleConfig.Status.OIDCProviderARN)
+		roleConfig.Status.OIDCProviderARN = ""
+	}
+
+	if roleConfig.Status.OIDCID != "" {
+		if err := ocmClient.DeleteOidcConfig(roleConfig.Status.OIDCID); err != nil && weberr.GetType(err) != weberr.NotFound {
+			return ctrl.Result{}, fmt.Errorf("failed to delete OIDC config %q: %w", roleConfig.Status.OIDCID, err)
+		}
+		record.Eventf(roleConfig, "SuccessfulOIDCConfigDeletion", "Deleted OIDC config %q", roleConfig.Status.OIDCID)
+		roleConfig.Status.OIDCID = ""
+	}
+
+	for _, role := range rosaaws.HCPAccountRoles {
+		if err := deleteRole(iamService, roleConfig, awsCommonValidations.GetRoleName(roleConfig.Spec.AccountRoleConfig.Prefix, role.Name)); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	roleConfig.Status.AccountRolesRef = rosacontrolplanev1.AccountRolesRef{}
+
+	controllerutil.RemoveFinalizer(roleConfig, ROSARoleConfigFinalizer)
+
+	return ctrl.Result{}, nil
+}
+
+func newRoleConfigIAMService(roleConfigScope *scope.RosaRoleConfigScope) *eksiam.IAMService {
+	return &eksiam.IAMService{
+		Wrapper:   &roleConfigScope.Logger,
+		IAMClient: scope.NewIAMClient(roleConfigScope, roleConfigScope, roleConfigScope, roleConfigScope.RoleConfig),
+		Client:    http.DefaultClient,
+	}
+}
+
+// ensureRole creates the role if it doesn't exist, and ensures the trust policy, the tags and the permission policy
+// of the role when it's managed by the ROSARoleConfig. It returns the ARN of the role.
+func ensureRole(iamService *eksiam.IAMService, roleConfig *rosacontrolplanev1.ROSARoleConfig, roleName string, trustPolicy *iamv1.PolicyDocument, tags infrav1.Tags, policyARN string) (string, error) {
+	tags = tags.DeepCopy()
+	tags[infrav1.RosaRoleConfigOwnerTag] = roleOwner(roleConfig)
+
+	role, err := iamService.GetIAMRole(roleName)
+	if err != nil {
+		if !isIAMNotFound(err) {
+			return "", err
+		}
+
+		role, err = createRole(iamService, roleName, trustPolicy, tags)
+		if err != nil {
+			record.Warnf(roleConfig, "FailedIAMRoleCreation", "Failed to create IAM role %q: %v", roleName, err)
+			return "", err
+		}
+		record.Eventf(roleConfig, "SuccessfulIAMRoleCreation", "Created IAM role %q", roleName)
+	}
+
+	if !isRoleOwned(role, roleConfig) {
+		iamService.Debug("Skipping, IAM role is unmanaged", "role", roleName)
+		return aws.StringValue(role.Arn), nil
+	}
+
+	// the owner tag is part of the tags, the roles have no cluster tag to keep.
+	if _, err := iamService.EnsureTagsAndPolicy(role, "", trustPolicy, tags); err != nil {
+		return "", fmt.Errorf("error ensuring tags and policy document are set on role %q: %w", roleName, err)
+	}
+
+	if _, err := iamService.EnsurePoliciesAttached(role, aws.StringSlice([]string{policyARN})); err != nil {
+		return "", fmt.Errorf("error ensuring policy %q is attached to role %q: %w", policyARN, roleName, err)
+	}
+
+	return aws.StringValue(role.Arn), nil
+}
+
+// deleteRole deletes the role when it's managed by the ROSARoleConfig.
+func deleteRole(iamService *eksiam.IAMService, roleConfig *rosacontrolplanev1.ROSARoleConfig, roleName string) error {
+	role, err := iamService.GetIAMRole(roleName)
+	if err != nil {
+		if isIAMNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if !isRoleOwned(role, roleConfig) {
+		iamService.Debug("Skipping, IAM role deletion as role is unmanaged", "role", roleName)
+		return nil
+	}
+
+	if err := iamService.DeleteRole(roleName); err != nil {
+		record.Warnf(roleConfig, "FailedIAMRoleDeletion", "Failed to delete IAM role %q: %v", roleName, err)
+		return err
+	}
+	record.Eventf(roleConfig, "SuccessfulIAMRoleDeletion", "Deleted IAM role %q", roleName)
+
+	return nil
+}
+
+// createRole creates the role with the given tags. Unlike IAMService.CreateRole, it doesn't tag the role with the
+// cloud provider tag of a cluster, as the roles created by a ROSARoleConfig are identified by the owner tag.
+func createRole(iamService *eksiam.IAMService, roleName string, trustPolicy *iamv1.PolicyDocument, tags infrav1.Tags) (*iam.Role, error) {
+	trustPolicyJSON, err := converters.IAMPolicyDocumentToJSON(*trustPolicy)
+	if err != nil {
+		return nil, fmt.Errorf("error converting trust policy to json: %w", err)
+	}
+
+	out, err := iamService.IAMClient.CreateRole(&iam.CreateRoleInput{
+		RoleName:                 aws.String(roleName),
+		AssumeRolePolicyDocument: aws.String(trustPolicyJSON),
+		Tags:                     cloudconverters.MapToIAMTags(tags),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to call CreateRole: %w", err)
+	}
+
+	return out.Role, nil
+}
+
+// roleOwner returns the value of the owner tag of the roles created by the ROSARoleConfig. It's the namespace and name
+// rather than the name only, as ROSARoleConfigs with the same name in different namespaces may create roles in the same
+// AWS account.
+func roleOwner(roleConfig *rosacontrolplanev1.ROSARoleConfig) string {
+	return roleConfig.Namespace + "/" + roleConfig.Name
+}
+
+// isRoleOwned returns true if the role was created by the ROSARoleConfig.
+func isRoleOwned(role *iam.Role, roleConfig *rosacontrolplanev1.ROSARoleConfig) bool {
+	for _, tag := range role.Tags {
+		if aws.StringValue(tag.Key) == infrav1.RosaRoleConfigOwnerTag {
+			return aws.StringValue(tag.Value) == roleOwner(roleConfig)
+		}
+	}
+	return false
+}
+
+func isIAMNotFound(err error) bool {
+	code, ok := awserrors.Code(err)
+	return ok && code == iam.ErrCodeNoSuchEntityException
+}
+
+// roleConfigTags returns the tags identifying the roles as ROSA roles with managed policies, merged with the
+// additional tags of the ROSARoleConfig.
+func roleConfigTags(additionalTags infrav1.Tags, roleTags map[string]string) infrav1.Tags {
+	tags := additionalTags.DeepCopy()
+	if tags == nil {
+		tags = infrav1.Tags{}
+	}
+	tags[rosatags.RedHatManaged] = rosatags.True
+	tags[rosatags.HypershiftPolicies] = rosatags.True
+	tags[awsCommonValidations.ManagedPolicies] = rosatags.True
+	for key, value := range roleTags {
+		tags[key] = value
+	}
+
+	return tags
+}
+
+// jumpAccountID returns the ID of the Red Hat AWS account assuming the installer and support roles for the
+// OpenShift Cluster Manager environment at the given URL.
+func jumpAccountID(ocmURL string) string {
+	for env, url := range ocm.URLAliases {
+		if url == strings.TrimSuffix(ocmURL, "/") {
+			if accountID, ok := rosaaws.JumpAccounts[env]; ok {
+				return accountID
+			}
+		}
+	}
+
+	return rosaaws.JumpAccounts[ocm.Production]
+}
+
+// accountRoleDefinitions returns the trust policies of the HCP account roles, by role type.
+func accountRoleDefinitions(partition, jumpAccountID string) map[string]*iamv1.PolicyDocument {
+	return map[string]*iamv1.PolicyDocument{
+		rosaaws.HCPInstallerRole: assumeRolePolicy(iamv1.PrincipalAWS, fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, jumpAccountID, installerTrustedRoleName)),
+		rosaaws.HCPSupportRole:   assumeRolePolicy(iamv1.PrincipalAWS, fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, jumpAccountID, supportTrustedRoleName)),
+		rosaaws.HCPWorkerRole:    eksiam.NodegroupTrustRelationship(),
+	}
+}
+
+func assumeRolePolicy(principalType iamv1.PrincipalType, principal string) *iamv1.PolicyDocument {
+	return &iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: iamv1.Statements{
+			{
+				Effect:    iamv1.EffectAllow,
+				Action:    iamv1.Actions{"sts:AssumeRole"},
+				Principal: iamv1.Principals{principalType: iamv1.PrincipalID{principal}},
+			},
+		},
+	}
+}
+
+// operatorRoleName returns the name of the role of an operator, as named by the rosa CLI.
+func operatorRoleName(prefix string, operator *cmv1.STSOperator) string {
+	return awsCommonUtils.TruncateRoleName(fmt.Sprintf("%s-%s-%s", prefix, operator.Namespace(), operator.Name()))
+}
+
+// operatorRoleTrustPolicy returns the trust policy allowing the service accounts of an operator to assume its role
+// with tokens issued by the OIDC config.
+func operatorRoleTrustPolicy(providerARN, issuerURL string, operator *cmv1.STSOperator) *iamv1.PolicyDocument {
+	serviceAccounts := make([]string, 0, len(operator.ServiceAccounts()))
+	for _, serviceAccount := range operator.ServiceAccounts() {
+		serviceAccounts = append(serviceAccounts, fmt.Sprintf("system:serviceaccount:%s:%s", operator.Namespace(), serviceAccount))
+	}
+
+	return &iamv1.PolicyDocument{
+		Version: iamv1.CurrentVersion,
+		Statement: iamv1.Statements{
+			{
+				Effect:    iamv1.EffectAllow,
+				Action:    iamv1.Actions{"sts:AssumeRoleWithWebIdentity"},
+				Principal: iamv1.Principals{iamv1.PrincipalFederated: iamv1.PrincipalID{providerARN}},
+				Condition: iamv1.Conditions{
+					iamv1.StringEquals: map[string][]string{
+						strings.TrimPrefix(issuerURL, "https://") + ":sub": serviceAccounts,
+					},
+				},
+			},
+		},
+	}
+}
+
+// operatorRoleARNField returns the field of the AWSRolesRef holding the role of the operator, or nil if the
+// operator isn't known.
+func operatorRoleARNField(rolesRef *rosacontrolplanev1.AWSRolesRef, operator *cmv1.STSOperator) *string {
+	switch operator.Namespace() + "/" + operator.Name() {
+	case "openshift-ingress-operator/cloud-credentials":
+		return &rolesRef.IngressARN
+	case "openshift-image-registry/installer-cloud-credentials":
+		return &rolesRef.ImageRegistryARN
+	case "openshift-cluster-csi-drivers/ebs-cloud-credentials":
+		return &rolesRef.StorageARN
+	case "openshift-cloud-network-config-controller/cloud-credentials":
+		return &rolesRef.NetworkARN
+	case "kube-system/kube-controller-manager":
+		return &rolesRef.KubeCloudControllerARN
+	case "kube-system/kms-provider":
+		return &rolesRef.KMSProviderARN
+	case "kube-system/control-plane-operator":
+		return &rolesRef.ControlPlaneOperatorARN
+	case "kube-system/capa-controller-manager":
+		return &rolesRef.NodePoolManagementARN
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rosaaws "github.com/openshift/rosa/pkg/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/cmd/clusterawsadm/converters"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+)
+
+func TestAccountRoleDefinitions(t *testing.T) {
+	g := NewWithT(t)
+
+	roles := accountRoleDefinitions("aws", "710019948333")
+	g.Expect(roles).To(HaveLen(3))
+
+	installer, err := converters.IAMPolicyDocumentToJSON(*roles[rosaaws.HCPInstallerRole])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(installer).To(MatchJSON(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Action": ["sts:AssumeRole"],
+			"Principal": {"AWS": ["arn:aws:iam::710019948333:role/RH-Managed-OpenShift-Installer"]}
+		}]
+	}`))
+
+	support, err := converters.IAMPolicyDocumentToJSON(*roles[rosaaws.HCPSupportRole])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(support).To(ContainSubstring("arn:aws:iam::710019948333:role/RH-Technical-Support-Access"))
+
+	worker, err := converters.IAMPolicyDocumentToJSON(*roles[rosaaws.HCPWorkerRole])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(worker).To(ContainSubstring("ec2.amazonaws.com"))
+}
+
+func TestJumpAccountID(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(jumpAccountID("https://api.openshift.com")).To(Equal("710019948333"))
+	g.Expect(jumpAccountID("https://api.stage.openshift.com/")).To(Equal("644306948063"))
+	g.Expect(jumpAccountID("https://ocm.example.com")).To(Equal("710019948333"))
+}
+
+func TestOperatorRoles(t *testing.T) {
+	g := NewWithT(t)
+
+	operator, err := cmv1.NewSTSOperator().
+		Namespace("openshift-ingress-operator").
+		Name("cloud-credentials").
+		ServiceAccounts("ingress-operator").
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(operatorRoleName("capa", operator)).To(Equal("capa-openshift-ingress-operator-cloud-credentials"))
+
+	trustPolicy, err := converters.IAMPolicyDocumentToJSON(*operatorRoleTrustPolicy(
+		"arn:aws:iam::123456789012:oidc-provider/oidc.os1.devshift.org/2a3b4c",
+		"https://oidc.os1.devshift.org/2a3b4c",
+		operator))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(trustPolicy).To(MatchJSON(`{
+		"Version": "2012-10-17",
+		"Statement": [{
+			"Effect": "Allow",
+			"Action": ["sts:AssumeRoleWithWebIdentity"],
+			"Principal": {"Federated": ["arn:aws:iam::123456789012:oidc-provider/oidc.os1.devshift.org/2a3b4c"]},
+			"Condition": {
+				"StringEquals": {
+					"oidc.os1.devshift.org/2a3b4c:sub": ["system:serviceaccount:openshift-ingress-operator:ingress-operator"]
+				}
+			}
+		}]
+	}`))
+
+	rolesRef := rosacontrolplanev1.AWSRolesRef{}
+	field := operatorRoleARNField(&rolesRef, operator)
+	g.Expect(field).ToNot(BeNil())
+	*field = "arn:aws:iam::123456789012:role/capa-openshift-ingress-operator-cloud-credentials"
+	g.Expect(rolesRef.IngressARN).To(Equal("arn:aws:iam::123456789012:role/capa-openshift-ingress-operator-cloud-credentials"))
+
+	unknown, err := cmv1.NewSTSOperator().Namespace("openshift-unknown").Name("cloud-credentials").Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(operatorRoleARNField(&rolesRef, unknown)).To(BeNil())
+}
+
+func TestROSARoleConfigReconcileGetError(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &ROSARoleConfigReconciler{
+		Client: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+				return errors.New("connection refused")
+			},
+		}).Build(),
+	}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "role-config"}})
+	g.Expect(err).To(MatchError("connection refused"))
+}
+
+func TestIsRoleOwned(t *testing.T) {
+	g := NewWithT(t)
+
+	roleConfig := &rosacontrolplanev1.ROSARoleConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-config", Namespace: "team-a"},
+	}
+	otherRoleConfig := &rosacontrolplanev1.ROSARoleConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "role-config", Namespace: "team-b"},
+	}
+	role := &iam.Role{
+		Tags: []*iam.Tag{{
+			Key:   aws.String(infrav1.RosaRoleConfigOwnerTag),
+			Value: aws.String("team-a/role-config"),
+		}},
+	}
+
+	g.Expect(isRoleOwned(role, roleConfig)).To(BeTrue())
+	g.Expect(isRoleOwned(role, otherRoleConfig)).To(BeFalse())
+	// roles created outside of CAPA aren't owned.
+	g.Expect(isRoleOwned(&iam.Role{}, roleConfig)).To(BeFalse())
+}
@@ -55,6 +55,8 @@ func (src *AWSMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 	}
 
+	restoreAWSLaunchTemplate(&restored.Spec.AWSLaunchTemplate, &dst.Spec.AWSLaunchTemplate)
+
 	dst.Spec.DefaultInstanceWarmup = restored.Spec.DefaultInstanceWarmup
 
 	return nil
@@ -105,10 +107,16 @@ func (src *AWSManagedMachinePool) ConvertTo(dstRaw conversion.Hub) error {
 		if restored.Spec.AWSLaunchTemplate.PrivateDNSName != nil {
 			dst.Spec.AWSLaunchTemplate.PrivateDNSName = restored.Spec.AWSLaunchTemplate.PrivateDNSName
 		}
+
+		restoreAWSLaunchTemplate(restored.Spec.AWSLaunchTemplate, dst.Spec.AWSLaunchTemplate)
 	}
 	if restored.Spec.AvailabilityZoneSubnetType != nil {
 		dst.Spec.AvailabilityZoneSubnetType = restored.Spec.AvailabilityZoneSubnetType
 	}
+	if restored.Spec.UpdateConfig != nil && dst.Spec.UpdateConfig != nil {
+		dst.Spec.UpdateConfig.UpdateStrategy = restored.Spec.UpdateConfig.UpdateStrategy
+	}
+	dst.Spec.NodeRepairConfig = restored.Spec.NodeRepairConfig
 
 	return nil
 }
@@ -145,14 +153,29 @@ func (r *AWSManagedMachinePoolList) ConvertFrom(srcRaw conversion.Hub) error {
 // ConvertTo converts the v1beta1 AWSFargateProfile receiver to a v1beta2 AWSFargateProfile.
 func (src *AWSFargateProfile) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1exp.AWSFargateProfile)
-	return Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil)
+	if err := Convert_v1beta1_AWSFargateProfile_To_v1beta2_AWSFargateProfile(src, dst, nil); err != nil {
+		return err
+	}
+	// Manually restore data.
+	restored := &infrav1exp.AWSFargateProfile{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Status.ActiveProfileName = restored.Status.ActiveProfileName
+
+	return nil
 }
 
 // ConvertFrom converts the v1beta2 AWSFargateProfile receiver to v1beta1 AWSFargateProfile.
 func (r *AWSFargateProfile) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1exp.AWSFargateProfile)
 
-	return Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil)
+	if err := Convert_v1beta2_AWSFargateProfile_To_v1beta1_AWSFargateProfile(src, r, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalData(src, r)
 }
 
 // ConvertTo converts the v1beta1 AWSFargateProfileList receiver to a v1beta2 AWSFargateProfileList.
@@ -193,6 +216,26 @@ func Convert_v1beta2_AWSLaunchTemplate_To_v1beta1_AWSLaunchTemplate(in *infrav1e
 	return autoConvert_v1beta2_AWSLaunchTemplate_To_v1beta1_AWSLaunchTemplate(in, out, s)
 }
 
+// Convert_v1beta2_UpdateConfig_To_v1beta1_UpdateConfig converts the v1beta2 UpdateConfig receiver to a v1beta1 UpdateConfig.
+func Convert_v1beta2_UpdateConfig_To_v1beta1_UpdateConfig(in *infrav1exp.UpdateConfig, out *UpdateConfig, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_UpdateConfig_To_v1beta1_UpdateConfig(in, out, s)
+}
+
+// Convert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus converts the v1beta2 FargateProfileStatus receiver to a v1beta1 FargateProfileStatus.
+func Convert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus(in *infrav1exp.FargateProfileStatus, out *FargateProfileStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta2_FargateProfileStatus_To_v1beta1_FargateProfileStatus(in, out, s)
+}
+
+// restoreAWSLaunchTemplate restores the AWSLaunchTemplate fields that don't exist in v1beta1.
+func restoreAWSLaunchTemplate(restored, dst *infrav1exp.AWSLaunchTemplate) {
+	dst.NonRootVolumes = restored.NonRootVolumes
+	dst.PlacementGroupName = restored.PlacementGroupName
+	dst.PlacementGroupPartition = restored.PlacementGroupPartition
+	dst.Tenancy = restored.Tenancy
+	dst.CapacityReservationID = restored.CapacityReservationID
+	dst.NetworkInterfaces = restored.NetworkInterfaces
+}
+
 func Convert_v1beta1_AWSMachinePoolSpec_To_v1beta2_AWSMachinePoolSpec(in *AWSMachinePoolSpec, out *infrav1exp.AWSMachinePoolSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_AWSMachinePoolSpec_To_v1beta2_AWSMachinePoolSpec(in, out, s)
 }
@@ -45,6 +45,9 @@ type FargateProfileSpec struct {
 
 	// SubnetIDs specifies which subnets are used for the
 	// auto scaling group of this nodegroup.
+	// Changing the subnets replaces the EKS fargate profile.
+	// If empty, the private subnets of the control plane are used when the profile is
+	// created, and later changes to them don't replace the profile.
 	// +optional
 	SubnetIDs []string `json:"subnetIDs,omitempty"`
 
@@ -61,6 +64,7 @@ type FargateProfileSpec struct {
 	RoleName string `json:"roleName,omitempty"`
 
 	// Selectors specify fargate pod selectors.
+	// Changing the selectors replaces the EKS fargate profile.
 	Selectors []FargateSelector `json:"selectors,omitempty"`
 }
 
@@ -117,6 +121,14 @@ type FargateProfileStatus struct {
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
+	// ActiveProfileName is the name of the EKS Fargate profile currently in use. Changing
+	// the selectors or subnets of the profile creates a replacement profile with a suffixed
+	// name, which becomes the active profile once it's active in EKS. If empty, the active
+	// profile is looked up from the profile tags, falling back to the one named by
+	// spec.profileName.
+	// +optional
+	ActiveProfileName string `json:"activeProfileName,omitempty"`
+
 	// Conditions defines current state of the Fargate profile.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
@@ -128,6 +140,7 @@ type FargateProfileStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="AWSFargateProfile ready status"
 // +kubebuilder:printcolumn:name="ProfileName",type="string",JSONPath=".spec.profileName",description="EKS Fargate profile name"
+// +kubebuilder:printcolumn:name="ActiveProfileName",type="string",JSONPath=".status.activeProfileName",description="Active EKS Fargate profile name",priority=1
 // +kubebuilder:printcolumn:name="FailureReason",type="string",JSONPath=".status.failureReason",description="Failure reason"
 
 // AWSFargateProfile is the Schema for the awsfargateprofiles API.
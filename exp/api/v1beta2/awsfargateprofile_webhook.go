@@ -113,6 +113,12 @@ func (r *AWSFargateProfile) ValidateUpdate(oldObj runtime.Object) (admission.War
 	// remove additionalTags from equal check since they are mutable
 	old.Spec.AdditionalTags = nil
 	r.Spec.AdditionalTags = nil
+	// remove selectors and subnetIDs from equal check since changing them
+	// replaces the EKS fargate profile
+	old.Spec.Selectors = nil
+	r.Spec.Selectors = nil
+	old.Spec.SubnetIDs = nil
+	r.Spec.SubnetIDs = nil
 
 	if !cmp.Equal(old.Spec, r.Spec) {
 		allErrs = append(
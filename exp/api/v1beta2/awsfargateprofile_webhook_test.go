@@ -75,6 +75,15 @@ func TestAWSFargateProfileValidateRoleNameUpdate(t *testing.T) {
 	validRoleNameUpdate := before.DeepCopy()
 	validRoleNameUpdate.Spec.RoleName = "clustername-profilename_fargate"
 
+	selectorsUpdate := before.DeepCopy()
+	selectorsUpdate.Spec.Selectors = []FargateSelector{{Namespace: "kube-system"}}
+
+	subnetIDsUpdate := before.DeepCopy()
+	subnetIDsUpdate.Spec.SubnetIDs = []string{"subnet-1"}
+
+	profileNameUpdate := before.DeepCopy()
+	profileNameUpdate.Spec.ProfileName = "different-profile-name"
+
 	beforeWithDifferentRoleName := before.DeepCopy()
 	beforeWithDifferentRoleName.Spec.RoleName = "different-role-name"
 
@@ -108,6 +117,24 @@ func TestAWSFargateProfileValidateRoleNameUpdate(t *testing.T) {
 			before:         beforeWithDifferentRoleName,
 			fargateProfile: validRoleNameUpdate,
 		},
+		{
+			name:           "update selectors should succeed",
+			expectErr:      false,
+			before:         before,
+			fargateProfile: selectorsUpdate,
+		},
+		{
+			name:           "update subnetIDs should succeed",
+			expectErr:      false,
+			before:         before,
+			fargateProfile: subnetIDsUpdate,
+		},
+		{
+			name:           "update profileName should fail",
+			expectErr:      true,
+			before:         before,
+			fargateProfile: profileNameUpdate,
+		},
 		{
 			name:           "update tags should fail when invalid tags are present",
 			expectErr:      true,
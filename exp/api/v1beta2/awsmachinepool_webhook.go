@@ -116,6 +116,31 @@ func (r *AWSMachinePool) validateSpotInstances() field.ErrorList {
 	return allErrs
 }
 
+// validateAWSLaunchTemplate validates the launch template settings shared by AWSMachinePool and AWSManagedMachinePool.
+func validateAWSLaunchTemplate(lt *AWSLaunchTemplate, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if lt.PlacementGroupPartition != 0 && lt.PlacementGroupName == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("placementGroupPartition"), lt.PlacementGroupPartition, "placementGroupPartition is only valid with placementGroupName"))
+	}
+
+	type interfaceIndex struct{ networkCardIndex, deviceIndex int64 }
+	indexes := map[interfaceIndex]bool{}
+	for i, ni := range lt.NetworkInterfaces {
+		index := interfaceIndex{ni.NetworkCardIndex, ni.DeviceIndex}
+		if indexes[index] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("networkInterfaces").Index(i).Child("deviceIndex"), ni.DeviceIndex))
+		}
+		indexes[index] = true
+
+		if ni.AssociatePublicIPAddress != nil && ni.DeviceIndex != 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("networkInterfaces").Index(i).Child("associatePublicIPAddress"), *ni.AssociatePublicIPAddress, "associatePublicIPAddress is only valid for the network interface with a device index of 0"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateCreate will do any extra validation when creating a AWSMachinePool.
 func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	log.Info("AWSMachinePool validate create", "machine-pool", klog.KObj(r))
@@ -128,6 +153,7 @@ func (r *AWSMachinePool) ValidateCreate() (admission.Warnings, error) {
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, validateAWSLaunchTemplate(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -149,6 +175,7 @@ func (r *AWSMachinePool) ValidateUpdate(_ runtime.Object) (admission.Warnings, e
 	allErrs = append(allErrs, r.validateSubnets()...)
 	allErrs = append(allErrs, r.validateAdditionalSecurityGroups()...)
 	allErrs = append(allErrs, r.validateSpotInstances()...)
+	allErrs = append(allErrs, validateAWSLaunchTemplate(&r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
 
 	if len(allErrs) == 0 {
 		return nil, nil
@@ -153,6 +153,70 @@ func TestAWSMachinePoolValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Should fail if the placement group partition is set without a placement group name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupPartition: 2,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should pass if the placement group partition is set with a placement group name",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						PlacementGroupName:      "placement-group",
+						PlacementGroupPartition: 2,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should pass with network interfaces",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 0, InterfaceType: "efa", AssociatePublicIPAddress: aws.Bool(false)},
+							{DeviceIndex: 0, NetworkCardIndex: 1, InterfaceType: "efa"},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail with duplicate network interfaces",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 1},
+							{DeviceIndex: 1},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if a public IP address is associated with a secondary network interface",
+			pool: &AWSMachinePool{
+				Spec: AWSMachinePoolSpec{
+					AWSLaunchTemplate: AWSLaunchTemplate{
+						NetworkInterfaces: []LaunchTemplateNetworkInterface{
+							{DeviceIndex: 1, AssociatePublicIPAddress: aws.Bool(true)},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
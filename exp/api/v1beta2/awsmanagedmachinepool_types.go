@@ -154,6 +154,10 @@ type AWSManagedMachinePoolSpec struct {
 	// +optional
 	UpdateConfig *UpdateConfig `json:"updateConfig,omitempty"`
 
+	// NodeRepairConfig specifies the node auto repair configuration of the nodegroup.
+	// +optional
+	NodeRepairConfig *NodeRepairConfig `json:"nodeRepairConfig,omitempty"`
+
 	// AWSLaunchTemplate specifies the launch template to use to create the managed node group.
 	// If AWSLaunchTemplate is specified, certain node group configuraions outside of launch template
 	// are prohibited (https://docs.aws.amazon.com/eks/latest/userguide/launch-templates.html).
@@ -167,6 +171,14 @@ type ManagedMachinePoolScaling struct {
 	MaxSize *int32 `json:"maxSize,omitempty"`
 }
 
+// NodeRepairConfig specifies the node auto repair configuration of a nodegroup.
+type NodeRepairConfig struct {
+	// Enabled specifies whether EKS repairs the nodes of the nodegroup when they
+	// become unhealthy.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
 // ManagedRemoteAccess specifies remote access settings for EC2 instances.
 type ManagedRemoteAccess struct {
 	// SSHKeyName specifies which EC2 SSH key can be used to access machines.
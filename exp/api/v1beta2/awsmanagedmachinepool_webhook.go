@@ -135,6 +135,8 @@ func (r *AWSManagedMachinePool) validateLaunchTemplate() field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "AWSLaunchTemplate", "IamInstanceProfile"), r.Spec.AWSLaunchTemplate.IamInstanceProfile, "IAM instance profile in launch template is prohibited in EKS managed node group"))
 	}
 
+	allErrs = append(allErrs, validateAWSLaunchTemplate(r.Spec.AWSLaunchTemplate, field.NewPath("spec", "awsLaunchTemplate"))...)
+
 	return allErrs
 }
 
@@ -64,6 +64,9 @@ const (
 	// WaitingForEKSControlPlaneReason used when the machine pool is waiting for
 	// EKS control plane infrastructure to be ready before proceeding.
 	WaitingForEKSControlPlaneReason = "WaitingForEKSControlPlane"
+	// EKSNodegroupHealthyCondition reports on the health issues of the EKS nodegroup. The reason
+	// is the code of the first health issue reported by EKS.
+	EKSNodegroupHealthyCondition clusterv1.ConditionType = "EKSNodegroupHealthy"
 )
 
 const (
@@ -74,6 +77,9 @@ const (
 	EKSFargateCreatingCondition clusterv1.ConditionType = "EKSFargateCreating"
 	// EKSFargateDeletingCondition used to report that the profile is deleting.
 	EKSFargateDeletingCondition = "EKSFargateDeleting"
+	// EKSFargateReplacingCondition used to report that the profile is being replaced
+	// by a profile with the updated selectors or subnets.
+	EKSFargateReplacingCondition clusterv1.ConditionType = "EKSFargateReplacing"
 	// EKSFargateReconciliationFailedReason used to report failures while reconciling EKS control plane.
 	EKSFargateReconciliationFailedReason = "EKSFargateReconciliationFailed"
 	// EKSFargateDeletingReason used when the profile is deleting.
@@ -86,6 +92,8 @@ const (
 	EKSFargateDeletedReason = "Deleted"
 	// EKSFargateFailedReason used when the profile failed.
 	EKSFargateFailedReason = "Failed"
+	// EKSFargateReplacedReason used when the profile was replaced.
+	EKSFargateReplacedReason = "Replaced"
 )
 
 const (
@@ -108,6 +116,8 @@ const (
 	RosaMachinePoolReadyCondition clusterv1.ConditionType = "RosaMchinePoolReady"
 	// RosaMachinePoolUpgradingCondition condition reports whether ROSAMachinePool is upgrading or not.
 	RosaMachinePoolUpgradingCondition clusterv1.ConditionType = "RosaMchinePoolUpgrading"
+	// RosaMachinePoolInSyncCondition condition reports whether the nodepool matches the spec of the ROSAMachinePool.
+	RosaMachinePoolInSyncCondition clusterv1.ConditionType = "RosaMachinePoolInSync"
 
 	// WaitingForRosaControlPlaneReason used when the machine pool is waiting for
 	// ROSA control plane infrastructure to be ready before proceeding.
@@ -115,4 +125,8 @@ const (
 
 	// RosaMachinePoolReconciliationFailedReason used to report failures while reconciling ROSAMachinePool.
 	RosaMachinePoolReconciliationFailedReason = "ReconciliationFailed"
+
+	// RosaMachinePoolDriftDetectedReason used when the nodepool doesn't match the spec of the ROSAMachinePool
+	// and the drift policy is to only report it.
+	RosaMachinePoolDriftDetectedReason = "DriftDetected"
 )
@@ -25,10 +25,23 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+const (
+	// RosaMachinePoolDriftPolicyAnnotation sets whether differences between the spec of a ROSAMachinePool and its node pool
+	// are corrected or only reported in the RosaMachinePoolInSync condition. Valid values are "Correct", the default, and "Report".
+	RosaMachinePoolDriftPolicyAnnotation = "infrastructure.cluster.x-k8s.io/rosamachinepool-drift-policy"
+
+	// RosaMachinePoolDriftPolicyCorrect updates the node pool to match the spec of the ROSAMachinePool.
+	RosaMachinePoolDriftPolicyCorrect = "Correct"
+
+	// RosaMachinePoolDriftPolicyReport only reports differences, without changing the node pool.
+	RosaMachinePoolDriftPolicyReport = "Report"
+)
+
 // RosaMachinePoolSpec defines the desired state of RosaMachinePool.
 type RosaMachinePoolSpec struct {
 	// NodePoolName specifies the name of the nodepool in Rosa
 	// must be a valid DNS-1035 label, so it must consist of lower case alphanumeric and have a max length of 15 characters.
+	// If a nodepool with this name already exists, it's adopted and its configuration is imported into the unset fields of the spec.
 	//
 	// +immutable
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="nodepoolName is immutable"
@@ -82,10 +95,15 @@ type RosaMachinePoolSpec struct {
 	Autoscaling *RosaMachinePoolAutoScaling `json:"autoscaling,omitempty"`
 
 	// TuningConfigs specifies the names of the tuning configs to be applied to this MachinePool.
-	// Tuning configs must already exist.
+	// Tuning configs must already exist, or be defined in the `tuningConfigs` of the ROSAControlPlane.
 	// +optional
 	TuningConfigs []string `json:"tuningConfigs,omitempty"`
 
+	// KubeletConfig specifies the name of the kubelet config to be applied to this MachinePool.
+	// The kubelet config must already exist, or be defined in the `kubeletConfigs` of the ROSAControlPlane.
+	// +optional
+	KubeletConfig string `json:"kubeletConfig,omitempty"`
+
 	// AdditionalSecurityGroups is an optional set of security groups to associate
 	// with all node instances of the machine pool.
 	//
@@ -93,6 +111,29 @@ type RosaMachinePoolSpec struct {
 	// +optional
 	AdditionalSecurityGroups []string `json:"additionalSecurityGroups,omitempty"`
 
+	// VolumeSize is the size of the root volume of the nodes, in GiB. Defaults to the size chosen by ROSA.
+	//
+	// +kubebuilder:validation:Minimum=75
+	// +kubebuilder:validation:Maximum=16384
+	// +immutable
+	// +optional
+	VolumeSize int `json:"volumeSize,omitempty"`
+
+	// EC2MetadataHTTPTokens sets whether the nodes require session tokens for requests to the instance metadata
+	// service (IMDSv2) with `required`, or also accept requests without tokens (IMDSv1) with `optional`.
+	// Defaults to the setting of the cluster.
+	//
+	// +kubebuilder:validation:Enum=optional;required
+	// +immutable
+	// +optional
+	EC2MetadataHTTPTokens infrav1.HTTPTokensState `json:"ec2MetadataHTTPTokens,omitempty"`
+
+	// CapacityReservation targets an EC2 capacity reservation for the instances of the nodes.
+	//
+	// +immutable
+	// +optional
+	CapacityReservation *RosaCapacityReservation `json:"capacityReservation,omitempty"`
+
 	// ProviderIDList contain a ProviderID for each machine instance that's currently managed by this machine pool.
 	// +optional
 	ProviderIDList []string `json:"providerIDList,omitempty"`
@@ -132,6 +173,32 @@ type RosaTaint struct {
 	Effect corev1.TaintEffect `json:"effect"`
 }
 
+// RosaCapacityReservationMarketType is the market type of an EC2 capacity reservation.
+type RosaCapacityReservationMarketType string
+
+const (
+	// RosaCapacityReservationMarketTypeOnDemand is an On-Demand Capacity Reservation.
+	RosaCapacityReservationMarketTypeOnDemand = RosaCapacityReservationMarketType("OnDemand")
+
+	// RosaCapacityReservationMarketTypeCapacityBlocks is a Capacity Block for ML.
+	RosaCapacityReservationMarketTypeCapacityBlocks = RosaCapacityReservationMarketType("CapacityBlocks")
+)
+
+// RosaCapacityReservation specifies the EC2 capacity reservation targeted by the instances of a MachinePool.
+type RosaCapacityReservation struct {
+	// ID of the capacity reservation, for example `cr-0123456789abcdef0`.
+	//
+	// +kubebuilder:validation:Pattern:=`^cr-[0-9a-f]+$`
+	ID string `json:"id"`
+
+	// MarketType is the market type of the capacity reservation.
+	//
+	// +kubebuilder:validation:Enum=OnDemand;CapacityBlocks
+	// +kubebuilder:default=OnDemand
+	// +optional
+	MarketType RosaCapacityReservationMarketType `json:"marketType,omitempty"`
+}
+
 // RosaMachinePoolAutoScaling specifies scaling options.
 type RosaMachinePoolAutoScaling struct {
 	// +kubebuilder:validation:Minimum=1
@@ -213,7 +280,8 @@ type RosaMachinePoolStatus struct {
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
 
-	// ID is the ID given by ROSA.
+	// ID is the ID given by ROSA. It's set once the node pool is created, or once an existing node pool
+	// with the same name is adopted.
 	ID string `json:"id,omitempty"`
 }
 
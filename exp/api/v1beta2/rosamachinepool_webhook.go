@@ -71,8 +71,14 @@ func (r *ROSAMachinePool) ValidateUpdate(old runtime.Object) (warnings admission
 		allErrs = append(allErrs, err)
 	}
 
-	allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalSecurityGroups, r.Spec.AdditionalSecurityGroups, "additionalSecurityGroups")...)
-	allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalTags, r.Spec.AdditionalTags, "additionalTags")...)
+	// the fields can still change until the nodepool is created or adopted, as adopting a nodepool imports its configuration.
+	if oldPool.Status.ID != "" {
+		allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalSecurityGroups, r.Spec.AdditionalSecurityGroups, "additionalSecurityGroups")...)
+		allErrs = append(allErrs, validateImmutable(oldPool.Spec.AdditionalTags, r.Spec.AdditionalTags, "additionalTags")...)
+		allErrs = append(allErrs, validateImmutable(oldPool.Spec.VolumeSize, r.Spec.VolumeSize, "volumeSize")...)
+		allErrs = append(allErrs, validateImmutable(oldPool.Spec.EC2MetadataHTTPTokens, r.Spec.EC2MetadataHTTPTokens, "ec2MetadataHTTPTokens")...)
+		allErrs = append(allErrs, validateImmutable(oldPool.Spec.CapacityReservation, r.Spec.CapacityReservation, "capacityReservation")...)
+	}
 
 	if len(allErrs) == 0 {
 		return nil, nil
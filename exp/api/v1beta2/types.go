@@ -96,6 +96,10 @@ type AWSLaunchTemplate struct {
 	// +optional
 	RootVolume *infrav1.Volume `json:"rootVolume,omitempty"`
 
+	// NonRootVolumes is the set of non-root volumes to attach to the instances.
+	// +optional
+	NonRootVolumes []infrav1.Volume `json:"nonRootVolumes,omitempty"`
+
 	// SSHKeyName is the name of the ssh key to attach to the instance. Valid values are empty string
 	// (do not use SSH keys), a valid SSH key name, or omitted (use the default SSH key name)
 	// +optional
@@ -124,6 +128,58 @@ type AWSLaunchTemplate struct {
 	// PrivateDNSName is the options for the instance hostname.
 	// +optional
 	PrivateDNSName *infrav1.PrivateDNSName `json:"privateDnsName,omitempty"`
+
+	// PlacementGroupName specifies the name of the placement group in which to launch the instances.
+	// +optional
+	PlacementGroupName string `json:"placementGroupName,omitempty"`
+
+	// PlacementGroupPartition is the partition number within the placement group in which to launch the instances.
+	// This value is only valid if the placement group, referred in `PlacementGroupName`, was created with
+	// strategy set to partition.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=7
+	// +optional
+	PlacementGroupPartition int64 `json:"placementGroupPartition,omitempty"`
+
+	// Tenancy indicates if the instances should run on shared or single-tenant hardware.
+	// +optional
+	// +kubebuilder:validation:Enum:=default;dedicated;host
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// CapacityReservationID is the ID of the capacity reservation in which to launch the instances.
+	// +optional
+	CapacityReservationID *string `json:"capacityReservationId,omitempty"`
+
+	// NetworkInterfaces is the configuration of the network interfaces of the instances. When set, the
+	// security groups of the launch template are applied to each network interface. The subnets of the
+	// network interfaces are chosen by the Auto Scaling group or the EKS node group.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	NetworkInterfaces []LaunchTemplateNetworkInterface `json:"networkInterfaces,omitempty"`
+}
+
+// LaunchTemplateNetworkInterface defines a network interface of the instances of a launch template.
+type LaunchTemplateNetworkInterface struct {
+	// DeviceIndex is the position of the network interface in the attachment order.
+	// The primary network interface has a device index of 0.
+	// +kubebuilder:validation:Minimum:=0
+	DeviceIndex int64 `json:"deviceIndex"`
+
+	// NetworkCardIndex is the index of the network card, for instance types that support multiple
+	// network cards.
+	// +kubebuilder:validation:Minimum:=0
+	// +optional
+	NetworkCardIndex int64 `json:"networkCardIndex,omitempty"`
+
+	// InterfaceType is the type of the network interface. Use efa for an Elastic Fabric Adapter.
+	// +kubebuilder:validation:Enum:=interface;efa
+	// +optional
+	InterfaceType string `json:"interfaceType,omitempty"`
+
+	// AssociatePublicIPAddress associates a public IPv4 address with the network interface.
+	// Only valid for the network interface with a device index of 0.
+	// +optional
+	AssociatePublicIPAddress *bool `json:"associatePublicIPAddress,omitempty"`
 }
 
 // Overrides are used to override the instance type specified by the launch template with multiple
@@ -294,8 +350,25 @@ type UpdateConfig struct {
 	// +kubebuilder:validation:Maximum=100
 	// +kubebuilder:validation:Minimum=1
 	MaxUnavailablePercentage *int `json:"maxUnavailablePercentage,omitempty"`
+
+	// UpdateStrategy is the strategy used to update the nodes of the nodegroup. DEFAULT
+	// scales up the nodegroup with new nodes before the old nodes are drained, MINIMAL
+	// doesn't scale up and drains the old nodes first.
+	// +optional
+	// +kubebuilder:validation:Enum=DEFAULT;MINIMAL
+	UpdateStrategy *NodegroupUpdateStrategy `json:"updateStrategy,omitempty"`
 }
 
+// NodegroupUpdateStrategy is the strategy used to update the nodes of a nodegroup.
+type NodegroupUpdateStrategy string
+
+const (
+	// NodegroupUpdateStrategyDefault creates new nodes before draining the old nodes.
+	NodegroupUpdateStrategyDefault NodegroupUpdateStrategy = "DEFAULT"
+	// NodegroupUpdateStrategyMinimal drains the old nodes without creating new nodes first.
+	NodegroupUpdateStrategyMinimal NodegroupUpdateStrategy = "MINIMAL"
+)
+
 // AZSubnetType is the type of subnet to use when an availability zone is specified.
 type AZSubnetType string
 
@@ -3,6 +3,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -222,6 +225,17 @@ func (r *ROSAMachinePoolReconciler) reconcileNormal(ctx context.Context,
 	}
 
 	if found {
+		if rosaMachinePool.Status.ID == "" {
+			// the nodePool wasn't created for this ROSAMachinePool, import its current configuration before enforcing the desired state.
+			if err := r.checkNodePoolNotAdopted(ctx, machinePoolScope, nodePool.ID()); err != nil {
+				return ctrl.Result{}, err
+			}
+			adoptNodePool(rosaMachinePool, nodePool)
+			r.Recorder.Eventf(rosaMachinePool, corev1.EventTypeNormal, "AdoptedNodePool", "Adopted existing NodePool %s", nodePool.ID())
+			machinePoolScope.Info("adopted existing NodePool", "id", nodePool.ID())
+			return ctrl.Result{Requeue: true}, nil
+		}
+
 		if rosaMachinePool.Spec.AvailabilityZone == "" {
 			// reflect the current AvailabilityZone in the spec if not set.
 			rosaMachinePool.Spec.AvailabilityZone = nodePool.AvailabilityZone()
@@ -278,7 +292,7 @@ func (r *ROSAMachinePoolReconciler) reconcileNormal(ctx context.Context,
 		return ctrl.Result{}, fmt.Errorf("failed to build rosa nodepool: %w", err)
 	}
 
-	nodePool, err = ocmClient.CreateNodePool(machinePoolScope.ControlPlane.Status.ID, nodePoolSpec)
+	nodePool, err = createNodePool(ctx, rosaControlPlaneScope, ocmClient, machinePoolScope.ControlPlane.Status.ID, nodePoolSpec, awsNodePoolSettings(rosaMachinePool.Spec))
 	if err != nil {
 		conditions.MarkFalse(rosaMachinePool,
 			expinfrav1.RosaMachinePoolReadyCondition,
@@ -327,6 +341,11 @@ func (r *ROSAMachinePoolReconciler) reconcileMachinePoolVersion(machinePoolScope
 		return nil
 	}
 
+	if driftPolicy(machinePoolScope.RosaMachinePool) == expinfrav1.RosaMachinePoolDriftPolicyReport {
+		// the version difference is reported by updateNodePool.
+		return nil
+	}
+
 	clusterID := machinePoolScope.ControlPlane.Status.ID
 	_, scheduledUpgrade, err := ocmClient.GetHypershiftNodePoolUpgrade(clusterID, machinePoolScope.ControlPlane.Spec.RosaClusterName, nodePool.ID())
 	if err != nil {
@@ -365,14 +384,35 @@ func (r *ROSAMachinePoolReconciler) updateNodePool(machinePoolScope *scope.RosaM
 	currentSpec := nodePoolToRosaMachinePoolSpec(nodePool)
 
 	ignoredFields := []string{
-		"ProviderIDList", // providerIDList is set by the controller.
-		"Version",        // Version changes are reconciled separately.
-		"AdditionalTags", // AdditionalTags day2 changes not supported.
+		"ProviderIDList",        // providerIDList is set by the controller.
+		"AdditionalTags",        // AdditionalTags day2 changes not supported.
+		"VolumeSize",            // VolumeSize is only set on creation.
+		"EC2MetadataHTTPTokens", // EC2MetadataHTTPTokens is only set on creation.
+		"CapacityReservation",   // CapacityReservation is only set on creation.
+	}
+	if driftPolicy(machinePool) == expinfrav1.RosaMachinePoolDriftPolicyReport {
+		// version differences are reported as well, as they won't be reconciled either.
+		if desiredSpec.Version == "" {
+			desiredSpec.Version = currentSpec.Version
+		}
+		if drifted := driftedFields(desiredSpec, currentSpec, ignoredFields...); len(drifted) > 0 {
+			conditions.MarkFalse(machinePoolScope.RosaMachinePool,
+				expinfrav1.RosaMachinePoolInSyncCondition,
+				expinfrav1.RosaMachinePoolDriftDetectedReason,
+				clusterv1.ConditionSeverityWarning,
+				"NodePool differs from the spec in: %s", strings.Join(drifted, ", "))
+		} else {
+			conditions.MarkTrue(machinePoolScope.RosaMachinePool, expinfrav1.RosaMachinePoolInSyncCondition)
+		}
+		return nodePool, nil
 	}
+
+	ignoredFields = append(ignoredFields, "Version") // Version changes are reconciled separately.
 	if cmp.Equal(desiredSpec, currentSpec,
 		cmpopts.EquateEmpty(), // ensures empty non-nil slices and nil slices are considered equal.
 		cmpopts.IgnoreFields(currentSpec, ignoredFields...)) {
 		// no changes detected.
+		conditions.MarkTrue(machinePoolScope.RosaMachinePool, expinfrav1.RosaMachinePoolInSyncCondition)
 		return nodePool, nil
 	}
 
@@ -396,6 +436,7 @@ func (r *ROSAMachinePoolReconciler) updateNodePool(machinePoolScope *scope.RosaM
 			"failed to update ROSAMachinePool: %s", err.Error())
 		return nil, fmt.Errorf("failed to update nodePool: %w", err)
 	}
+	conditions.MarkTrue(machinePoolScope.RosaMachinePool, expinfrav1.RosaMachinePoolInSyncCondition)
 
 	return updatedNodePool, nil
 }
@@ -432,6 +473,10 @@ func nodePoolBuilder(rosaMachinePoolSpec expinfrav1.RosaMachinePoolSpec, machine
 		npBuilder = npBuilder.TuningConfigs(rosaMachinePoolSpec.TuningConfigs...)
 	}
 
+	if rosaMachinePoolSpec.KubeletConfig != "" {
+		npBuilder = npBuilder.KubeletConfigs(rosaMachinePoolSpec.KubeletConfig)
+	}
+
 	if len(rosaMachinePoolSpec.Taints) > 0 {
 		taintBuilders := []*cmv1.TaintBuilder{}
 		for _, taint := range rosaMachinePoolSpec.Taints {
@@ -494,6 +539,42 @@ func nodePoolBuilder(rosaMachinePoolSpec expinfrav1.RosaMachinePoolSpec, machine
 	return npBuilder
 }
 
+// createNodePool creates the nodePool with the OCM client, unless it has AWS settings which the OCM client doesn't support.
+func createNodePool(ctx context.Context, rosaControlPlaneScope *scope.ROSAControlPlaneScope, ocmClient *ocm.Client, clusterID string,
+	nodePool *cmv1.NodePool, awsSettings rosa.AWSNodePoolSettings) (*cmv1.NodePool, error) {
+	if awsSettings.IsEmpty() {
+		return ocmClient.CreateNodePool(clusterID, nodePool)
+	}
+
+	nodePoolClient, err := rosa.NewNodePoolClient(ctx, rosaControlPlaneScope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create nodepool client: %w", err)
+	}
+	defer nodePoolClient.Close()
+
+	return nodePoolClient.CreateNodePool(clusterID, nodePool, awsSettings)
+}
+
+// awsNodePoolSettings returns the AWS settings of the nodePool which aren't supported by nodePoolBuilder.
+func awsNodePoolSettings(rosaMachinePoolSpec expinfrav1.RosaMachinePoolSpec) rosa.AWSNodePoolSettings {
+	settings := rosa.AWSNodePoolSettings{
+		EC2MetadataHTTPTokens: string(rosaMachinePoolSpec.EC2MetadataHTTPTokens),
+	}
+	if rosaMachinePoolSpec.VolumeSize != 0 {
+		settings.RootVolume = &rosa.AWSNodePoolRootVolume{
+			Size: rosaMachinePoolSpec.VolumeSize,
+		}
+	}
+	if capacityReservation := rosaMachinePoolSpec.CapacityReservation; capacityReservation != nil {
+		settings.CapacityReservation = &rosa.AWSCapacityReservation{
+			ID:         capacityReservation.ID,
+			MarketType: string(capacityReservation.MarketType),
+		}
+	}
+
+	return settings
+}
+
 func nodePoolToRosaMachinePoolSpec(nodePool *cmv1.NodePool) expinfrav1.RosaMachinePoolSpec {
 	spec := expinfrav1.RosaMachinePoolSpec{
 		NodePoolName:             nodePool.ID(),
@@ -510,6 +591,9 @@ func nodePoolToRosaMachinePoolSpec(nodePool *cmv1.NodePool) expinfrav1.RosaMachi
 		// AdditionalTags:           nodePool.AWSNodePool().Tags(),
 	}
 
+	if len(nodePool.KubeletConfigs()) > 0 {
+		spec.KubeletConfig = nodePool.KubeletConfigs()[0]
+	}
 	if nodePool.Autoscaling() != nil {
 		spec.Autoscaling = &expinfrav1.RosaMachinePoolAutoScaling{
 			MinReplicas: nodePool.Autoscaling().MinReplica(),
@@ -517,7 +601,7 @@ func nodePoolToRosaMachinePoolSpec(nodePool *cmv1.NodePool) expinfrav1.RosaMachi
 		}
 	}
 	if nodePool.Taints() != nil {
-		rosaTaints := make([]expinfrav1.RosaTaint, len(nodePool.Taints()))
+		rosaTaints := make([]expinfrav1.RosaTaint, 0, len(nodePool.Taints()))
 		for _, taint := range nodePool.Taints() {
 			rosaTaints = append(rosaTaints, expinfrav1.RosaTaint{
 				Key:    taint.Key(),
@@ -547,6 +631,97 @@ func nodePoolToRosaMachinePoolSpec(nodePool *cmv1.NodePool) expinfrav1.RosaMachi
 	return spec
 }
 
+// checkNodePoolNotAdopted returns an error if another ROSAMachinePool of the cluster already records the ID of the nodePool,
+// so that two ROSAMachinePools never manage the same nodePool.
+func (r *ROSAMachinePoolReconciler) checkNodePoolNotAdopted(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope, nodePoolID string) error {
+	rosaMachinePools := &expinfrav1.ROSAMachinePoolList{}
+	if err := r.Client.List(ctx, rosaMachinePools,
+		client.InNamespace(machinePoolScope.Cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: machinePoolScope.Cluster.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list ROSAMachinePools: %w", err)
+	}
+
+	for _, rosaMachinePool := range rosaMachinePools.Items {
+		if rosaMachinePool.UID != machinePoolScope.RosaMachinePool.UID && rosaMachinePool.Status.ID == nodePoolID {
+			return fmt.Errorf("nodePool %s is already managed by ROSAMachinePool %s", nodePoolID, rosaMachinePool.Name)
+		}
+	}
+	return nil
+}
+
+// adoptNodePool sets the ID of an existing nodePool in the status and imports its configuration into the unset fields of the spec.
+func adoptNodePool(rosaMachinePool *expinfrav1.ROSAMachinePool, nodePool *cmv1.NodePool) {
+	spec := &rosaMachinePool.Spec
+	current := nodePoolToRosaMachinePoolSpec(nodePool)
+
+	if spec.Version == "" {
+		spec.Version = current.Version
+	}
+	if spec.AvailabilityZone == "" {
+		spec.AvailabilityZone = current.AvailabilityZone
+	}
+	if spec.Subnet == "" {
+		spec.Subnet = current.Subnet
+	}
+	if len(spec.Labels) == 0 {
+		spec.Labels = current.Labels
+	}
+	if len(spec.Taints) == 0 {
+		spec.Taints = current.Taints
+	}
+	if spec.InstanceType == "" {
+		spec.InstanceType = current.InstanceType
+	}
+	if spec.Autoscaling == nil {
+		spec.Autoscaling = current.Autoscaling
+	}
+	if len(spec.TuningConfigs) == 0 {
+		spec.TuningConfigs = current.TuningConfigs
+	}
+	if spec.KubeletConfig == "" {
+		spec.KubeletConfig = current.KubeletConfig
+	}
+	if len(spec.AdditionalSecurityGroups) == 0 {
+		spec.AdditionalSecurityGroups = current.AdditionalSecurityGroups
+	}
+	if spec.NodeDrainGracePeriod == nil || spec.NodeDrainGracePeriod.Duration == 0 {
+		spec.NodeDrainGracePeriod = current.NodeDrainGracePeriod
+	}
+	if spec.UpdateConfig == nil {
+		spec.UpdateConfig = current.UpdateConfig
+	}
+
+	rosaMachinePool.Status.ID = nodePool.ID()
+}
+
+// driftPolicy returns the drift policy of the ROSAMachinePool, which defaults to correcting any drift.
+func driftPolicy(rosaMachinePool *expinfrav1.ROSAMachinePool) string {
+	if rosaMachinePool.Annotations[expinfrav1.RosaMachinePoolDriftPolicyAnnotation] == expinfrav1.RosaMachinePoolDriftPolicyReport {
+		return expinfrav1.RosaMachinePoolDriftPolicyReport
+	}
+	return expinfrav1.RosaMachinePoolDriftPolicyCorrect
+}
+
+// driftedFields returns the json names of the fields which differ between the desired and current specs.
+func driftedFields(desired, current expinfrav1.RosaMachinePoolSpec, ignoredFields ...string) []string {
+	desiredValue := reflect.ValueOf(desired)
+	currentValue := reflect.ValueOf(current)
+	specType := desiredValue.Type()
+
+	drifted := []string{}
+	for i := 0; i < specType.NumField(); i++ {
+		field := specType.Field(i)
+		if slices.Contains(ignoredFields, field.Name) {
+			continue
+		}
+		if !cmp.Equal(desiredValue.Field(i).Interface(), currentValue.Field(i).Interface(), cmpopts.EquateEmpty()) {
+			drifted = append(drifted, strings.Split(field.Tag.Get("json"), ",")[0])
+		}
+	}
+	return drifted
+}
+
 func (r *ROSAMachinePoolReconciler) reconcileProviderIDList(ctx context.Context, machinePoolScope *scope.RosaMachinePoolScope, nodePool *cmv1.NodePool) error {
 	tags := nodePool.AWSNodePool().Tags()
 	if len(tags) == 0 {
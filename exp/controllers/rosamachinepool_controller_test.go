@@ -1,17 +1,34 @@
 package controllers
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/test/helpers/fakeocm"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestNodePoolToRosaMachinePoolSpec(t *testing.T) {
@@ -24,6 +41,7 @@ func TestNodePoolToRosaMachinePoolSpec(t *testing.T) {
 		AutoRepair:    true,
 		InstanceType:  "m5.large",
 		TuningConfigs: []string{"config1"},
+		KubeletConfig: "kubelet-config1",
 		NodeDrainGracePeriod: &metav1.Duration{
 			Duration: time.Minute * 10,
 		},
@@ -48,3 +66,374 @@ func TestNodePoolToRosaMachinePoolSpec(t *testing.T) {
 
 	g.Expect(rosaMachinePoolSpec).To(BeComparableTo(expectedSpec, cmpopts.EquateEmpty()))
 }
+
+func TestAdoptNodePool(t *testing.T) {
+	g := NewWithT(t)
+
+	nodePool, err := cmv1.NewNodePool().ID("test-nodepool").
+		Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5")).
+		Subnet("subnet-id").
+		Labels(map[string]string{"role": "worker"}).
+		AutoRepair(true).
+		Autoscaling(cmv1.NewNodePoolAutoscaling().MinReplica(2).MaxReplica(5)).
+		TuningConfigs("config1").
+		AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.large").AdditionalSecurityGroupIds("sg-1")).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	rosaMachinePool := &expinfrav1.ROSAMachinePool{
+		Spec: expinfrav1.RosaMachinePoolSpec{
+			NodePoolName: "test-nodepool",
+			InstanceType: "m5.xlarge",
+			Labels:       map[string]string{"role": "infra"},
+		},
+	}
+
+	adoptNodePool(rosaMachinePool, nodePool)
+
+	g.Expect(rosaMachinePool.Status.ID).To(Equal("test-nodepool"))
+	// fields set in the spec are kept.
+	g.Expect(rosaMachinePool.Spec.InstanceType).To(Equal("m5.xlarge"))
+	g.Expect(rosaMachinePool.Spec.Labels).To(Equal(map[string]string{"role": "infra"}))
+	// unset fields are imported from the nodePool.
+	g.Expect(rosaMachinePool.Spec.Version).To(Equal("4.14.5"))
+	g.Expect(rosaMachinePool.Spec.Subnet).To(Equal("subnet-id"))
+	g.Expect(rosaMachinePool.Spec.Autoscaling).To(Equal(&expinfrav1.RosaMachinePoolAutoScaling{MinReplicas: 2, MaxReplicas: 5}))
+	g.Expect(rosaMachinePool.Spec.TuningConfigs).To(Equal([]string{"config1"}))
+	g.Expect(rosaMachinePool.Spec.AdditionalSecurityGroups).To(Equal([]string{"sg-1"}))
+}
+
+func TestCheckNodePoolNotAdopted(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "capa-rosa", Namespace: "default"}}
+	rosaMachinePool := func(name, uid, clusterName, id string) *expinfrav1.ROSAMachinePool {
+		return &expinfrav1.ROSAMachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(uid),
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: clusterName},
+			},
+			Status: expinfrav1.RosaMachinePoolStatus{ID: id},
+		}
+	}
+	adopting := rosaMachinePool("adopting", "1", "capa-rosa", "")
+
+	scheme := runtime.NewScheme()
+	g.Expect(expinfrav1.AddToScheme(scheme)).To(Succeed())
+	r := &ROSAMachinePoolReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			adopting,
+			rosaMachinePool("workers", "2", "capa-rosa", "workers"),
+			rosaMachinePool("other-cluster", "3", "other-rosa", "infra"),
+		).Build(),
+	}
+	machinePoolScope := &scope.RosaMachinePoolScope{
+		Cluster:         cluster,
+		RosaMachinePool: adopting,
+	}
+
+	g.Expect(r.checkNodePoolNotAdopted(ctx, machinePoolScope, "workers")).To(MatchError(ContainSubstring("nodePool workers is already managed by ROSAMachinePool workers")))
+	// nodePools with the same ID in other clusters are ignored.
+	g.Expect(r.checkNodePoolNotAdopted(ctx, machinePoolScope, "infra")).To(Succeed())
+	g.Expect(r.checkNodePoolNotAdopted(ctx, machinePoolScope, "new")).To(Succeed())
+}
+
+func TestDriftedFields(t *testing.T) {
+	g := NewWithT(t)
+
+	current := expinfrav1.RosaMachinePoolSpec{
+		NodePoolName:   "test-nodepool",
+		Version:        "4.14.5",
+		InstanceType:   "m5.large",
+		Labels:         map[string]string{"role": "worker"},
+		ProviderIDList: []string{"aws:///us-east-1a/i-1"},
+	}
+
+	desired := *current.DeepCopy()
+	desired.ProviderIDList = nil
+	g.Expect(driftedFields(desired, current, "ProviderIDList")).To(BeEmpty())
+
+	desired.Version = "4.14.6"
+	desired.Labels = map[string]string{"role": "infra"}
+	desired.TuningConfigs = []string{}
+	g.Expect(driftedFields(desired, current, "ProviderIDList")).To(Equal([]string{"version", "labels"}))
+}
+
+func TestROSAMachinePoolReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	// the AWS sessions of the default controller identity use the credentials from the environment.
+	t.Setenv("AWS_ACCESS_KEY_ID", "fake")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "fake")
+
+	ocmServer := fakeocm.NewServer()
+	defer ocmServer.Close()
+
+	ocmCluster, err := cmv1.NewCluster().Name("capa-rosa").
+		Status(cmv1.NewClusterStatus().State(cmv1.ClusterStateReady)).
+		Version(cmv1.NewVersion().ID("openshift-v4.14.6").RawID("4.14.6")).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	ocmCluster, err = ocmServer.AddCluster(ocmCluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	clusterID := ocmCluster.ID()
+
+	controllerIdentity := &infrav1.AWSClusterControllerIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+		Spec: infrav1.AWSClusterControllerIdentitySpec{
+			AWSClusterIdentitySpec: infrav1.AWSClusterIdentitySpec{
+				AllowedNamespaces: &infrav1.AllowedNamespaces{},
+			},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, controllerIdentity)).To(Succeed())
+	ns, err := testEnv.CreateNamespace(ctx, fmt.Sprintf("rosa-%s", util.RandomString(5)))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	credentialsSecret := ocmServer.CredentialsSecret("ocm-credentials", ns.Name)
+	g.Expect(testEnv.Create(ctx, credentialsSecret)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capa-rosa",
+			Namespace: ns.Name,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: rosacontrolplanev1.GroupVersion.String(),
+				Kind:       "ROSAControlPlane",
+				Name:       "capa-rosa-control-plane",
+			},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, cluster)).To(Succeed())
+
+	rosaControlPlane := &rosacontrolplanev1.ROSAControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "capa-rosa-control-plane",
+			Namespace: ns.Name,
+		},
+		Spec: rosacontrolplanev1.RosaControlPlaneSpec{
+			RosaClusterName:      "capa-rosa",
+			Subnets:              []string{"subnet-0ac99a6230b408813"},
+			AvailabilityZones:    []string{"us-east-1a"},
+			Region:               fakeocm.DefaultRegion,
+			Version:              "4.14.6",
+			InstallerRoleARN:     "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Installer-Role",
+			SupportRoleARN:       "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Support-Role",
+			WorkerRoleARN:        "arn:aws:iam::123456789012:role/capa-HCP-ROSA-Worker-Role",
+			OIDCID:               "2a3b4c5d6e7f8g9h0i1j2k3l4m5n6o7p",
+			RolesRef:             rosacontrolplanev1.AWSRolesRef{IngressARN: "arn:aws:iam::123456789012:role/capa-openshift-ingress-operator-cloud-credentials"},
+			CredentialsSecretRef: &corev1.LocalObjectReference{Name: credentialsSecret.Name},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, rosaControlPlane)).To(Succeed())
+	rosaControlPlane.Status.ID = clusterID
+	rosaControlPlane.Status.Ready = true
+	g.Expect(testEnv.Status().Update(ctx, rosaControlPlane)).To(Succeed())
+
+	machinePool := &expclusterv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workers",
+			Namespace: ns.Name,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: cluster.Name,
+			},
+		},
+		Spec: expclusterv1.MachinePoolSpec{
+			ClusterName: cluster.Name,
+			Replicas:    ptr.To[int32](2),
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName: cluster.Name,
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: ptr.To[string](""),
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: expinfrav1.GroupVersion.String(),
+						Kind:       "ROSAMachinePool",
+						Name:       "workers",
+					},
+				},
+			},
+		},
+	}
+	g.Expect(testEnv.Create(ctx, machinePool)).To(Succeed())
+
+	rosaMachinePool := &expinfrav1.ROSAMachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workers",
+			Namespace: ns.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: expclusterv1.GroupVersion.String(),
+					Kind:       "MachinePool",
+					Name:       machinePool.Name,
+					UID:        machinePool.UID,
+				},
+			},
+		},
+		Spec: expinfrav1.RosaMachinePoolSpec{
+			NodePoolName: "workers",
+			Version:      "4.14.5",
+			Subnet:       "subnet-0ac99a6230b408813",
+			InstanceType: "m5.xlarge",
+			AutoRepair:   true,
+		},
+	}
+	g.Expect(testEnv.Create(ctx, rosaMachinePool)).To(Succeed())
+
+	defer func() {
+		g.Expect(testEnv.Cleanup(ctx, rosaMachinePool, machinePool, rosaControlPlane, cluster, credentialsSecret, controllerIdentity, ns)).To(Succeed())
+	}()
+
+	r := &ROSAMachinePoolReconciler{
+		Client:    testEnv,
+		Recorder:  record.NewFakeRecorder(10),
+		Endpoints: ocmServer.ServiceEndpoints(),
+	}
+	key := client.ObjectKeyFromObject(rosaMachinePool)
+	reconcile := func() (ctrl.Result, error) {
+		return r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	}
+	// the reconciler reads the objects from the cache of the manager, so the changes are awaited before
+	// reconciling again.
+	expectMachinePool := func(assertions func(g Gomega, machinePool *expinfrav1.ROSAMachinePool)) {
+		g.Eventually(func(g Gomega) {
+			machinePool := &expinfrav1.ROSAMachinePool{}
+			g.Expect(testEnv.Get(ctx, key, machinePool)).To(Succeed())
+			assertions(g, machinePool)
+		}, 10*time.Second).Should(Succeed())
+	}
+	g.Eventually(func(g Gomega) {
+		controlPlane := &rosacontrolplanev1.ROSAControlPlane{}
+		g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(rosaControlPlane), controlPlane)).To(Succeed())
+		g.Expect(controlPlane.Status.Ready).To(BeTrue())
+	}, 10*time.Second).Should(Succeed())
+
+	// the node pool is created.
+	expectMachinePool(func(Gomega, *expinfrav1.ROSAMachinePool) {})
+	_, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.Finalizers).To(ContainElement(expinfrav1.RosaMachinePoolFinalizer))
+		g.Expect(machinePool.Status.ID).To(Equal("workers"))
+	})
+
+	nodePool, found, err := ocmServer.GetNodePool(clusterID, "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(nodePool.Replicas()).To(Equal(2))
+	g.Expect(nodePool.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+	g.Expect(nodePool.Subnet()).To(Equal("subnet-0ac99a6230b408813"))
+	g.Expect(nodePool.Version().ID()).To(Equal("openshift-v4.14.5"))
+
+	// the machine pool isn't ready until the nodes are provisioned.
+	result, err := reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Second * 60))
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.Status.Ready).To(BeFalse())
+		g.Expect(conditions.IsTrue(machinePool, expinfrav1.RosaMachinePoolInSyncCondition)).To(BeTrue())
+	})
+
+	provisionedNodePool, err := cmv1.NewNodePool().
+		Status(cmv1.NewNodePoolStatus().CurrentReplicas(2)).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = ocmServer.UpdateNodePool(clusterID, "workers", provisionedNodePool)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	result, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(BeZero())
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.Status.Ready).To(BeTrue())
+		g.Expect(machinePool.Status.Replicas).To(BeEquivalentTo(2))
+		g.Expect(conditions.IsTrue(machinePool, expinfrav1.RosaMachinePoolReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsFalse(machinePool, expinfrav1.RosaMachinePoolUpgradingCondition)).To(BeTrue())
+	})
+	upgradePolicies, err := ocmServer.NodePoolUpgradePolicies(clusterID, "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(upgradePolicies).To(BeEmpty())
+
+	// changing the version schedules an upgrade of the node pool.
+	g.Eventually(func(g Gomega) {
+		machinePool := &expinfrav1.ROSAMachinePool{}
+		g.Expect(testEnv.Get(ctx, key, machinePool)).To(Succeed())
+		machinePool.Spec.Version = "4.14.6"
+		g.Expect(testEnv.Update(ctx, machinePool)).To(Succeed())
+	}, 10*time.Second).Should(Succeed())
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.Spec.Version).To(Equal("4.14.6"))
+	})
+	for i := 0; i < 2; i++ {
+		_, err = reconcile()
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// scheduled upgrades aren't scheduled again.
+		upgradePolicies, err = ocmServer.NodePoolUpgradePolicies(clusterID, "workers")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(upgradePolicies).To(HaveLen(1))
+		g.Expect(upgradePolicies[0].Version()).To(Equal("4.14.6"))
+	}
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(conditions.IsTrue(machinePool, expinfrav1.RosaMachinePoolUpgradingCondition)).To(BeTrue())
+	})
+
+	// the node pool is updated with the replicas of the MachinePool and the labels of the ROSAMachinePool.
+	g.Eventually(func(g Gomega) {
+		current := &expclusterv1.MachinePool{}
+		g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(machinePool), current)).To(Succeed())
+		current.Spec.Replicas = ptr.To[int32](3)
+		g.Expect(testEnv.Update(ctx, current)).To(Succeed())
+	}, 10*time.Second).Should(Succeed())
+	g.Eventually(func(g Gomega) {
+		machinePool := &expinfrav1.ROSAMachinePool{}
+		g.Expect(testEnv.Get(ctx, key, machinePool)).To(Succeed())
+		machinePool.Spec.Labels = map[string]string{"role": "worker"}
+		g.Expect(testEnv.Update(ctx, machinePool)).To(Succeed())
+	}, 10*time.Second).Should(Succeed())
+	g.Eventually(func(g Gomega) {
+		current := &expclusterv1.MachinePool{}
+		g.Expect(testEnv.Get(ctx, client.ObjectKeyFromObject(machinePool), current)).To(Succeed())
+		g.Expect(current.Spec.Replicas).To(Equal(ptr.To[int32](3)))
+	}, 10*time.Second).Should(Succeed())
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.Spec.Labels).To(HaveKeyWithValue("role", "worker"))
+	})
+
+	result, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(time.Second * 60))
+
+	nodePool, found, err = ocmServer.GetNodePool(clusterID, "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(nodePool.Replicas()).To(Equal(3))
+	g.Expect(nodePool.Labels()).To(HaveKeyWithValue("role", "worker"))
+	g.Expect(nodePool.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(conditions.IsFalse(machinePool, expinfrav1.RosaMachinePoolReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(machinePool, expinfrav1.RosaMachinePoolInSyncCondition)).To(BeTrue())
+	})
+
+	// the node pool is deleted before the finalizer is removed.
+	g.Expect(testEnv.Delete(ctx, rosaMachinePool)).To(Succeed())
+	expectMachinePool(func(g Gomega, machinePool *expinfrav1.ROSAMachinePool) {
+		g.Expect(machinePool.DeletionTimestamp.IsZero()).To(BeFalse())
+	})
+	_, err = reconcile()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, found, err = ocmServer.GetNodePool(clusterID, "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+	g.Eventually(func() bool {
+		return apierrors.IsNotFound(testEnv.Get(ctx, key, &expinfrav1.ROSAMachinePool{}))
+	}, 10*time.Second).Should(BeTrue())
+}
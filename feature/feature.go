@@ -86,6 +86,10 @@ const (
 	// owner: @enxebre
 	// alpha: v2.2
 	ROSA featuregate.Feature = "ROSA"
+
+	// ROSALogForwarding is used to enable the forwarding of the logs of ROSA HCP control plane applications, which is
+	// managed with raw OCM API requests as the OCM SDK doesn't support log forwarders yet.
+	ROSALogForwarding featuregate.Feature = "ROSALogForwarding"
 )
 
 func init() {
@@ -108,4 +112,5 @@ var defaultCAPAFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AlternativeGCStrategy:         {Default: false, PreRelease: featuregate.Alpha},
 	TagUnmanagedNetworkResources:  {Default: true, PreRelease: featuregate.Alpha},
 	ROSA:                          {Default: false, PreRelease: featuregate.Alpha},
+	ROSALogForwarding:             {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -240,6 +240,16 @@ func main() {
 			os.Exit(1)
 		}
 
+		setupLog.Debug("enabling ROSA role config controller")
+		if err := (&rosacontrolplanecontrollers.ROSARoleConfigReconciler{
+			Client:           mgr.GetClient(),
+			WatchFilterValue: watchFilterValue,
+			Endpoints:        awsServiceEndpoints,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ROSARoleConfig")
+			os.Exit(1)
+		}
+
 		setupLog.Debug("enabling ROSA cluster controller")
 		if err := (&controllers.ROSAClusterReconciler{
 			Client:           mgr.GetClient(),
@@ -433,6 +443,7 @@ func setupEKSReconcilersAndWebhooks(ctx context.Context, mgr ctrl.Manager, awsSe
 	if err := (&eksbootstrapcontrollers.EKSConfigReconciler{
 		Client:           mgr.GetClient(),
 		WatchFilterValue: watchFilterValue,
+		Endpoints:        awsServiceEndpoints,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: awsClusterConcurrency, RecoverPanic: ptr.To[bool](true)}); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "EKSConfig")
 		os.Exit(1)
@@ -19,6 +19,8 @@ package scope
 import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
 )
 
@@ -30,4 +32,8 @@ type KubeProxyScope interface {
 	RemoteClient() (client.Client, error)
 	// DisableKubeProxy returns whether kube-proxy daemonset is to be disabled
 	DisableKubeProxy() bool
+	// KubeProxy returns the kube-proxy configuration of the cluster.
+	KubeProxy() ekscontrolplanev1.KubeProxy
+	// VPC returns the VPC of the cluster.
+	VPC() *infrav1.VPCSpec
 }
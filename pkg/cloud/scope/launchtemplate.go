@@ -38,6 +38,8 @@ type LaunchTemplateScope interface {
 	GetLaunchTemplateLatestVersionStatus() string
 	SetLaunchTemplateLatestVersionStatus(version string)
 	GetRawBootstrapData() ([]byte, *types.NamespacedName, error)
+	// RequiresMultipartUserData returns true if the user data of the launch template must be a multipart MIME document.
+	RequiresMultipartUserData() bool
 
 	IsEKSManaged() bool
 	AdditionalTags() infrav1.Tags
@@ -277,6 +277,11 @@ func (m *MachinePoolScope) IsEKSManaged() bool {
 	return m.InfraCluster.InfraCluster().GetObjectKind().GroupVersionKind().Kind == ekscontrolplanev1.AWSManagedControlPlaneKind
 }
 
+// RequiresMultipartUserData returns false as the bootstrap data is used as is as the user data of the launch template.
+func (m *MachinePoolScope) RequiresMultipartUserData() bool {
+	return false
+}
+
 // SubnetIDs returns the machine pool subnet IDs.
 func (m *MachinePoolScope) SubnetIDs(subnetIDs []string) ([]string, error) {
 	strategy, err := newDefaultSubnetPlacementStrategy(&m.Logger)
@@ -310,9 +310,8 @@ func (s *ManagedControlPlaneScope) Bastion() *infrav1.Bastion {
 }
 
 // Bucket returns the bucket details.
-// For ManagedControlPlane this is always nil, as we don't support S3 buckets for managed clusters.
 func (s *ManagedControlPlaneScope) Bucket() *infrav1.S3Bucket {
-	return nil
+	return s.ControlPlane.Spec.S3Bucket
 }
 
 // TagUnmanagedNetworkResources returns if the feature flag tag unmanaged network resources is set.
@@ -397,6 +396,11 @@ func (s *ManagedControlPlaneScope) DisableKubeProxy() bool {
 	return s.ControlPlane.Spec.KubeProxy.Disable
 }
 
+// KubeProxy returns the kube-proxy configuration of the cluster.
+func (s *ManagedControlPlaneScope) KubeProxy() ekscontrolplanev1.KubeProxy {
+	return s.ControlPlane.Spec.KubeProxy
+}
+
 // DisableVPCCNI returns whether the AWS VPC CNI should be disabled.
 func (s *ManagedControlPlaneScope) DisableVPCCNI() bool {
 	return s.ControlPlane.Spec.VpcCni.Disable
@@ -267,6 +267,7 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 		s.ManagedMachinePool,
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			expinfrav1.EKSNodegroupReadyCondition,
+			expinfrav1.EKSNodegroupHealthyCondition,
 			expinfrav1.IAMNodegroupRolesReadyCondition,
 		}})
 }
@@ -325,7 +326,7 @@ func (s *ManagedMachinePoolScope) Namespace() string {
 	return s.ManagedMachinePool.Namespace
 }
 
-// GetRawBootstrapData returns the raw bootstrap data from the linked Machine's bootstrap.dataSecretName.
+// GetRawBootstrapData returns the bootstrap data from the linked Machine's bootstrap.dataSecretName.
 func (s *ManagedMachinePoolScope) GetRawBootstrapData() ([]byte, *types.NamespacedName, error) {
 	if s.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
 		return nil, nil, errors.New("error retrieving bootstrap data: linked Machine's bootstrap.dataSecretName is nil")
@@ -346,6 +347,12 @@ func (s *ManagedMachinePoolScope) GetRawBootstrapData() ([]byte, *types.Namespac
 	return value, &key, nil
 }
 
+// RequiresMultipartUserData returns true as EKS requires the user data of a launch template used
+// by a managed node group to be a multipart MIME document.
+func (s *ManagedMachinePoolScope) RequiresMultipartUserData() bool {
+	return true
+}
+
 // GetObjectMeta returns the ObjectMeta for the AWSManagedMachinePool.
 func (s *ManagedMachinePoolScope) GetObjectMeta() *metav1.ObjectMeta {
 	return &s.ManagedMachinePool.ObjectMeta
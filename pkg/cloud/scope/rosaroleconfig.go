@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"fmt"
+
+	awsclient "github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/throttle"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// RosaRoleConfigScopeParams defines the input parameters used to create a new RosaRoleConfigScope.
+type RosaRoleConfigScopeParams struct {
+	Client         client.Client
+	Logger         *logger.Logger
+	RoleConfig     *rosacontrolplanev1.ROSARoleConfig
+	ControllerName string
+	Endpoints      []ServiceEndpoint
+}
+
+// NewRosaRoleConfigScope creates a new RosaRoleConfigScope from the supplied parameters.
+func NewRosaRoleConfigScope(params RosaRoleConfigScopeParams) (*RosaRoleConfigScope, error) {
+	if params.RoleConfig == nil {
+		return nil, errors.New("failed to generate new scope from nil ROSARoleConfig")
+	}
+	if params.Logger == nil {
+		log := klog.Background()
+		params.Logger = logger.NewLogger(log)
+	}
+
+	roleConfigScope := &RosaRoleConfigScope{
+		Logger:         *params.Logger,
+		Client:         params.Client,
+		RoleConfig:     params.RoleConfig,
+		controllerName: params.ControllerName,
+	}
+
+	session, serviceLimiters, err := sessionForClusterWithRegion(params.Client, roleConfigScope, params.RoleConfig.Spec.Region, params.Endpoints, params.Logger)
+	if err != nil {
+		return nil, errors.Errorf("failed to create aws session: %v", err)
+	}
+
+	helper, err := patch.NewHelper(params.RoleConfig, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	roleConfigScope.patchHelper = helper
+	roleConfigScope.session = session
+	roleConfigScope.serviceLimiters = serviceLimiters
+
+	stsClient := NewSTSClient(roleConfigScope, roleConfigScope, roleConfigScope, roleConfigScope.RoleConfig)
+	identity, err := stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify the AWS caller: %w", err)
+	}
+	roleConfigScope.Identity = identity
+
+	return roleConfigScope, nil
+}
+
+// RosaRoleConfigScope defines the basic context for an actuator to operate upon a ROSARoleConfig.
+type RosaRoleConfigScope struct {
+	logger.Logger
+	Client      client.Client
+	patchHelper *patch.Helper
+
+	RoleConfig *rosacontrolplanev1.ROSARoleConfig
+
+	session         awsclient.ConfigProvider
+	serviceLimiters throttle.ServiceLimiters
+	controllerName  string
+	Identity        *sts.GetCallerIdentityOutput
+}
+
+var _ cloud.ScopeUsage = (*RosaRoleConfigScope)(nil)
+var _ cloud.Session = (*RosaRoleConfigScope)(nil)
+var _ cloud.SessionMetadata = (*RosaRoleConfigScope)(nil)
+
+// InfraCluster returns the ROSARoleConfig object.
+func (s *RosaRoleConfigScope) InfraCluster() cloud.ClusterObject {
+	return s.RoleConfig
+}
+
+// InfraClusterName returns the ROSARoleConfig name.
+func (s *RosaRoleConfigScope) InfraClusterName() string {
+	return s.RoleConfig.Name
+}
+
+// Namespace returns the ROSARoleConfig namespace.
+func (s *RosaRoleConfigScope) Namespace() string {
+	return s.RoleConfig.Namespace
+}
+
+// IdentityRef returns the AWSIdentityReference object.
+func (s *RosaRoleConfigScope) IdentityRef() *infrav1.AWSIdentityReference {
+	return s.RoleConfig.Spec.IdentityRef
+}
+
+// Session returns the AWS SDK session. Used for creating clients.
+func (s *RosaRoleConfigScope) Session() awsclient.ConfigProvider {
+	return s.session
+}
+
+// ServiceLimiter returns the AWS SDK session. Used for creating clients.
+func (s *RosaRoleConfigScope) ServiceLimiter(service string) *throttle.ServiceLimiter {
+	if sl, ok := s.serviceLimiters[service]; ok {
+		return sl
+	}
+	return nil
+}
+
+// ControllerName returns the name of the controller.
+func (s *RosaRoleConfigScope) ControllerName() string {
+	return s.controllerName
+}
+
+// GetClient returns the Kubernetes client.
+func (s *RosaRoleConfigScope) GetClient() client.Client {
+	return s.Client
+}
+
+// CredentialsSecret returns the CredentialsSecret object.
+func (s *RosaRoleConfigScope) CredentialsSecret() *corev1.Secret {
+	secretRef := s.RoleConfig.Spec.CredentialsSecretRef
+	if secretRef == nil {
+		return nil
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretRef.Name,
+			Namespace: s.RoleConfig.Namespace,
+		},
+	}
+}
+
+// PatchObject persists the ROSARoleConfig configuration and status.
+func (s *RosaRoleConfigScope) PatchObject() error {
+	return s.patchHelper.Patch(
+		context.TODO(),
+		s.RoleConfig,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			rosacontrolplanev1.ROSARoleConfigReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the ROSARoleConfig configuration and status.
+func (s *RosaRoleConfigScope) Close() error {
+	return s.PatchObject()
+}
@@ -18,7 +18,9 @@ package awsnode
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	amazoncni "github.com/aws/amazon-vpc-cni-k8s/pkg/apis/crd/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -34,15 +36,33 @@ import (
 	"sigs.k8s.io/kustomize/api/konfig"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
 	awsNodeName      = "aws-node"
+	awsNodeInitName  = "aws-vpc-cni-init"
 	awsNodeNamespace = "kube-system"
 )
 
+var (
+	// ipv6EnvironmentProperties are the environment properties of aws-node required for IPv6
+	// clusters. IPv6 requires prefix delegation.
+	ipv6EnvironmentProperties = map[string]string{
+		"ENABLE_IPv6":              "true",
+		"ENABLE_IPv4":              "false",
+		"ENABLE_PREFIX_DELEGATION": "true",
+	}
+
+	// ipv6InitEnvironmentProperties are the environment properties of the aws-node init
+	// container required for IPv6 clusters.
+	ipv6InitEnvironmentProperties = map[string]string{
+		"ENABLE_IPv6": "true",
+	}
+)
+
 // ReconcileCNI will reconcile the CNI of a service.
 func (s *Service) ReconcileCNI(ctx context.Context) error {
 	s.scope.Info("Reconciling aws-node DaemonSet in cluster", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
@@ -60,6 +80,17 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 		return nil
 	}
 
+	// The aws-node DaemonSet of a VPC CNI migrating to the vpc-cni addon is managed by the
+	// addon, only the ENIConfigs are still managed here.
+	if s.scope.VpcCni().MigrateToAddon != nil {
+		s.scope.Info("aws-node DaemonSet is managed by the vpc-cni addon, skipping", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+		secondarySubnets := s.secondarySubnets()
+		if len(secondarySubnets) == 0 {
+			return nil
+		}
+		return s.reconcileENIConfigs(ctx, remoteClient, secondarySubnets)
+	}
+
 	var ds appsv1.DaemonSet
 	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: awsNodeNamespace, Name: awsNodeName}, &ds); err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -80,6 +111,20 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 		}
 	}
 
+	if s.scope.VPC().IsIPv6Enabled() {
+		s.scope.Info("updating aws-node daemonset for ipv6", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+
+		updated := s.applyDefaultDaemonSetEnvironmentProperties(&ds, ipv6EnvironmentProperties, ipv6InitEnvironmentProperties)
+		needsUpdate = needsUpdate || updated
+	}
+
+	if env, initEnv := vpcCniModeEnvironmentProperties(s.scope.VpcCni()); len(env) > 0 || len(initEnv) > 0 {
+		s.scope.Info("updating aws-node daemonset for vpc cni modes", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+
+		updated := s.applyDefaultDaemonSetEnvironmentProperties(&ds, env, initEnv)
+		needsUpdate = needsUpdate || updated
+	}
+
 	secondarySubnets := s.secondarySubnets()
 	if len(secondarySubnets) == 0 {
 		if needsUpdate {
@@ -93,6 +138,17 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 		return nil
 	}
 
+	if err := s.reconcileENIConfigs(ctx, remoteClient, secondarySubnets); err != nil {
+		return err
+	}
+
+	s.scope.Info("updating containers", "cluster-name", s.scope.Name(), "cluster-namespace", s.scope.Namespace())
+	return remoteClient.Update(ctx, &ds, &client.UpdateOptions{})
+}
+
+// reconcileENIConfigs creates an ENIConfig for each secondary subnet, and removes the
+// ENIConfigs of secondary subnets which no longer exist.
+func (s *Service) reconcileENIConfigs(ctx context.Context, remoteClient client.Client, secondarySubnets []*infrav1.SubnetSpec) error {
 	sgs, err := s.getSecurityGroups()
 	if err != nil {
 		return err
@@ -150,7 +206,7 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 	}
 	for _, eniConfig := range eniConfigs.Items {
 		matchFound := false
-		for _, subnet := range s.secondarySubnets() {
+		for _, subnet := range secondarySubnets {
 			if eniConfig.Name == subnet.AvailabilityZone {
 				matchFound = true
 				break
@@ -166,8 +222,7 @@ func (s *Service) ReconcileCNI(ctx context.Context) error {
 		}
 	}
 
-	s.scope.Info("updating containers", "cluster-name", s.scope.Name(), "cluster-namespace", s.scope.Namespace())
-	return remoteClient.Update(ctx, &ds, &client.UpdateOptions{})
+	return nil
 }
 
 func (s *Service) getSecurityGroups() ([]string, error) {
@@ -216,6 +271,147 @@ func (s *Service) applyUserProvidedEnvironmentProperties(containerEnv []corev1.E
 	return containerEnv, needsUpdate
 }
 
+// vpcCniModeEnvironmentProperties returns the environment properties of aws-node and its init
+// container required for the enabled VPC CNI modes.
+func vpcCniModeEnvironmentProperties(vpcCni ekscontrolplanev1.VpcCni) (map[string]string, map[string]string) {
+	env := map[string]string{}
+	initEnv := map[string]string{}
+
+	if vpcCni.CustomNetworking {
+		env["AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG"] = "true"
+		// The ENIConfigs are named after the availability zones, so nodes select the
+		// ENIConfig of their zone by the zone label.
+		env["ENI_CONFIG_LABEL_DEF"] = corev1.LabelTopologyZone
+	}
+	if vpcCni.PrefixDelegation {
+		env["ENABLE_PREFIX_DELEGATION"] = "true"
+		env["WARM_PREFIX_TARGET"] = "1"
+	}
+	if vpcCni.PodSecurityGroups {
+		env["ENABLE_POD_ENI"] = "true"
+		// Liveness and readiness probes of pods with security groups require this.
+		initEnv["DISABLE_TCP_EARLY_DEMUX"] = "true"
+	}
+
+	return env, initEnv
+}
+
+// AddonConfiguration returns the configuration of the vpc-cni addon which preserves the
+// environment properties CAPA applies to the aws-node DaemonSet, so a VPC CNI migrating to the
+// addon keeps its behaviour. Environment properties provided by the user take precedence, those
+// referencing other values can't be expressed in the addon configuration and are skipped.
+func AddonConfiguration(vpcCni ekscontrolplanev1.VpcCni, ipv6 bool) (string, error) {
+	env := map[string]string{}
+	initEnv := map[string]string{}
+	if ipv6 {
+		for k, v := range ipv6EnvironmentProperties {
+			env[k] = v
+		}
+		for k, v := range ipv6InitEnvironmentProperties {
+			initEnv[k] = v
+		}
+	}
+	modeEnv, modeInitEnv := vpcCniModeEnvironmentProperties(vpcCni)
+	for k, v := range modeEnv {
+		env[k] = v
+	}
+	for k, v := range modeInitEnv {
+		initEnv[k] = v
+	}
+	for _, e := range vpcCni.Env {
+		if e.ValueFrom != nil {
+			continue
+		}
+		env[e.Name] = e.Value
+		if _, ok := initEnv[e.Name]; ok {
+			initEnv[e.Name] = e.Value
+		}
+	}
+
+	config := map[string]interface{}{}
+	if len(env) > 0 {
+		config["env"] = env
+	}
+	if len(initEnv) > 0 {
+		config["init"] = map[string]interface{}{"env": initEnv}
+	}
+	if len(config) == 0 {
+		return "", nil
+	}
+
+	// The keys of maps are sorted, so the configuration is stable.
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("marshalling vpc-cni addon configuration: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// applyDefaultDaemonSetEnvironmentProperties applies default values to the environment of the
+// aws-node container and its init container.
+func (s *Service) applyDefaultDaemonSetEnvironmentProperties(ds *appsv1.DaemonSet, defaults, initDefaults map[string]string) bool {
+	needsUpdate := false
+	for i := range ds.Spec.Template.Spec.Containers {
+		container := &ds.Spec.Template.Spec.Containers[i]
+		if container.Name == awsNodeName {
+			var updated bool
+			container.Env, updated = s.applyDefaultEnvironmentProperties(container.Env, defaults)
+			needsUpdate = needsUpdate || updated
+		}
+	}
+	for i := range ds.Spec.Template.Spec.InitContainers {
+		container := &ds.Spec.Template.Spec.InitContainers[i]
+		if container.Name == awsNodeInitName {
+			var updated bool
+			container.Env, updated = s.applyDefaultEnvironmentProperties(container.Env, initDefaults)
+			needsUpdate = needsUpdate || updated
+		}
+	}
+
+	return needsUpdate
+}
+
+// applyDefaultEnvironmentProperties applies default values to a container environment. Values
+// provided by the user take precedence over the defaults.
+func (s *Service) applyDefaultEnvironmentProperties(containerEnv []corev1.EnvVar, defaults map[string]string) ([]corev1.EnvVar, bool) {
+	userProvided := make(map[string]bool)
+	for _, e := range s.scope.VpcCni().Env {
+		userProvided[e.Name] = true
+	}
+
+	needsUpdate := false
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if userProvided[name] {
+			continue
+		}
+		value := defaults[name]
+		found := false
+		for i := range containerEnv {
+			if containerEnv[i].Name != name {
+				continue
+			}
+			found = true
+			if containerEnv[i].Value != value || containerEnv[i].ValueFrom != nil {
+				needsUpdate = true
+				containerEnv[i] = corev1.EnvVar{Name: name, Value: value}
+			}
+		}
+		if !found {
+			needsUpdate = true
+			containerEnv = append(containerEnv, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+
+	return containerEnv, needsUpdate
+}
+
 func (s *Service) deleteCNI(ctx context.Context, remoteClient client.Client) error {
 	// EKS has a tendency to pre-install the vpc-cni automagically even if you don't specify it as an addon
 	// and looks like a kubectl apply from a script of a manifest that looks like this
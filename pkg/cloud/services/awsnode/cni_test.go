@@ -257,6 +257,328 @@ func TestReconcileCniVpcCniValues(t *testing.T) {
 	}
 }
 
+func TestReconcileCNIIPv6(t *testing.T) {
+	tests := []struct {
+		name           string
+		cniValues      ekscontrolplanev1.VpcCni
+		env            []corev1.EnvVar
+		consistsOf     []corev1.EnvVar
+		initConsistsOf []corev1.EnvVar
+	}{
+		{
+			name: "ipv6 environment values are added",
+			env: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_IPv6",
+					Value: "false",
+				},
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_IPv6",
+					Value: "true",
+				},
+				{
+					Name:  "ENABLE_IPv4",
+					Value: "false",
+				},
+				{
+					Name:  "ENABLE_PREFIX_DELEGATION",
+					Value: "true",
+				},
+			},
+			initConsistsOf: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_IPv6",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "users can override ipv6 environment values",
+			cniValues: ekscontrolplanev1.VpcCni{
+				Env: []corev1.EnvVar{
+					{
+						Name:  "ENABLE_PREFIX_DELEGATION",
+						Value: "false",
+					},
+				},
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_IPv6",
+					Value: "true",
+				},
+				{
+					Name:  "ENABLE_IPv4",
+					Value: "false",
+				},
+				{
+					Name:  "ENABLE_PREFIX_DELEGATION",
+					Value: "false",
+				},
+			},
+			initConsistsOf: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_IPv6",
+					Value: "true",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockClient := &cachingClient{
+				getValue: &v1.DaemonSet{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "DaemonSet",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      awsNodeName,
+						Namespace: awsNodeNamespace,
+					},
+					Spec: v1.DaemonSetSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								InitContainers: []corev1.Container{
+									{
+										Name: awsNodeInitName,
+									},
+								},
+								Containers: []corev1.Container{
+									{
+										Name: awsNodeName,
+										Env:  tc.env,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			m := &mockScope{
+				client: mockClient,
+				cni:    tc.cniValues,
+				vpc: infrav1.VPCSpec{
+					IPv6: &infrav1.IPv6{},
+				},
+			}
+			s := NewService(m)
+
+			err := s.ReconcileCNI(context.Background())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(mockClient.updateChain).NotTo(BeEmpty())
+			ds, ok := mockClient.updateChain[0].(*v1.DaemonSet)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(tc.consistsOf))
+			g.Expect(ds.Spec.Template.Spec.InitContainers[0].Env).To(ConsistOf(tc.initConsistsOf))
+		})
+	}
+}
+
+func TestReconcileCNIModes(t *testing.T) {
+	tests := []struct {
+		name           string
+		cniValues      ekscontrolplanev1.VpcCni
+		consistsOf     []corev1.EnvVar
+		initConsistsOf []corev1.EnvVar
+	}{
+		{
+			name: "custom networking and prefix delegation environment values are added",
+			cniValues: ekscontrolplanev1.VpcCni{
+				CustomNetworking: true,
+				PrefixDelegation: true,
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "AWS_VPC_K8S_CNI_CUSTOM_NETWORK_CFG",
+					Value: "true",
+				},
+				{
+					Name:  "ENI_CONFIG_LABEL_DEF",
+					Value: "topology.kubernetes.io/zone",
+				},
+				{
+					Name:  "ENABLE_PREFIX_DELEGATION",
+					Value: "true",
+				},
+				{
+					Name:  "WARM_PREFIX_TARGET",
+					Value: "1",
+				},
+			},
+		},
+		{
+			name: "pod security groups environment values are added",
+			cniValues: ekscontrolplanev1.VpcCni{
+				PodSecurityGroups: true,
+				Env: []corev1.EnvVar{
+					{
+						Name:  "NAME1",
+						Value: "VALUE1",
+					},
+				},
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "NAME1",
+					Value: "VALUE1",
+				},
+				{
+					Name:  "ENABLE_POD_ENI",
+					Value: "true",
+				},
+			},
+			initConsistsOf: []corev1.EnvVar{
+				{
+					Name:  "DISABLE_TCP_EARLY_DEMUX",
+					Value: "true",
+				},
+			},
+		},
+		{
+			name: "users can override mode environment values",
+			cniValues: ekscontrolplanev1.VpcCni{
+				PrefixDelegation: true,
+				Env: []corev1.EnvVar{
+					{
+						Name:  "WARM_PREFIX_TARGET",
+						Value: "2",
+					},
+				},
+			},
+			consistsOf: []corev1.EnvVar{
+				{
+					Name:  "ENABLE_PREFIX_DELEGATION",
+					Value: "true",
+				},
+				{
+					Name:  "WARM_PREFIX_TARGET",
+					Value: "2",
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mockClient := &cachingClient{
+				getValue: &v1.DaemonSet{
+					TypeMeta: metav1.TypeMeta{
+						Kind: "DaemonSet",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      awsNodeName,
+						Namespace: awsNodeNamespace,
+					},
+					Spec: v1.DaemonSetSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								InitContainers: []corev1.Container{
+									{
+										Name: awsNodeInitName,
+									},
+								},
+								Containers: []corev1.Container{
+									{
+										Name: awsNodeName,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			m := &mockScope{
+				client: mockClient,
+				cni:    tc.cniValues,
+			}
+			s := NewService(m)
+
+			err := s.ReconcileCNI(context.Background())
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(mockClient.updateChain).NotTo(BeEmpty())
+			ds, ok := mockClient.updateChain[0].(*v1.DaemonSet)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(tc.consistsOf))
+			g.Expect(ds.Spec.Template.Spec.InitContainers[0].Env).To(ConsistOf(tc.initConsistsOf))
+		})
+	}
+}
+
+func TestReconcileCNIMigrateToAddon(t *testing.T) {
+	g := NewWithT(t)
+	mockClient := &cachingClient{
+		getValue: &v1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      awsNodeName,
+				Namespace: awsNodeNamespace,
+			},
+		},
+	}
+	m := &mockScope{
+		client: mockClient,
+		cni: ekscontrolplanev1.VpcCni{
+			PrefixDelegation: true,
+			MigrateToAddon:   &ekscontrolplanev1.AddonMigration{Version: "default"},
+		},
+	}
+	s := NewService(m)
+
+	err := s.ReconcileCNI(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(mockClient.updateChain).To(BeEmpty())
+}
+
+func TestAddonConfiguration(t *testing.T) {
+	tests := []struct {
+		name     string
+		vpcCni   ekscontrolplanev1.VpcCni
+		ipv6     bool
+		expected string
+	}{
+		{
+			name:     "no environment values",
+			expected: "",
+		},
+		{
+			name: "mode and user environment values",
+			vpcCni: ekscontrolplanev1.VpcCni{
+				PrefixDelegation:  true,
+				PodSecurityGroups: true,
+				Env: []corev1.EnvVar{
+					{
+						Name:  "WARM_PREFIX_TARGET",
+						Value: "2",
+					},
+					{
+						Name: "MY_NODE_NAME",
+						ValueFrom: &corev1.EnvVarSource{
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+						},
+					},
+				},
+			},
+			expected: `{"env":{"ENABLE_POD_ENI":"true","ENABLE_PREFIX_DELEGATION":"true","WARM_PREFIX_TARGET":"2"},"init":{"env":{"DISABLE_TCP_EARLY_DEMUX":"true"}}}`,
+		},
+		{
+			name:     "ipv6 environment values",
+			ipv6:     true,
+			expected: `{"env":{"ENABLE_IPv4":"false","ENABLE_IPv6":"true","ENABLE_PREFIX_DELEGATION":"true"},"init":{"env":{"ENABLE_IPv6":"true"}}}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config, err := AddonConfiguration(tc.vpcCni, tc.ipv6)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(config).To(Equal(tc.expected))
+		})
+	}
+}
+
 type cachingClient struct {
 	client.Client
 	getValue    client.Object
@@ -287,6 +609,7 @@ type mockScope struct {
 	secondaryCidrBlock *string
 	securityGroups     map[infrav1.SecurityGroupRole]infrav1.SecurityGroup
 	subnets            infrav1.Subnets
+	vpc                infrav1.VPCSpec
 }
 
 func (s *mockScope) RemoteClient() (client.Client, error) {
@@ -324,3 +647,7 @@ func (s *mockScope) SecurityGroups() map[infrav1.SecurityGroupRole]infrav1.Secur
 func (s *mockScope) Subnets() infrav1.Subnets {
 	return s.subnets
 }
+
+func (s *mockScope) VPC() *infrav1.VPCSpec {
+	return &s.vpc
+}
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/userdata"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/internal/mime"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -68,7 +69,10 @@ func (s *Service) ReconcileLaunchTemplate(
 		record.Eventf(scope.GetMachinePool(), corev1.EventTypeWarning, "FailedGetBootstrapData", err.Error())
 		return err
 	}
-	bootstrapDataHash := userdata.ComputeHash(bootstrapData)
+	userData, err := launchTemplateUserData(scope, bootstrapData)
+	if err != nil {
+		return err
+	}
 
 	scope.Info("checking for existing launch template")
 	launchTemplate, launchTemplateUserDataHash, launchTemplateUserDataSecretKey, err := ec2svc.GetLaunchTemplate(scope.LaunchTemplateName())
@@ -85,7 +89,7 @@ func (s *Service) ReconcileLaunchTemplate(
 
 	if launchTemplate == nil {
 		scope.Info("no existing launch template found, creating")
-		launchTemplateID, err := ec2svc.CreateLaunchTemplate(scope, imageID, *bootstrapDataSecretKey, bootstrapData)
+		launchTemplateID, err := ec2svc.CreateLaunchTemplate(scope, imageID, *bootstrapDataSecretKey, userData)
 		if err != nil {
 			conditions.MarkFalse(scope.GetSetter(), expinfrav1.LaunchTemplateReadyCondition, expinfrav1.LaunchTemplateCreateFailedReason, clusterv1.ConditionSeverityError, err.Error())
 			return err
@@ -151,7 +155,7 @@ func (s *Service) ReconcileLaunchTemplate(
 		}
 	}
 
-	userDataHashChanged := launchTemplateUserDataHash != bootstrapDataHash
+	userDataHashChanged := userDataChanged(launchTemplateUserDataHash, bootstrapData, userData)
 
 	// Create a new launch template version if there's a difference in configuration, tags,
 	// userdata, OR we've discovered a new AMI ID.
@@ -162,7 +166,7 @@ func (s *Service) ReconcileLaunchTemplate(
 		if err := ec2svc.PruneLaunchTemplateVersions(scope.GetLaunchTemplateIDStatus()); err != nil {
 			return err
 		}
-		if err := ec2svc.CreateLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, bootstrapData); err != nil {
+		if err := ec2svc.CreateLaunchTemplateVersion(scope.GetLaunchTemplateIDStatus(), scope, imageID, *bootstrapDataSecretKey, userData); err != nil {
 			return err
 		}
 		version, err := ec2svc.GetLaunchTemplateLatestVersion(scope.GetLaunchTemplateIDStatus())
@@ -535,6 +539,23 @@ func (s *Service) createLaunchTemplateData(scope scope.LaunchTemplateScope, imag
 		}
 	}
 
+	// Set up non-root volumes
+	for i := range lt.NonRootVolumes {
+		if lt.NonRootVolumes[i].DeviceName == "" {
+			return nil, errors.New("non root volume should have device name specified")
+		}
+		data.BlockDeviceMappings = append(data.BlockDeviceMappings, volumeToLaunchTemplateBlockDeviceMappingRequest(&lt.NonRootVolumes[i]))
+	}
+
+	data.Placement = getLaunchTemplatePlacementRequest(lt)
+	data.CapacityReservationSpecification = getLaunchTemplateCapacityReservationSpecificationRequest(lt.CapacityReservationID)
+
+	// The security groups have to be set on the network interfaces when they are specified.
+	if len(lt.NetworkInterfaces) > 0 {
+		data.NetworkInterfaces = getLaunchTemplateNetworkInterfacesRequest(lt.NetworkInterfaces, data.SecurityGroupIds)
+		data.SecurityGroupIds = nil
+	}
+
 	data.TagSpecifications = s.buildLaunchTemplateTagSpecificationRequest(scope, userDataSecretKey)
 
 	return data, nil
@@ -711,7 +732,61 @@ func (s *Service) SDKToLaunchTemplate(d *ec2.LaunchTemplateVersion) (*expinfrav1
 		}
 	}
 
-	for _, id := range v.SecurityGroupIds {
+	// The root device name is only known from the AMI.
+	var rootDeviceName string
+	if len(v.BlockDeviceMappings) > 0 && v.ImageId != nil {
+		name, err := s.getImageRootDevice(aws.StringValue(v.ImageId))
+		if err != nil {
+			return nil, "", nil, errors.Wrapf(err, "failed to get root device name of image %q", aws.StringValue(v.ImageId))
+		}
+		rootDeviceName = aws.StringValue(name)
+	}
+
+	for _, mapping := range v.BlockDeviceMappings {
+		if mapping.Ebs == nil {
+			continue
+		}
+		volume := infrav1.Volume{
+			DeviceName:    aws.StringValue(mapping.DeviceName),
+			Size:          aws.Int64Value(mapping.Ebs.VolumeSize),
+			Type:          infrav1.VolumeType(aws.StringValue(mapping.Ebs.VolumeType)),
+			IOPS:          aws.Int64Value(mapping.Ebs.Iops),
+			Throughput:    mapping.Ebs.Throughput,
+			Encrypted:     mapping.Ebs.Encrypted,
+			EncryptionKey: aws.StringValue(mapping.Ebs.KmsKeyId),
+		}
+		if volume.DeviceName == rootDeviceName {
+			i.RootVolume = &volume
+			continue
+		}
+		i.NonRootVolumes = append(i.NonRootVolumes, volume)
+	}
+
+	if v.Placement != nil {
+		i.PlacementGroupName = aws.StringValue(v.Placement.GroupName)
+		i.PlacementGroupPartition = aws.Int64Value(v.Placement.PartitionNumber)
+		i.Tenancy = aws.StringValue(v.Placement.Tenancy)
+	}
+
+	if v.CapacityReservationSpecification != nil && v.CapacityReservationSpecification.CapacityReservationTarget != nil {
+		i.CapacityReservationID = v.CapacityReservationSpecification.CapacityReservationTarget.CapacityReservationId
+	}
+
+	securityGroupIDs := v.SecurityGroupIds
+	for _, ni := range v.NetworkInterfaces {
+		i.NetworkInterfaces = append(i.NetworkInterfaces, expinfrav1.LaunchTemplateNetworkInterface{
+			DeviceIndex:              aws.Int64Value(ni.DeviceIndex),
+			NetworkCardIndex:         aws.Int64Value(ni.NetworkCardIndex),
+			InterfaceType:            aws.StringValue(ni.InterfaceType),
+			AssociatePublicIPAddress: ni.AssociatePublicIpAddress,
+		})
+		// The security groups are set on every network interface when network interfaces are specified.
+		if len(securityGroupIDs) == 0 {
+			securityGroupIDs = ni.Groups
+		}
+	}
+
+	for _, id := range securityGroupIDs {
 		// FIXME(dlipovetsky): This will include the core security groups as well, making the
 		// "Additional" a bit dishonest. However, including the core groups drastically simplifies
 		// comparison with the incoming security groups.
@@ -761,6 +836,21 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 		return true, nil
 	}
 
+	if !nonRootVolumesEqual(incoming, existing) {
+		return true, nil
+	}
+	if incoming.PlacementGroupName != existing.PlacementGroupName ||
+		incoming.PlacementGroupPartition != existing.PlacementGroupPartition ||
+		incoming.Tenancy != existing.Tenancy {
+		return true, nil
+	}
+	if aws.StringValue(incoming.CapacityReservationID) != aws.StringValue(existing.CapacityReservationID) {
+		return true, nil
+	}
+	if !networkInterfacesEqual(incoming.NetworkInterfaces, existing.NetworkInterfaces) {
+		return true, nil
+	}
+
 	incomingIDs, err := s.GetAdditionalSecurityGroupsIDs(incoming.AdditionalSecurityGroups)
 	if err != nil {
 		return false, err
@@ -786,6 +876,98 @@ func (s *Service) LaunchTemplateNeedsUpdate(scope scope.LaunchTemplateScope, inc
 	return false, nil
 }
 
+// nonRootVolumesEqual checks if the incoming non-root volumes match the non-root volumes of the existing
+// launch template.
+func nonRootVolumesEqual(incoming, existing *expinfrav1.AWSLaunchTemplate) bool {
+	if len(incoming.NonRootVolumes) != len(existing.NonRootVolumes) {
+		return false
+	}
+
+	existingVolumes := make(map[string]infrav1.Volume, len(existing.NonRootVolumes))
+	for _, v := range existing.NonRootVolumes {
+		existingVolumes[v.DeviceName] = v
+	}
+
+	for _, v := range incoming.NonRootVolumes {
+		e, ok := existingVolumes[v.DeviceName]
+		if !ok || !launchTemplateVolumeEqual(v, e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// networkInterfacesEqual checks if the incoming network interfaces match the network interfaces of the
+// existing launch template, regardless of their order.
+func networkInterfacesEqual(incoming, existing []expinfrav1.LaunchTemplateNetworkInterface) bool {
+	if len(incoming) != len(existing) {
+		return false
+	}
+
+	type key struct{ networkCardIndex, deviceIndex int64 }
+	existingInterfaces := make(map[key]expinfrav1.LaunchTemplateNetworkInterface, len(existing))
+	for _, ni := range existing {
+		existingInterfaces[key{ni.NetworkCardIndex, ni.DeviceIndex}] = ni
+	}
+
+	for _, ni := range incoming {
+		e, ok := existingInterfaces[key{ni.NetworkCardIndex, ni.DeviceIndex}]
+		if !ok {
+			return false
+		}
+		if networkInterfaceType(ni) != networkInterfaceType(e) || !cmp.Equal(ni.AssociatePublicIPAddress, e.AssociatePublicIPAddress) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// networkInterfaceType returns the type of a network interface, which defaults to interface.
+func networkInterfaceType(ni expinfrav1.LaunchTemplateNetworkInterface) string {
+	if ni.InterfaceType == "" {
+		return ec2.NetworkInterfaceTypeInterface
+	}
+	return ni.InterfaceType
+}
+
+// launchTemplateVolumeEqual compares volumes the way they are set in a launch template block device mapping.
+func launchTemplateVolumeEqual(a, b infrav1.Volume) bool {
+	aEncrypted := aws.BoolValue(a.Encrypted) || a.EncryptionKey != ""
+	bEncrypted := aws.BoolValue(b.Encrypted) || b.EncryptionKey != ""
+
+	return a.Size == b.Size &&
+		a.Type == b.Type &&
+		a.IOPS == b.IOPS &&
+		aws.Int64Value(a.Throughput) == aws.Int64Value(b.Throughput) &&
+		a.EncryptionKey == b.EncryptionKey &&
+		aEncrypted == bEncrypted
+}
+
+// launchTemplateUserData returns the user data of the launch template for the bootstrap data, wrapped in a
+// multipart MIME document when the scope requires it.
+func launchTemplateUserData(scope scope.LaunchTemplateScope, bootstrapData []byte) ([]byte, error) {
+	if !scope.RequiresMultipartUserData() {
+		return bootstrapData, nil
+	}
+
+	userData, err := mime.GenerateMultipartDocument(bootstrapData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate multipart MIME user data")
+	}
+	return userData, nil
+}
+
+// userDataChanged returns true if the user data of the launch template, identified by its hash, differs from the
+// user data generated for the bootstrap data. Launch templates created before the user data was wrapped in a
+// multipart MIME document still hold the bootstrap data itself, which isn't considered a change so that upgrading
+// the controller doesn't roll the nodes. Their user data is wrapped when a new version is created for another reason.
+func userDataChanged(launchTemplateUserDataHash string, bootstrapData, userData []byte) bool {
+	return launchTemplateUserDataHash != userdata.ComputeHash(userData) &&
+		launchTemplateUserDataHash != userdata.ComputeHash(bootstrapData)
+}
+
 // DiscoverLaunchTemplateAMI will discover the AMI launch template.
 func (s *Service) DiscoverLaunchTemplateAMI(scope scope.LaunchTemplateScope) (*string, error) {
 	lt := scope.GetLaunchTemplate()
@@ -972,6 +1154,58 @@ func getLaunchTemplateInstanceMarketOptionsRequest(spotMarketOptions *infrav1.Sp
 	return launchTemplateInstanceMarketOptionsRequest
 }
 
+func getLaunchTemplatePlacementRequest(lt *expinfrav1.AWSLaunchTemplate) *ec2.LaunchTemplatePlacementRequest {
+	if lt.PlacementGroupName == "" && lt.Tenancy == "" {
+		return nil
+	}
+
+	placement := &ec2.LaunchTemplatePlacementRequest{}
+	if lt.PlacementGroupName != "" {
+		placement.GroupName = aws.String(lt.PlacementGroupName)
+		if lt.PlacementGroupPartition != 0 {
+			placement.PartitionNumber = aws.Int64(lt.PlacementGroupPartition)
+		}
+	}
+	if lt.Tenancy != "" {
+		placement.Tenancy = aws.String(lt.Tenancy)
+	}
+
+	return placement
+}
+
+func getLaunchTemplateNetworkInterfacesRequest(networkInterfaces []expinfrav1.LaunchTemplateNetworkInterface, securityGroupIDs []*string) []*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest {
+	requests := make([]*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest, 0, len(networkInterfaces))
+	for _, ni := range networkInterfaces {
+		request := &ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			DeviceIndex:              aws.Int64(ni.DeviceIndex),
+			AssociatePublicIpAddress: ni.AssociatePublicIPAddress,
+			DeleteOnTermination:      aws.Bool(true),
+			Groups:                   securityGroupIDs,
+		}
+		if ni.NetworkCardIndex != 0 {
+			request.NetworkCardIndex = aws.Int64(ni.NetworkCardIndex)
+		}
+		if ni.InterfaceType != "" {
+			request.InterfaceType = aws.String(ni.InterfaceType)
+		}
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+func getLaunchTemplateCapacityReservationSpecificationRequest(capacityReservationID *string) *ec2.LaunchTemplateCapacityReservationSpecificationRequest {
+	if capacityReservationID == nil {
+		return nil
+	}
+
+	return &ec2.LaunchTemplateCapacityReservationSpecificationRequest{
+		CapacityReservationTarget: &ec2.CapacityReservationTarget{
+			CapacityReservationId: capacityReservationID,
+		},
+	}
+}
+
 func getLaunchTemplatePrivateDNSNameOptionsRequest(privateDNSName *infrav1.PrivateDNSName) *ec2.LaunchTemplatePrivateDnsNameOptionsRequest {
 	if privateDNSName == nil {
 		return nil
@@ -199,6 +199,11 @@ func TestGetLaunchTemplate(t *testing.T) {
 						},
 					},
 				}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					ImageIds: []*string{aws.String("foo-image")},
+				})).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{{RootDeviceName: aws.String("/dev/xvda")}},
+				}, nil)
 			},
 			check: func(g *WithT, launchTemplate *expinfrav1.AWSLaunchTemplate, userDataHash string, err error) {
 				wantLT := &expinfrav1.AWSLaunchTemplate{
@@ -210,6 +215,8 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NonRootVolumes:           []infrav1.Volume{{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)}},
+					NetworkInterfaces:        []expinfrav1.LaunchTemplateNetworkInterface{{DeviceIndex: 1}},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -257,6 +264,11 @@ func TestGetLaunchTemplate(t *testing.T) {
 						},
 					},
 				}, nil)
+				m.DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+					ImageIds: []*string{aws.String("foo-image")},
+				})).Return(&ec2.DescribeImagesOutput{
+					Images: []*ec2.Image{{RootDeviceName: aws.String("/dev/xvda")}},
+				}, nil)
 			},
 			check: func(g *WithT, launchTemplate *expinfrav1.AWSLaunchTemplate, userDataHash string, err error) {
 				wantLT := &expinfrav1.AWSLaunchTemplate{
@@ -268,6 +280,8 @@ func TestGetLaunchTemplate(t *testing.T) {
 					SSHKeyName:               aws.String("foo-keyname"),
 					VersionNumber:            aws.Int64(1),
 					AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("sg-id")}},
+					NonRootVolumes:           []infrav1.Volume{{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)}},
+					NetworkInterfaces:        []expinfrav1.LaunchTemplateNetworkInterface{{DeviceIndex: 1}},
 				}
 
 				g.Expect(err).NotTo(HaveOccurred())
@@ -302,6 +316,9 @@ func TestGetLaunchTemplate(t *testing.T) {
 }
 
 func TestServiceSDKToLaunchTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
 	tests := []struct {
 		name              string
 		input             *ec2.LaunchTemplateVersion
@@ -346,9 +363,12 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("foo-image"),
 				},
-				IamInstanceProfile: "foo-profile",
-				SSHKeyName:         aws.String("foo-keyname"),
-				VersionNumber:      aws.Int64(1),
+				IamInstanceProfile:       "foo-profile",
+				SSHKeyName:               aws.String("foo-keyname"),
+				VersionNumber:            aws.Int64(1),
+				NonRootVolumes:           []infrav1.Volume{{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)}},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}},
+				NetworkInterfaces:        []expinfrav1.LaunchTemplateNetworkInterface{{DeviceIndex: 1}},
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: nil, // respective tag is not given
@@ -400,17 +420,84 @@ func TestServiceSDKToLaunchTemplate(t *testing.T) {
 				AMI: infrav1.AMIReference{
 					ID: aws.String("foo-image"),
 				},
-				IamInstanceProfile: "foo-profile",
-				SSHKeyName:         aws.String("foo-keyname"),
-				VersionNumber:      aws.Int64(1),
+				IamInstanceProfile:       "foo-profile",
+				SSHKeyName:               aws.String("foo-keyname"),
+				VersionNumber:            aws.Int64(1),
+				NonRootVolumes:           []infrav1.Volume{{DeviceName: "foo-device", Size: 16, Type: "cool", Encrypted: aws.Bool(true)}},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{{ID: aws.String("foo-group")}},
+				NetworkInterfaces:        []expinfrav1.LaunchTemplateNetworkInterface{{DeviceIndex: 1}},
 			},
 			wantHash:          testUserDataHash,
 			wantDataSecretKey: &types.NamespacedName{Namespace: "bootstrap-secret-ns", Name: "bootstrap-secret"},
 		},
+		{
+			name: "placement, capacity reservation and non-root volumes",
+			input: &ec2.LaunchTemplateVersion{
+				LaunchTemplateId:   aws.String("lt-12345"),
+				LaunchTemplateName: aws.String("foo"),
+				LaunchTemplateData: &ec2.ResponseLaunchTemplateData{
+					ImageId: aws.String("foo-image"),
+					BlockDeviceMappings: []*ec2.LaunchTemplateBlockDeviceMapping{
+						{
+							DeviceName: aws.String("/dev/xvda"),
+							Ebs: &ec2.LaunchTemplateEbsBlockDevice{
+								VolumeSize: aws.Int64(16),
+								VolumeType: aws.String("gp3"),
+							},
+						},
+						{
+							DeviceName: aws.String("/dev/xvdb"),
+							Ebs: &ec2.LaunchTemplateEbsBlockDevice{
+								VolumeSize: aws.Int64(100),
+								VolumeType: aws.String("io2"),
+								Iops:       aws.Int64(3000),
+								Encrypted:  aws.Bool(true),
+								KmsKeyId:   aws.String("kms-key"),
+							},
+						},
+					},
+					Placement: &ec2.LaunchTemplatePlacement{
+						GroupName:       aws.String("placement-group"),
+						PartitionNumber: aws.Int64(2),
+						Tenancy:         aws.String("dedicated"),
+					},
+					CapacityReservationSpecification: &ec2.LaunchTemplateCapacityReservationSpecificationResponse{
+						CapacityReservationTarget: &ec2.CapacityReservationTargetResponse{
+							CapacityReservationId: aws.String("cr-12345"),
+						},
+					},
+					UserData: aws.String(base64.StdEncoding.EncodeToString([]byte(testUserData))),
+				},
+				VersionNumber: aws.Int64(1),
+			},
+			wantLT: &expinfrav1.AWSLaunchTemplate{
+				Name: "foo",
+				AMI: infrav1.AMIReference{
+					ID: aws.String("foo-image"),
+				},
+				VersionNumber: aws.Int64(1),
+				RootVolume:    &infrav1.Volume{DeviceName: "/dev/xvda", Size: 16, Type: "gp3"},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100, Type: "io2", IOPS: 3000, Encrypted: aws.Bool(true), EncryptionKey: "kms-key"},
+				},
+				PlacementGroupName:      "placement-group",
+				PlacementGroupPartition: 2,
+				Tenancy:                 "dedicated",
+				CapacityReservationID:   aws.String("cr-12345"),
+			},
+			wantHash: testUserDataHash,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Service{}
+			ec2Mock := mocks.NewMockEC2API(mockCtrl)
+			ec2Mock.EXPECT().DescribeImagesWithContext(context.TODO(), gomock.Eq(&ec2.DescribeImagesInput{
+				ImageIds: []*string{aws.String("foo-image")},
+			})).Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{{RootDeviceName: aws.String("/dev/xvda")}},
+			}, nil).AnyTimes()
+
+			s := &Service{EC2Client: ec2Mock}
 			gotLT, gotHash, gotDataSecretKey, err := s.SDKToLaunchTemplate(tt.input)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("error mismatch: got %v, wantErr %v", err, tt.wantErr)
@@ -544,6 +631,123 @@ func TestServiceLaunchTemplateNeedsUpdate(t *testing.T) {
 			want:     true,
 			wantErr:  false,
 		},
+		{
+			name: "the same non-root volumes with a root volume",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100, Type: "gp3", EncryptionKey: "kms-key"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{DeviceName: "/dev/xvda", Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100, Type: "gp3", Encrypted: aws.Bool(true), EncryptionKey: "kms-key"},
+				},
+				AdditionalSecurityGroups: []infrav1.AWSResourceReference{
+					{ID: aws.String("sg-111")},
+					{ID: aws.String("sg-222")},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "new non-root volume",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{DeviceName: "/dev/xvda", Size: 16},
+			},
+			want: true,
+		},
+		{
+			name: "non-root volume removed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{DeviceName: "/dev/xvda", Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "non-root volume replaced",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				RootVolume: &infrav1.Volume{DeviceName: "/dev/xvda", Size: 16},
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100},
+					{DeviceName: "/dev/xvdc", Size: 100},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "new network interface",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0, InterfaceType: "efa"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "network interface type changed",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0, InterfaceType: "efa"},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NetworkInterfaces: []expinfrav1.LaunchTemplateNetworkInterface{
+					{DeviceIndex: 0, InterfaceType: "interface"},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "non-root volume resized",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 200},
+				},
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{
+				NonRootVolumes: []infrav1.Volume{
+					{DeviceName: "/dev/xvdb", Size: 100},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "new placement group",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				PlacementGroupName: "placement-group",
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
+		{
+			name: "new capacity reservation",
+			incoming: &expinfrav1.AWSLaunchTemplate{
+				CapacityReservationID: aws.String("cr-12345"),
+			},
+			existing: &expinfrav1.AWSLaunchTemplate{},
+			want:     true,
+		},
 		{
 			name:     "new launch template instance metadata options, removing IMDSv2 requirement",
 			incoming: &expinfrav1.AWSLaunchTemplate{},
@@ -1028,6 +1232,70 @@ func TestCreateLaunchTemplate(t *testing.T) {
 	}
 }
 
+func TestCreateLaunchTemplateAWSMachinePoolSettings(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cs, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	mockEC2Client := mocks.NewMockEC2API(mockCtrl)
+
+	ms, err := setupMachinePoolScope(client, cs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ms.AWSMachinePool.Spec.AWSLaunchTemplate.NonRootVolumes = []infrav1.Volume{{DeviceName: "/dev/xvdb", Size: 100, Type: "gp3"}}
+	ms.AWSMachinePool.Spec.AWSLaunchTemplate.PlacementGroupName = "placement-group"
+	ms.AWSMachinePool.Spec.AWSLaunchTemplate.PlacementGroupPartition = 2
+	ms.AWSMachinePool.Spec.AWSLaunchTemplate.NetworkInterfaces = []expinfrav1.LaunchTemplateNetworkInterface{
+		{DeviceIndex: 0, InterfaceType: "efa"},
+	}
+
+	s := NewService(cs)
+	s.EC2Client = mockEC2Client
+
+	mockEC2Client.EXPECT().CreateLaunchTemplateWithContext(context.TODO(), gomock.Any()).Return(&ec2.CreateLaunchTemplateOutput{
+		LaunchTemplate: &ec2.LaunchTemplate{
+			LaunchTemplateId: aws.String("launch-template-id"),
+		},
+	}, nil).Do(func(ctx context.Context, arg *ec2.CreateLaunchTemplateInput, requestOptions ...request.Option) {
+		data := arg.LaunchTemplateData
+		g.Expect(data.BlockDeviceMappings).To(Equal([]*ec2.LaunchTemplateBlockDeviceMappingRequest{
+			{
+				DeviceName: aws.String("/dev/xvdb"),
+				Ebs: &ec2.LaunchTemplateEbsBlockDeviceRequest{
+					DeleteOnTermination: aws.Bool(true),
+					VolumeSize:          aws.Int64(100),
+					VolumeType:          aws.String("gp3"),
+				},
+			},
+		}))
+		g.Expect(data.Placement).To(Equal(&ec2.LaunchTemplatePlacementRequest{
+			GroupName:       aws.String("placement-group"),
+			PartitionNumber: aws.Int64(2),
+		}))
+		// The security groups are set on the network interfaces instead of the launch template.
+		g.Expect(data.SecurityGroupIds).To(BeEmpty())
+		g.Expect(data.NetworkInterfaces).To(Equal([]*ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			{
+				DeviceIndex:         aws.Int64(0),
+				DeleteOnTermination: aws.Bool(true),
+				InterfaceType:       aws.String("efa"),
+				Groups:              aws.StringSlice([]string{"nodeSG", "lbSG"}),
+			},
+		}))
+	})
+
+	launchTemplate, err := s.CreateLaunchTemplate(ms, aws.String("imageID"), types.NamespacedName{Namespace: "ns", Name: "name"}, []byte{1, 0, 0})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(launchTemplate).To(Equal("launch-template-id"))
+}
+
 func TestLaunchTemplateDataCreation(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1056,6 +1324,42 @@ func TestLaunchTemplateDataCreation(t *testing.T) {
 	})
 }
 
+func TestLaunchTemplateUserData(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, err := setupScheme()
+	g.Expect(err).NotTo(HaveOccurred())
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cs, err := setupClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+	ms, err := setupMachinePoolScope(client, cs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	bootstrapData := []byte("#!/bin/bash\n/etc/eks/bootstrap.sh default_capa-eks\n")
+
+	// the bootstrap data of AWSMachinePools is used as is.
+	userData, err := launchTemplateUserData(ms, bootstrapData)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(userData).To(Equal(bootstrapData))
+	g.Expect(userDataChanged(userdata.ComputeHash(bootstrapData), bootstrapData, userData)).To(BeFalse())
+
+	// the bootstrap data of managed node groups is wrapped in a MIME document.
+	userData, err = launchTemplateUserData(&scope.ManagedMachinePoolScope{}, bootstrapData)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(userData)).To(HavePrefix("MIME-Version: 1.0"))
+	g.Expect(string(userData)).To(ContainSubstring(string(bootstrapData)))
+
+	// launch templates created before the bootstrap data was wrapped aren't changed for the same bootstrap data.
+	g.Expect(userDataChanged(userdata.ComputeHash(bootstrapData), bootstrapData, userData)).To(BeFalse())
+	g.Expect(userDataChanged(userdata.ComputeHash(userData), bootstrapData, userData)).To(BeFalse())
+
+	changedBootstrapData := []byte("#!/bin/bash\n/etc/eks/bootstrap.sh default_capa-eks --use-max-pods false\n")
+	changedUserData, err := launchTemplateUserData(&scope.ManagedMachinePoolScope{}, changedBootstrapData)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(userDataChanged(userdata.ComputeHash(bootstrapData), changedBootstrapData, changedUserData)).To(BeTrue())
+	g.Expect(userDataChanged(userdata.ComputeHash(userData), changedBootstrapData, changedUserData)).To(BeTrue())
+}
+
 func TestCreateLaunchTemplateVersion(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
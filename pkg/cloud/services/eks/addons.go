@@ -18,16 +18,33 @@ package eks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/awsnode"
 	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// addonProcedure is a procedure that applies to a single addon.
+type addonProcedure interface {
+	AddonName() string
+}
+
+const (
+	vpcCniAddonName    = "vpc-cni"
+	kubeProxyAddonName = "kube-proxy"
 )
 
 func (s *Service) reconcileAddons(ctx context.Context) error {
@@ -51,16 +68,28 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 
 	// Get the addons from the spec we want for the cluster
 	desiredAddons := s.translateAPIToAddon(s.scope.Addons())
+	migrationAddons, err := s.translateMigrationsToAddons()
+	if err != nil {
+		return fmt.Errorf("getting eks addons of migrations: %w", err)
+	}
+	desiredAddons = append(desiredAddons, migrationAddons...)
+
+	// Get the Kubernetes version used to resolve addon version selectors
+	kubernetesVersion, err := s.getAddonsKubernetesVersion(eksClusterName, desiredAddons, installed)
+	if err != nil {
+		return fmt.Errorf("getting kubernetes version for eks addons: %w", err)
+	}
 
 	// If there are no addons desired or installed then do nothing
 	if len(installed) == 0 && len(desiredAddons) == 0 {
 		s.scope.Info("no addons installed and no addons to install, no action needed")
+		s.scope.ControlPlane.Status.ComponentOwnership = s.componentOwnership(nil)
 		return nil
 	}
 
 	//  Compute operations to move installed to desired
 	s.scope.Debug("creating eks addons plan", "cluster", eksClusterName, "numdesired", len(desiredAddons), "numinstalled", len(installed))
-	addonsPlan := eksaddons.NewPlan(eksClusterName, desiredAddons, installed, s.EKSClient)
+	addonsPlan := eksaddons.NewPlan(eksClusterName, kubernetesVersion, desiredAddons, installed, s.EKSClient)
 	procedures, err := addonsPlan.Create(ctx)
 	if err != nil {
 		s.scope.Error(err, "failed creating eks addons plane")
@@ -68,12 +97,33 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	}
 	s.scope.Debug("computed EKS addons plan", "numprocs", len(procedures))
 
-	// Perform required operations
+	// Perform required operations. An invalid configuration is reported on the
+	// addon state, so the remaining procedures of that addon are skipped but the
+	// procedures of the other addons still run.
+	validations := map[string]*eksaddons.InvalidConfigurationError{}
 	for _, procedure := range procedures {
+		addonName := ""
+		if p, ok := procedure.(addonProcedure); ok {
+			addonName = p.AddonName()
+		}
+		if invalid, ok := validations[addonName]; ok && invalid != nil {
+			continue
+		}
+
 		s.scope.Debug("Executing addon procedure", "name", procedure.Name())
-		if err := procedure.Do(ctx); err != nil {
+		err := procedure.Do(ctx)
+		var invalidConfigErr *eksaddons.InvalidConfigurationError
+		switch {
+		case errors.As(err, &invalidConfigErr):
+			s.scope.Error(err, "invalid addon configuration", "addon", addonName)
+			validations[addonName] = invalidConfigErr
+		case err != nil:
 			s.scope.Error(err, "failed executing addon procedure", "name", procedure.Name())
 			return fmt.Errorf("%s: %w", procedure.Name(), err)
+		default:
+			if _, ok := procedure.(*eksaddons.ValidateAddonConfigurationProcedure); ok {
+				validations[addonName] = nil
+			}
 		}
 	}
 
@@ -85,12 +135,25 @@ func (s *Service) reconcileAddons(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("getting installed state of eks addons: %w", err)
 	}
+	setAddonResolvedVersions(addonState, desiredAddons)
+	addonState = setAddonConfigurationConditions(addonState, s.scope.ControlPlane.Status.Addons, desiredAddons, validations)
 	s.scope.ControlPlane.Status.Addons = addonState
+	s.scope.ControlPlane.Status.ComponentOwnership = s.componentOwnership(addonState)
 
 	// Persist status and record event
 	if err := s.scope.PatchObject(); err != nil {
 		return fmt.Errorf("failed to update control plane: %w", err)
 	}
+	var invalidConfigErrs []error
+	for _, addon := range desiredAddons {
+		if invalid := validations[aws.StringValue(addon.Name)]; invalid != nil {
+			record.Warnf(s.scope.ControlPlane, "FailedValidateEKSAddonConfiguration", "Invalid configuration for EKS addon %s: %s", invalid.AddonName, invalid.Reason)
+			invalidConfigErrs = append(invalidConfigErrs, invalid)
+		}
+	}
+	if len(invalidConfigErrs) > 0 {
+		return fmt.Errorf("validating eks addons configuration: %w", kerrors.NewAggregate(invalidConfigErrs))
+	}
 	record.Eventf(s.scope.ControlPlane, "SuccessfulReconcileEKSClusterAddons", "Reconciled addons for EKS Cluster %s", s.scope.KubernetesClusterName())
 	s.scope.Debug("Reconcile EKS addons completed successfully")
 
@@ -196,12 +259,16 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 		addon := addons[i]
 		convertedAddon := &eksaddons.EKSAddon{
 			Name:                  &addon.Name,
-			Version:               &addon.Version,
 			Configuration:         &addon.Configuration,
 			Tags:                  ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags()),
 			ResolveConflict:       convertConflictResolution(*addon.ConflictResolution),
 			ServiceAccountRoleARN: addon.ServiceAccountRoleArn,
 		}
+		if eksaddons.IsVersionSelector(addon.Version) {
+			convertedAddon.VersionSelector = &addon.Version
+		} else {
+			convertedAddon.Version = &addon.Version
+		}
 
 		converted = append(converted, convertedAddon)
 	}
@@ -209,6 +276,207 @@ func (s *Service) translateAPIToAddon(addons []ekscontrolplanev1.Addon) []*eksad
 	return converted
 }
 
+// translateMigrationsToAddons returns the addons that the VPC CNI and kube-proxy migrate to. The
+// addons adopt the self-managed components by overwriting conflicts, and the vpc-cni addon is
+// configured with the environment properties CAPA applied to aws-node unless a configuration
+// is provided.
+func (s *Service) translateMigrationsToAddons() ([]*eksaddons.EKSAddon, error) {
+	converted := []*eksaddons.EKSAddon{}
+
+	if migration := s.scope.VpcCni().MigrateToAddon; migration != nil && !s.hasAddon(vpcCniAddonName) {
+		configuration := migration.Configuration
+		if configuration == "" {
+			var err error
+			configuration, err = awsnode.AddonConfiguration(s.scope.VpcCni(), s.scope.VPC().IsIPv6Enabled())
+			if err != nil {
+				return nil, err
+			}
+		}
+		converted = append(converted, s.translateMigrationToAddon(vpcCniAddonName, migration, configuration))
+	}
+
+	if migration := s.scope.KubeProxy().MigrateToAddon; migration != nil && !s.hasAddon(kubeProxyAddonName) {
+		converted = append(converted, s.translateMigrationToAddon(kubeProxyAddonName, migration, migration.Configuration))
+	}
+
+	return converted, nil
+}
+
+func (s *Service) translateMigrationToAddon(name string, migration *ekscontrolplanev1.AddonMigration, configuration string) *eksaddons.EKSAddon {
+	addon := &eksaddons.EKSAddon{
+		Name:                  aws.String(name),
+		Configuration:         aws.String(configuration),
+		Tags:                  ngTags(s.scope.Cluster.Name, s.scope.AdditionalTags()),
+		ResolveConflict:       aws.String(eks.ResolveConflictsOverwrite),
+		ServiceAccountRoleARN: migration.ServiceAccountRoleArn,
+	}
+	version := migration.Version
+	if version == "" {
+		version = eksaddons.VersionSelectorDefault
+	}
+	if eksaddons.IsVersionSelector(version) {
+		addon.VersionSelector = &version
+	} else {
+		addon.Version = &version
+	}
+
+	return addon
+}
+
+func (s *Service) hasAddon(name string) bool {
+	for _, addon := range s.scope.Addons() {
+		if addon.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// componentOwnership returns the owners of the VPC CNI and kube-proxy. A component migrating to
+// its addon is owned by the addon once the addon is active.
+func (s *Service) componentOwnership(state []ekscontrolplanev1.AddonState) *ekscontrolplanev1.ComponentOwnership {
+	owner := func(name string, disabled bool, migration *ekscontrolplanev1.AddonMigration) ekscontrolplanev1.ComponentOwner {
+		switch {
+		case s.hasAddon(name):
+			return ekscontrolplanev1.ComponentOwnerAddon
+		case migration != nil:
+			if index := findAddonState(state, name); index != -1 && aws.StringValue(state[index].Status) == eks.AddonStatusActive {
+				return ekscontrolplanev1.ComponentOwnerAddon
+			}
+			return ekscontrolplanev1.ComponentOwnerMigrating
+		case disabled:
+			return ekscontrolplanev1.ComponentOwnerDisabled
+		default:
+			return ekscontrolplanev1.ComponentOwnerSelfManaged
+		}
+	}
+
+	return &ekscontrolplanev1.ComponentOwnership{
+		VpcCni:    owner(vpcCniAddonName, s.scope.VpcCni().Disable, s.scope.VpcCni().MigrateToAddon),
+		KubeProxy: owner(kubeProxyAddonName, s.scope.KubeProxy().Disable, s.scope.KubeProxy().MigrateToAddon),
+	}
+}
+
+// getAddonsKubernetesVersion returns the current Kubernetes version of the cluster if any of
+// the desired addons uses a version selector. While the control plane is updating, addons
+// using a version selector keep their installed version so they are only upgraded after the
+// control plane has reached its new version.
+func (s *Service) getAddonsKubernetesVersion(eksClusterName string, desired, installed []*eksaddons.EKSAddon) (string, error) {
+	hasSelector := false
+	for _, addon := range desired {
+		if addon.VersionSelector != nil {
+			hasSelector = true
+		}
+	}
+	if !hasSelector {
+		return "", nil
+	}
+
+	cluster, err := s.describeEKSCluster(eksClusterName)
+	if err != nil {
+		return "", err
+	}
+	if cluster == nil {
+		return "", fmt.Errorf("eks cluster %s not found", eksClusterName)
+	}
+
+	if aws.StringValue(cluster.Status) == eks.ClusterStatusUpdating {
+		for _, addon := range desired {
+			if addon.VersionSelector == nil {
+				continue
+			}
+			for _, installedAddon := range installed {
+				if *installedAddon.Name == *addon.Name {
+					s.scope.Debug("control plane is updating, keeping installed addon version", "addon", *addon.Name, "version", aws.StringValue(installedAddon.Version))
+					addon.Version = installedAddon.Version
+				}
+			}
+		}
+	}
+
+	return aws.StringValue(cluster.Version), nil
+}
+
+// setAddonResolvedVersions records the version that the version selector of each addon resolved to.
+func setAddonResolvedVersions(state []ekscontrolplanev1.AddonState, desired []*eksaddons.EKSAddon) {
+	for _, addon := range desired {
+		if addon.VersionSelector == nil {
+			continue
+		}
+		if index := findAddonState(state, *addon.Name); index != -1 {
+			state[index].ResolvedVersion = aws.StringValue(addon.Version)
+		}
+	}
+}
+
+// setAddonConfigurationConditions reports on the addon state the result of validating
+// the configuration of each desired addon. The validations map holds the addons whose
+// configuration was validated, with a nil error when it is valid. An addon whose
+// configuration wasn't validated, because it is already applied, keeps a previous
+// successful validation. An addon that failed validation before it was installed
+// is added to the state so the failure is visible.
+func setAddonConfigurationConditions(state, previous []ekscontrolplanev1.AddonState, desired []*eksaddons.EKSAddon, validations map[string]*eksaddons.InvalidConfigurationError) []ekscontrolplanev1.AddonState {
+	for _, addon := range desired {
+		name := aws.StringValue(addon.Name)
+		if aws.StringValue(addon.Configuration) == "" {
+			continue
+		}
+
+		var previousCondition *clusterv1.Condition
+		if prevIndex := findAddonState(previous, name); prevIndex != -1 {
+			for i := range previous[prevIndex].Conditions {
+				if previous[prevIndex].Conditions[i].Type == ekscontrolplanev1.EKSAddonConfigurationValidCondition {
+					previousCondition = &previous[prevIndex].Conditions[i]
+				}
+			}
+		}
+
+		var condition *clusterv1.Condition
+		invalid, validated := validations[name]
+		switch {
+		case validated && invalid != nil:
+			condition = conditions.FalseCondition(ekscontrolplanev1.EKSAddonConfigurationValidCondition, ekscontrolplanev1.EKSAddonConfigurationInvalidReason, clusterv1.ConditionSeverityError, "%s", invalid.Reason)
+		case validated:
+			condition = conditions.TrueCondition(ekscontrolplanev1.EKSAddonConfigurationValidCondition)
+		case previousCondition != nil && previousCondition.Status == corev1.ConditionTrue:
+			condition = previousCondition.DeepCopy()
+		default:
+			continue
+		}
+
+		index := findAddonState(state, name)
+		if index == -1 {
+			if invalid == nil {
+				continue
+			}
+			state = append(state, ekscontrolplanev1.AddonState{
+				Name:    name,
+				Version: aws.StringValue(addon.Version),
+			})
+			index = len(state) - 1
+		}
+
+		condition.LastTransitionTime = metav1.Now()
+		if previousCondition != nil && previousCondition.Status == condition.Status && previousCondition.Reason == condition.Reason && previousCondition.Message == condition.Message {
+			condition.LastTransitionTime = previousCondition.LastTransitionTime
+		}
+		state[index].Conditions = clusterv1.Conditions{*condition}
+	}
+
+	return state
+}
+
+func findAddonState(state []ekscontrolplanev1.AddonState, name string) int {
+	for i := range state {
+		if state[i].Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func convertConflictResolution(conflict ekscontrolplanev1.AddonResolution) *string {
 	if conflict == ekscontrolplanev1.AddonResolutionNone {
 		return aws.String(eks.ResolveConflictsNone)
@@ -0,0 +1,110 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	eksaddons "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/addons"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestSetAddonConfigurationConditions(t *testing.T) {
+	desired := []*eksaddons.EKSAddon{
+		{Name: aws.String("vpc-cni"), Version: aws.String("v1.0.0"), Configuration: aws.String(`{"env":{}}`)},
+		{Name: aws.String("coredns"), Version: aws.String("v1.0.0"), Configuration: aws.String(`{"replicaCount":2}`)},
+		{Name: aws.String("kube-proxy"), Version: aws.String("v1.0.0")},
+	}
+	validCondition := func(status corev1.ConditionStatus) clusterv1.Conditions {
+		return clusterv1.Conditions{{Type: ekscontrolplanev1.EKSAddonConfigurationValidCondition, Status: status}}
+	}
+
+	testCases := []struct {
+		name        string
+		state       []ekscontrolplanev1.AddonState
+		previous    []ekscontrolplanev1.AddonState
+		validations map[string]*eksaddons.InvalidConfigurationError
+		expect      map[string]corev1.ConditionStatus
+	}{
+		{
+			name:  "addons without a validation or a previous successful validation don't get a condition",
+			state: []ekscontrolplanev1.AddonState{{Name: "vpc-cni"}, {Name: "coredns"}, {Name: "kube-proxy"}},
+			previous: []ekscontrolplanev1.AddonState{
+				{Name: "vpc-cni", Conditions: validCondition(corev1.ConditionFalse)},
+			},
+			expect: map[string]corev1.ConditionStatus{},
+		},
+		{
+			name:  "validated addons get the result of the validation",
+			state: []ekscontrolplanev1.AddonState{{Name: "vpc-cni"}, {Name: "coredns"}, {Name: "kube-proxy"}},
+			validations: map[string]*eksaddons.InvalidConfigurationError{
+				"vpc-cni": {AddonName: "vpc-cni", Reason: "bad"},
+				"coredns": nil,
+			},
+			expect: map[string]corev1.ConditionStatus{
+				"vpc-cni": corev1.ConditionFalse,
+				"coredns": corev1.ConditionTrue,
+			},
+		},
+		{
+			name:  "an addon that wasn't validated keeps a previous successful validation",
+			state: []ekscontrolplanev1.AddonState{{Name: "vpc-cni"}, {Name: "coredns"}},
+			previous: []ekscontrolplanev1.AddonState{
+				{Name: "coredns", Conditions: validCondition(corev1.ConditionTrue)},
+			},
+			validations: map[string]*eksaddons.InvalidConfigurationError{
+				"vpc-cni": nil,
+			},
+			expect: map[string]corev1.ConditionStatus{
+				"vpc-cni": corev1.ConditionTrue,
+				"coredns": corev1.ConditionTrue,
+			},
+		},
+		{
+			name:  "an addon that failed validation before it was installed is added to the state",
+			state: []ekscontrolplanev1.AddonState{{Name: "vpc-cni"}},
+			validations: map[string]*eksaddons.InvalidConfigurationError{
+				"coredns": {AddonName: "coredns", Reason: "bad"},
+			},
+			expect: map[string]corev1.ConditionStatus{
+				"coredns": corev1.ConditionFalse,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			state := setAddonConfigurationConditions(tc.state, tc.previous, desired, tc.validations)
+
+			actual := map[string]corev1.ConditionStatus{}
+			for _, addon := range state {
+				for _, condition := range addon.Conditions {
+					g.Expect(condition.Type).To(Equal(ekscontrolplanev1.EKSAddonConfigurationValidCondition))
+					actual[addon.Name] = condition.Status
+				}
+			}
+			g.Expect(actual).To(Equal(tc.expect))
+		})
+	}
+}
@@ -101,6 +101,17 @@ func (s *Service) reconcileCluster(ctx context.Context) error {
 		Port: 443,
 	}
 
+	if cluster.CertificateAuthority != nil {
+		s.scope.ControlPlane.Status.CertificateAuthorityData = aws.StringValue(cluster.CertificateAuthority.Data)
+	}
+	if netConfig := cluster.KubernetesNetworkConfig; netConfig != nil {
+		if aws.StringValue(netConfig.IpFamily) == eks.IpFamilyIpv6 {
+			s.scope.ControlPlane.Status.ServiceCIDR = aws.StringValue(netConfig.ServiceIpv6Cidr)
+		} else {
+			s.scope.ControlPlane.Status.ServiceCIDR = aws.StringValue(netConfig.ServiceIpv4Cidr)
+		}
+	}
+
 	if err := s.reconcileSecurityGroups(cluster); err != nil {
 		return errors.Wrap(err, "failed reconciling security groups")
 	}
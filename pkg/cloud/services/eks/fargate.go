@@ -18,6 +18,9 @@ package eks
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -29,12 +32,18 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
 	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/hash"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+const (
+	maxFargateProfileNameLength    = 100
+	fargateProfileNameSuffixLength = 8
+)
+
 func requeueProfileUpdating() reconcile.Result {
 	return reconcile.Result{RequeueAfter: 10 * time.Second}
 }
@@ -85,26 +94,28 @@ func (s *FargateService) Reconcile() (reconcile.Result, error) {
 }
 
 func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
-	profileName := s.scope.FargateProfile.Spec.ProfileName
+	if err := s.recoverActiveProfileName(); err != nil {
+		return false, err
+	}
+	profileName := s.activeProfileName()
 
-	profile, err := s.describeFargateProfile()
+	profile, err := s.describeFargateProfile(profileName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to describe profile")
 	}
 
 	if eksClusterName := s.scope.KubernetesClusterName(); profile == nil {
-		profile, err = s.createFargateProfile()
+		profile, err = s.createFargateProfile(profileName, s.activeProfileTags())
 		if err != nil {
 			return false, errors.Wrap(err, "failed to create profile")
 		}
 		// Force status to creating
 		profile.Status = aws.String(eks.FargateProfileStatusCreating)
+		s.scope.FargateProfile.Status.ActiveProfileName = profileName
 		s.scope.Info("Created EKS fargate profile", "cluster-name", eksClusterName, "profile-name", profileName)
 	} else {
-		tagKey := infrav1.ClusterAWSCloudProviderTagKey(s.scope.ClusterName())
-		ownedTag := profile.Tags[tagKey]
-		if ownedTag == nil {
-			return false, errors.New("owned tag not found for this cluster")
+		if err := s.checkOwnedTag(profile); err != nil {
+			return false, err
 		}
 		s.scope.Debug("Found owned EKS fargate profile", "cluster-name", eksClusterName, "profile-name", profileName)
 	}
@@ -113,9 +124,201 @@ func (s *FargateService) reconcileFargateProfile() (requeue bool, err error) {
 		return false, errors.Wrapf(err, "failed to reconcile profile tags")
 	}
 
+	if aws.StringValue(profile.Status) == eks.FargateProfileStatusActive && !s.profileMatchesSpec(profile) {
+		return s.reconcileReplacementProfile(profile)
+	}
+
+	return s.handleStatus(profile), nil
+}
+
+// reconcileReplacementProfile replaces the active profile with one that matches the spec. EKS
+// fargate profiles can't be updated, so a profile with a suffixed name is created and the active
+// profile is only deleted once the replacement is active, so pods can be scheduled throughout.
+func (s *FargateService) reconcileReplacementProfile(active *eks.FargateProfile) (requeue bool, err error) {
+	eksClusterName := s.scope.KubernetesClusterName()
+	activeName := aws.StringValue(active.FargateProfileName)
+
+	profileName, err := s.replacementProfileName()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to generate replacement profile name")
+	}
+	if profileName == activeName {
+		return s.handleStatus(active), nil
+	}
+
+	profile, err := s.describeFargateProfile(profileName)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to describe replacement profile")
+	}
+
+	if profile == nil {
+		if _, err := s.createFargateProfile(profileName, s.profileTags()); err != nil {
+			return false, errors.Wrap(err, "failed to create replacement profile")
+		}
+		record.Eventf(s.scope.FargateProfile, "InitiatedReplaceEKSFargateProfile", "Started replacing EKS fargate profile %s with %s", activeName, profileName)
+		conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateReplacingCondition)
+		s.scope.Info("Created replacement EKS fargate profile", "cluster-name", eksClusterName, "profile-name", profileName, "active-profile-name", activeName)
+		return true, nil
+	}
+
+	if err := s.checkOwnedTag(profile); err != nil {
+		return false, err
+	}
+
+	switch aws.StringValue(profile.Status) {
+	case eks.FargateProfileStatusCreating, eks.FargateProfileStatusDeleting:
+		conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateReplacingCondition)
+		return true, nil
+	case eks.FargateProfileStatusCreateFailed, eks.FargateProfileStatusDeleteFailed:
+		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateReplacingCondition, expinfrav1.EKSFargateFailedReason, clusterv1.ConditionSeverityError, "replacement profile %s has status %s", profileName, aws.StringValue(profile.Status))
+		return false, errors.Errorf("unexpected replacement profile status: %s", aws.StringValue(profile.Status))
+	}
+
+	// Mark the replacement as active before the replaced profile is deleted, so the active
+	// profile can always be recovered from its tag.
+	if err := updateTags(s.EKSClient, profile.FargateProfileArn, aws.StringValueMap(profile.Tags), s.activeProfileTags()); err != nil {
+		return false, errors.Wrap(err, "failed to tag replacement profile")
+	}
+
+	if err := s.deleteProfileIfExists(activeName); err != nil {
+		return false, errors.Wrapf(err, "failed to delete replaced profile %s", activeName)
+	}
+
+	s.scope.FargateProfile.Status.ActiveProfileName = profileName
+	record.Eventf(s.scope.FargateProfile, "SuccessfulReplaceEKSFargateProfile", "Replaced EKS fargate profile %s with %s", activeName, profileName)
+	conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateReplacingCondition, expinfrav1.EKSFargateReplacedReason, clusterv1.ConditionSeverityInfo, "")
+	s.scope.Info("Replaced EKS fargate profile", "cluster-name", eksClusterName, "profile-name", profileName, "replaced-profile-name", activeName)
+
 	return s.handleStatus(profile), nil
 }
 
+func (s *FargateService) checkOwnedTag(profile *eks.FargateProfile) error {
+	tagKey := infrav1.ClusterAWSCloudProviderTagKey(s.scope.ClusterName())
+	if ownedTag := profile.Tags[tagKey]; ownedTag == nil {
+		return errors.New("owned tag not found for this cluster")
+	}
+	return nil
+}
+
+// recoverActiveProfileName sets the active profile in the status from the profile tagged as
+// active when the status doesn't record it, e.g. after the AWSFargateProfile was restored from
+// a backup.
+func (s *FargateService) recoverActiveProfileName() error {
+	if s.scope.FargateProfile.Status.ActiveProfileName != "" {
+		return nil
+	}
+
+	profiles, err := s.ownedProfiles()
+	if err != nil {
+		return err
+	}
+
+	var active *eks.FargateProfile
+	for _, profile := range profiles {
+		if aws.StringValue(profile.Tags[infrav1.FargateProfileActiveTag]) != "true" || aws.StringValue(profile.Status) == eks.FargateProfileStatusDeleting {
+			continue
+		}
+		// Both profiles are tagged as active for a short time while a replacement completes.
+		if active == nil || aws.TimeValue(profile.CreatedAt).After(aws.TimeValue(active.CreatedAt)) {
+			active = profile
+		}
+	}
+
+	if active != nil {
+		s.scope.FargateProfile.Status.ActiveProfileName = aws.StringValue(active.FargateProfileName)
+		s.scope.Info("Recovered active EKS fargate profile from tags", "profile-name", s.scope.FargateProfile.Status.ActiveProfileName)
+	}
+
+	return nil
+}
+
+// activeProfileName returns the name of the EKS fargate profile currently in use.
+func (s *FargateService) activeProfileName() string {
+	if name := s.scope.FargateProfile.Status.ActiveProfileName; name != "" {
+		return name
+	}
+	return s.scope.FargateProfile.Spec.ProfileName
+}
+
+// replacementProfileName returns the name of the profile that replaces the active profile when
+// the selectors or subnets change. The name is suffixed with a hash of the subnets set in the spec
+// and the selectors so that each change gets its own profile. The control plane subnets used when
+// no subnets are set aren't part of the hash, so changing them doesn't replace the profile.
+func (s *FargateService) replacementProfileName() (string, error) {
+	return fargateReplacementProfileName(s.scope.FargateProfile.Spec.ProfileName, s.scope.FargateProfile.Spec.SubnetIDs, s.scope.FargateProfile.Spec.Selectors)
+}
+
+func fargateReplacementProfileName(profileName string, subnets []string, selectors []expinfrav1.FargateSelector) (string, error) {
+	var b strings.Builder
+	for _, subnet := range sortedStrings(subnets) {
+		fmt.Fprintf(&b, "subnet=%s;", subnet)
+	}
+	for _, selector := range selectorKeys(selectors) {
+		fmt.Fprintf(&b, "selector=%s;", selector)
+	}
+
+	suffix, err := hash.Base36TruncatedHash(b.String(), fargateProfileNameSuffixLength)
+	if err != nil {
+		return "", err
+	}
+
+	if maxLength := maxFargateProfileNameLength - fargateProfileNameSuffixLength - 1; len(profileName) > maxLength {
+		profileName = profileName[:maxLength]
+	}
+
+	return fmt.Sprintf("%s-%s", profileName, suffix), nil
+}
+
+// profileMatchesSpec returns whether the subnets and selectors of the profile match the spec.
+// The subnets are only compared when they're set in the spec.
+func (s *FargateService) profileMatchesSpec(profile *eks.FargateProfile) bool {
+	if subnets := s.scope.FargateProfile.Spec.SubnetIDs; len(subnets) > 0 && !reflect.DeepEqual(sortedStrings(aws.StringValueSlice(profile.Subnets)), sortedStrings(subnets)) {
+		return false
+	}
+
+	selectors := make([]expinfrav1.FargateSelector, 0, len(profile.Selectors))
+	for _, selector := range profile.Selectors {
+		selectors = append(selectors, expinfrav1.FargateSelector{
+			Labels:    aws.StringValueMap(selector.Labels),
+			Namespace: aws.StringValue(selector.Namespace),
+		})
+	}
+
+	return reflect.DeepEqual(selectorKeys(selectors), selectorKeys(s.scope.FargateProfile.Spec.Selectors))
+}
+
+func (s *FargateService) desiredSubnets() []string {
+	subnets := s.scope.FargateProfile.Spec.SubnetIDs
+	if len(subnets) == 0 {
+		subnets = []string{}
+		for _, s := range s.scope.ControlPlane.Spec.NetworkSpec.Subnets.FilterPrivate() {
+			subnets = append(subnets, s.ID)
+		}
+	}
+	return subnets
+}
+
+// selectorKeys returns a sorted, canonical representation of the selectors.
+func selectorKeys(selectors []expinfrav1.FargateSelector) []string {
+	keys := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		labels := make([]string, 0, len(selector.Labels))
+		for k, v := range selector.Labels {
+			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		}
+		sort.Strings(labels)
+		keys = append(keys, fmt.Sprintf("%s/%s", selector.Namespace, strings.Join(labels, ",")))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStrings(in []string) []string {
+	out := append([]string{}, in...)
+	sort.Strings(out)
+	return out
+}
+
 func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool) {
 	s.Debug("fargate profile", "status", *profile.Status)
 	switch *profile.Status {
@@ -125,7 +328,7 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition, expinfrav1.EKSFargateCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		}
 		if !conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition) {
-			record.Eventf(s.scope.FargateProfile, "InitiatedCreateEKSFargateProfile", "Started creating EKS fargate profile %s", s.scope.FargateProfile.Spec.ProfileName)
+			record.Eventf(s.scope.FargateProfile, "InitiatedCreateEKSFargateProfile", "Started creating EKS fargate profile %s", aws.StringValue(profile.FargateProfileName))
 			conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition)
 		}
 		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateCreatingReason, clusterv1.ConditionSeverityInfo, "")
@@ -138,14 +341,14 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 	case eks.FargateProfileStatusActive:
 		s.scope.FargateProfile.Status.Ready = true
 		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition) {
-			record.Eventf(s.scope.FargateProfile, "SuccessfulCreateEKSFargateProfile", "Created new EKS fargate profile %s", s.scope.FargateProfile.Spec.ProfileName)
+			record.Eventf(s.scope.FargateProfile, "SuccessfulCreateEKSFargateProfile", "Created new EKS fargate profile %s", aws.StringValue(profile.FargateProfileName))
 			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateCreatingCondition, expinfrav1.EKSFargateCreatedReason, clusterv1.ConditionSeverityInfo, "")
 		}
 		conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition)
 	case eks.FargateProfileStatusDeleting:
 		s.scope.FargateProfile.Status.Ready = false
 		if !conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition) {
-			record.Eventf(s.scope.FargateProfile, "InitiatedDeleteEKSFargateProfile", "Started deleting EKS fargate profile %s", s.scope.FargateProfile.Spec.ProfileName)
+			record.Eventf(s.scope.FargateProfile, "InitiatedDeleteEKSFargateProfile", "Started deleting EKS fargate profile %s", aws.StringValue(profile.FargateProfileName))
 			conditions.MarkTrue(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition)
 		}
 		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateDeletingReason, clusterv1.ConditionSeverityInfo, "")
@@ -162,7 +365,20 @@ func (s *FargateService) handleStatus(profile *eks.FargateProfile) (requeue bool
 func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 	s.scope.Debug("Reconciling EKS fargate profile deletion")
 
-	requeue, err := s.deleteFargateProfile()
+	// Delete replacement profiles that may have been created before the active profile.
+	if err := s.recoverActiveProfileName(); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	requeue, err := s.deleteReplacementProfiles()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return requeueProfileUpdating(), nil
+	}
+
+	requeue, err = s.deleteFargateProfile(s.activeProfileName())
 	if err != nil {
 		conditions.MarkFalse(
 			s.scope.FargateProfile,
@@ -191,9 +407,8 @@ func (s *FargateService) ReconcileDelete() (reconcile.Result, error) {
 	return reconcile.Result{}, err
 }
 
-func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
+func (s *FargateService) describeFargateProfile(profileName string) (*eks.FargateProfile, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
 	input := &eks.DescribeFargateProfileInput{
 		ClusterName:        aws.String(eksClusterName),
 		FargateProfileName: aws.String(profileName),
@@ -210,26 +425,15 @@ func (s *FargateService) describeFargateProfile() (*eks.FargateProfile, error) {
 	return out.FargateProfile, nil
 }
 
-func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
+func (s *FargateService) createFargateProfile(profileName string, tags map[string]string) (*eks.FargateProfile, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
-
-	additionalTags := s.scope.AdditionalTags()
 
 	roleArn, err := s.roleArn()
 	if err != nil {
 		return nil, err
 	}
 
-	tags := ngTags(s.scope.ClusterName(), additionalTags)
-
-	subnets := s.scope.FargateProfile.Spec.SubnetIDs
-	if len(subnets) == 0 {
-		subnets = []string{}
-		for _, s := range s.scope.ControlPlane.Spec.NetworkSpec.Subnets.FilterPrivate() {
-			subnets = append(subnets, s.ID)
-		}
-	}
+	subnets := s.desiredSubnets()
 
 	selectors := []*eks.FargateProfileSelector{}
 	for _, s := range s.scope.FargateProfile.Spec.Selectors {
@@ -259,17 +463,16 @@ func (s *FargateService) createFargateProfile() (*eks.FargateProfile, error) {
 	return out.FargateProfile, nil
 }
 
-func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
+func (s *FargateService) deleteFargateProfile(profileName string) (requeue bool, err error) {
 	eksClusterName := s.scope.KubernetesClusterName()
-	profileName := s.scope.FargateProfile.Spec.ProfileName
 
-	profile, err := s.describeFargateProfile()
+	profile, err := s.describeFargateProfile(profileName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to describe profile")
 	}
 	if profile == nil {
 		if conditions.IsTrue(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition) {
-			record.Eventf(s.scope.FargateProfile, "SuccessfulDeleteEKSFargateProfile", "Deleted EKS fargate profile %s", s.scope.FargateProfile.Spec.ProfileName)
+			record.Eventf(s.scope.FargateProfile, "SuccessfulDeleteEKSFargateProfile", "Deleted EKS fargate profile %s", profileName)
 			conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateDeletingCondition, expinfrav1.EKSFargateDeletedReason, clusterv1.ConditionSeverityInfo, "")
 		}
 		conditions.MarkFalse(s.scope.FargateProfile, expinfrav1.EKSFargateProfileReadyCondition, expinfrav1.EKSFargateDeletedReason, clusterv1.ConditionSeverityInfo, "")
@@ -301,6 +504,89 @@ func (s *FargateService) deleteFargateProfile() (requeue bool, err error) {
 	return s.handleStatus(profile), nil
 }
 
+// deleteReplacementProfiles deletes profiles which were created to replace the active profile,
+// but didn't become active.
+func (s *FargateService) deleteReplacementProfiles() (requeue bool, err error) {
+	profiles, err := s.ownedProfiles()
+	if err != nil {
+		return false, err
+	}
+
+	activeName := s.activeProfileName()
+	for _, profile := range profiles {
+		name := aws.StringValue(profile.FargateProfileName)
+		if name == activeName {
+			continue
+		}
+		switch aws.StringValue(profile.Status) {
+		case eks.FargateProfileStatusCreating, eks.FargateProfileStatusDeleting:
+		default:
+			if err := s.deleteProfileIfExists(name); err != nil {
+				return false, errors.Wrapf(err, "failed to delete replacement profile %s", name)
+			}
+		}
+		requeue = true
+	}
+
+	return requeue, nil
+}
+
+// ownedProfiles returns the EKS fargate profiles created for the AWSFargateProfile. Profiles are
+// matched on the owner tag, as the name prefix may be shared with the profiles of other
+// AWSFargateProfiles.
+func (s *FargateService) ownedProfiles() ([]*eks.FargateProfile, error) {
+	profileName := s.scope.FargateProfile.Spec.ProfileName
+	prefix := profileName
+	if maxLength := maxFargateProfileNameLength - fargateProfileNameSuffixLength - 1; len(prefix) > maxLength {
+		prefix = prefix[:maxLength]
+	}
+	prefix += "-"
+
+	input := &eks.ListFargateProfilesInput{
+		ClusterName: aws.String(s.scope.KubernetesClusterName()),
+	}
+	var names []string
+	if err := s.EKSClient.ListFargateProfilesPages(input, func(out *eks.ListFargateProfilesOutput, _ bool) bool {
+		for _, name := range aws.StringValueSlice(out.FargateProfileNames) {
+			if name == profileName || (strings.HasPrefix(name, prefix) && len(name) == len(prefix)+fargateProfileNameSuffixLength) {
+				names = append(names, name)
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list fargate profiles")
+	}
+
+	profiles := []*eks.FargateProfile{}
+	for _, name := range names {
+		profile, err := s.describeFargateProfile(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe profile")
+		}
+		if profile == nil || s.checkOwnedTag(profile) != nil || aws.StringValue(profile.Tags[infrav1.FargateProfileOwnerTag]) != s.ownerTagValue() {
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	return profiles, nil
+}
+
+// deleteProfileIfExists deletes the profile without waiting for the deletion to finish.
+func (s *FargateService) deleteProfileIfExists(profileName string) error {
+	input := &eks.DeleteFargateProfileInput{
+		ClusterName:        aws.String(s.scope.KubernetesClusterName()),
+		FargateProfileName: aws.String(profileName),
+	}
+	if _, err := s.EKSClient.DeleteFargateProfile(input); err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == eks.ErrCodeResourceNotFoundException {
+			return nil
+		}
+		return errors.Wrap(err, "failed to delete fargate profile")
+	}
+	return nil
+}
+
 func (s *FargateService) roleArn() (*string, error) {
 	var role *iam.Role
 	if s.scope.RoleName() != "" {
@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/logger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestFargateReplacementProfileName(t *testing.T) {
+	g := NewWithT(t)
+
+	selectors := []expinfrav1.FargateSelector{
+		{Namespace: "default", Labels: map[string]string{"a": "1", "b": "2"}},
+		{Namespace: "kube-system"},
+	}
+
+	name, err := fargateReplacementProfileName("profile", []string{"subnet-1", "subnet-2"}, selectors)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(name).To(HavePrefix("profile-"))
+	g.Expect(name).To(HaveLen(len("profile-") + fargateProfileNameSuffixLength))
+
+	reordered, err := fargateReplacementProfileName("profile", []string{"subnet-2", "subnet-1"}, []expinfrav1.FargateSelector{selectors[1], selectors[0]})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(reordered).To(Equal(name), "order of subnets and selectors should not change the name")
+
+	changed, err := fargateReplacementProfileName("profile", []string{"subnet-1", "subnet-2"}, selectors[:1])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(changed).NotTo(Equal(name))
+
+	long, err := fargateReplacementProfileName(strings.Repeat("p", maxFargateProfileNameLength), nil, selectors)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(long).To(HaveLen(maxFargateProfileNameLength))
+}
+
+func TestSelectorKeys(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors []expinfrav1.FargateSelector
+		expected  []string
+	}{
+		{
+			name:     "no selectors",
+			expected: []string{},
+		},
+		{
+			name: "selectors are sorted",
+			selectors: []expinfrav1.FargateSelector{
+				{Namespace: "kube-system"},
+				{Namespace: "default", Labels: map[string]string{"b": "2", "a": "1"}},
+			},
+			expected: []string{"default/a=1,b=2", "kube-system/"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(selectorKeys(tc.selectors)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestDeleteReplacementProfiles(t *testing.T) {
+	g := NewWithT(t)
+
+	mockControl := gomock.NewController(t)
+	defer mockControl.Finish()
+	eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+	clusterName := "capi-name"
+	eksClusterName := "default_capi-name"
+	ownedTag := infrav1.ClusterAWSCloudProviderTagKey(clusterName)
+	profiles := map[string]*eks.FargateProfile{
+		// a sibling profile of the AWSFargateProfile "frontend" that shares the name prefix.
+		"workers-frontend": {
+			FargateProfileName: aws.String("workers-frontend"),
+			Status:             aws.String(eks.FargateProfileStatusActive),
+			Tags: aws.StringMap(map[string]string{
+				ownedTag:                       string(infrav1.ResourceLifecycleOwned),
+				infrav1.FargateProfileOwnerTag: "default/frontend",
+			}),
+		},
+		// a replacement profile of the AWSFargateProfile "workers".
+		"workers-1a2b3c4d": {
+			FargateProfileName: aws.String("workers-1a2b3c4d"),
+			Status:             aws.String(eks.FargateProfileStatusActive),
+			Tags: aws.StringMap(map[string]string{
+				ownedTag:                       string(infrav1.ResourceLifecycleOwned),
+				infrav1.FargateProfileOwnerTag: "default/workers",
+			}),
+		},
+	}
+
+	eksMock.EXPECT().
+		ListFargateProfilesPages(&eks.ListFargateProfilesInput{ClusterName: aws.String(eksClusterName)}, gomock.Any()).
+		DoAndReturn(func(_ *eks.ListFargateProfilesInput, fn func(*eks.ListFargateProfilesOutput, bool) bool) error {
+			fn(&eks.ListFargateProfilesOutput{
+				FargateProfileNames: aws.StringSlice([]string{"workers", "workers-frontend", "workers-1a2b3c4d"}),
+			}, true)
+			return nil
+		})
+	eksMock.EXPECT().
+		DescribeFargateProfile(gomock.AssignableToTypeOf(&eks.DescribeFargateProfileInput{})).
+		DoAndReturn(func(input *eks.DescribeFargateProfileInput) (*eks.DescribeFargateProfileOutput, error) {
+			return &eks.DescribeFargateProfileOutput{FargateProfile: profiles[aws.StringValue(input.FargateProfileName)]}, nil
+		}).
+		Times(3)
+	eksMock.EXPECT().
+		DeleteFargateProfile(&eks.DeleteFargateProfileInput{
+			ClusterName:        aws.String(eksClusterName),
+			FargateProfileName: aws.String("workers-1a2b3c4d"),
+		}).
+		Return(&eks.DeleteFargateProfileOutput{}, nil)
+
+	s := &FargateService{
+		scope: &scope.FargateProfileScope{
+			Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: "default"}},
+			ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+				Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: eksClusterName},
+			},
+			FargateProfile: &expinfrav1.AWSFargateProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default"},
+				Spec:       expinfrav1.FargateProfileSpec{ClusterName: clusterName, ProfileName: "workers"},
+			},
+		},
+		EKSClient: eksMock,
+	}
+
+	requeue, err := s.deleteReplacementProfiles()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(requeue).To(BeTrue())
+}
+
+func TestRecoverActiveProfileName(t *testing.T) {
+	clusterName := "capi-name"
+	eksClusterName := "default_capi-name"
+	ownedTag := infrav1.ClusterAWSCloudProviderTagKey(clusterName)
+	profile := func(name, status, owner string, active bool, created time.Time) *eks.FargateProfile {
+		tags := map[string]string{
+			ownedTag:                       string(infrav1.ResourceLifecycleOwned),
+			infrav1.FargateProfileOwnerTag: owner,
+		}
+		if active {
+			tags[infrav1.FargateProfileActiveTag] = "true"
+		}
+		return &eks.FargateProfile{
+			FargateProfileName: aws.String(name),
+			Status:             aws.String(status),
+			CreatedAt:          aws.Time(created),
+			Tags:               aws.StringMap(tags),
+		}
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		profiles []*eks.FargateProfile
+		expected string
+	}{
+		{
+			name:     "no profiles falls back to the profile name",
+			expected: "workers",
+		},
+		{
+			name: "the profile tagged as active is used",
+			profiles: []*eks.FargateProfile{
+				profile("workers", eks.FargateProfileStatusDeleting, "default/workers", true, now.Add(-2*time.Hour)),
+				profile("workers-1a2b3c4d", eks.FargateProfileStatusActive, "default/workers", true, now.Add(-time.Hour)),
+				profile("workers-5e6f7g8h", eks.FargateProfileStatusCreating, "default/workers", false, now),
+			},
+			expected: "workers-1a2b3c4d",
+		},
+		{
+			name: "the newest profile is used while a replacement completes",
+			profiles: []*eks.FargateProfile{
+				profile("workers-1a2b3c4d", eks.FargateProfileStatusActive, "default/workers", true, now.Add(-time.Hour)),
+				profile("workers-5e6f7g8h", eks.FargateProfileStatusActive, "default/workers", true, now),
+			},
+			expected: "workers-5e6f7g8h",
+		},
+		{
+			name: "profiles of other AWSFargateProfiles are ignored",
+			profiles: []*eks.FargateProfile{
+				profile("workers-1a2b3c4d", eks.FargateProfileStatusActive, "default/frontend", true, now),
+			},
+			expected: "workers",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mockControl := gomock.NewController(t)
+			defer mockControl.Finish()
+			eksMock := mock_eksiface.NewMockEKSAPI(mockControl)
+
+			profiles := map[string]*eks.FargateProfile{}
+			names := []string{}
+			for _, p := range tc.profiles {
+				profiles[aws.StringValue(p.FargateProfileName)] = p
+				names = append(names, aws.StringValue(p.FargateProfileName))
+			}
+			eksMock.EXPECT().
+				ListFargateProfilesPages(&eks.ListFargateProfilesInput{ClusterName: aws.String(eksClusterName)}, gomock.Any()).
+				DoAndReturn(func(_ *eks.ListFargateProfilesInput, fn func(*eks.ListFargateProfilesOutput, bool) bool) error {
+					fn(&eks.ListFargateProfilesOutput{FargateProfileNames: aws.StringSlice(names)}, true)
+					return nil
+				})
+			eksMock.EXPECT().
+				DescribeFargateProfile(gomock.AssignableToTypeOf(&eks.DescribeFargateProfileInput{})).
+				DoAndReturn(func(input *eks.DescribeFargateProfileInput) (*eks.DescribeFargateProfileOutput, error) {
+					return &eks.DescribeFargateProfileOutput{FargateProfile: profiles[aws.StringValue(input.FargateProfileName)]}, nil
+				}).
+				AnyTimes()
+
+			s := &FargateService{
+				scope: &scope.FargateProfileScope{
+					Logger:  *logger.NewLogger(klog.Background()),
+					Cluster: &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: "default"}},
+					ControlPlane: &ekscontrolplanev1.AWSManagedControlPlane{
+						Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{EKSClusterName: eksClusterName},
+					},
+					FargateProfile: &expinfrav1.AWSFargateProfile{
+						ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: "default"},
+						Spec:       expinfrav1.FargateProfileSpec{ClusterName: clusterName, ProfileName: "workers"},
+					},
+				},
+				EKSClient: eksMock,
+			}
+
+			g.Expect(s.recoverActiveProfileName()).To(Succeed())
+			g.Expect(s.activeProfileName()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestProfileMatchesSpec(t *testing.T) {
+	selectors := []expinfrav1.FargateSelector{{Namespace: "default"}}
+	controlPlane := &ekscontrolplanev1.AWSManagedControlPlane{
+		Spec: ekscontrolplanev1.AWSManagedControlPlaneSpec{
+			NetworkSpec: infrav1.NetworkSpec{
+				Subnets: infrav1.Subnets{{ID: "subnet-private-2", IsPublic: false}},
+			},
+		},
+	}
+	profile := &eks.FargateProfile{
+		Subnets:   aws.StringSlice([]string{"subnet-private-1"}),
+		Selectors: []*eks.FargateProfileSelector{{Namespace: aws.String("default")}},
+	}
+
+	tests := []struct {
+		name      string
+		subnetIDs []string
+		selectors []expinfrav1.FargateSelector
+		expected  bool
+	}{
+		{
+			name:      "control plane subnets aren't compared",
+			selectors: selectors,
+			expected:  true,
+		},
+		{
+			name:      "subnets set in the spec are compared",
+			subnetIDs: []string{"subnet-private-3"},
+			selectors: selectors,
+			expected:  false,
+		},
+		{
+			name:      "selectors are compared",
+			selectors: []expinfrav1.FargateSelector{{Namespace: "kube-system"}},
+			expected:  false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &FargateService{
+				scope: &scope.FargateProfileScope{
+					ControlPlane: controlPlane,
+					FargateProfile: &expinfrav1.AWSFargateProfile{
+						Spec: expinfrav1.FargateProfileSpec{ProfileName: "workers", SubnetIDs: tc.subnetIDs, Selectors: tc.selectors},
+					},
+				},
+			}
+
+			g.Expect(s.profileMatchesSpec(profile)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -419,7 +419,12 @@ const stsAWSAudience = "sts.amazonaws.com"
 
 // CreateOIDCProvider will create an OIDC provider.
 func (s *IAMService) CreateOIDCProvider(cluster *eks.Cluster) (string, error) {
-	issuerURL, err := url.Parse(*cluster.Identity.Oidc.Issuer)
+	return s.CreateOIDCProviderForIssuer(*cluster.Identity.Oidc.Issuer)
+}
+
+// CreateOIDCProviderForIssuer will create an OIDC provider trusting the given issuer URL.
+func (s *IAMService) CreateOIDCProviderForIssuer(issuer string) (string, error) {
+	issuerURL, err := url.Parse(issuer)
 	if err != nil {
 		return "", err
 	}
@@ -446,7 +451,13 @@ func (s *IAMService) CreateOIDCProvider(cluster *eks.Cluster) (string, error) {
 // FindAndVerifyOIDCProvider will try to find an OIDC provider. It will return an error if the found provider does not
 // match the cluster spec.
 func (s *IAMService) FindAndVerifyOIDCProvider(cluster *eks.Cluster) (string, error) {
-	issuerURL, err := url.Parse(*cluster.Identity.Oidc.Issuer)
+	return s.FindAndVerifyOIDCProviderForIssuer(*cluster.Identity.Oidc.Issuer)
+}
+
+// FindAndVerifyOIDCProviderForIssuer will try to find an OIDC provider for the given issuer URL. It will return an
+// error if the found provider does not match the issuer.
+func (s *IAMService) FindAndVerifyOIDCProviderForIssuer(issuer string) (string, error) {
+	issuerURL, err := url.Parse(issuer)
 	if err != nil {
 		return "", err
 	}
@@ -27,6 +27,7 @@ import (
 	request "github.com/aws/aws-sdk-go/aws/request"
 	eks "github.com/aws/aws-sdk-go/service/eks"
 	gomock "github.com/golang/mock/gomock"
+	nodegroup "sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/nodegroup"
 )
 
 // MockEKSAPI is a mock of EKSAPI interface.
@@ -502,6 +503,21 @@ func (mr *MockEKSAPIMockRecorder) CreateNodegroupWithContext(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNodegroupWithContext", reflect.TypeOf((*MockEKSAPI)(nil).CreateNodegroupWithContext), varargs...)
 }
 
+// CreateNodegroupWithRepairConfig mocks base method.
+func (m *MockEKSAPI) CreateNodegroupWithRepairConfig(arg0 *nodegroup.CreateInput) (*eks.CreateNodegroupOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNodegroupWithRepairConfig", arg0)
+	ret0, _ := ret[0].(*eks.CreateNodegroupOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNodegroupWithRepairConfig indicates an expected call of CreateNodegroupWithRepairConfig.
+func (mr *MockEKSAPIMockRecorder) CreateNodegroupWithRepairConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNodegroupWithRepairConfig", reflect.TypeOf((*MockEKSAPI)(nil).CreateNodegroupWithRepairConfig), arg0)
+}
+
 // CreatePodIdentityAssociation mocks base method.
 func (m *MockEKSAPI) CreatePodIdentityAssociation(arg0 *eks.CreatePodIdentityAssociationInput) (*eks.CreatePodIdentityAssociationOutput, error) {
 	m.ctrl.T.Helper()
@@ -1450,6 +1466,21 @@ func (mr *MockEKSAPIMockRecorder) DescribeNodegroup(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNodegroup", reflect.TypeOf((*MockEKSAPI)(nil).DescribeNodegroup), arg0)
 }
 
+// DescribeNodegroupConfig mocks base method.
+func (m *MockEKSAPI) DescribeNodegroupConfig(arg0 *eks.DescribeNodegroupInput) (*nodegroup.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeNodegroupConfig", arg0)
+	ret0, _ := ret[0].(*nodegroup.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeNodegroupConfig indicates an expected call of DescribeNodegroupConfig.
+func (mr *MockEKSAPIMockRecorder) DescribeNodegroupConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeNodegroupConfig", reflect.TypeOf((*MockEKSAPI)(nil).DescribeNodegroupConfig), arg0)
+}
+
 // DescribeNodegroupRequest mocks base method.
 func (m *MockEKSAPI) DescribeNodegroupRequest(arg0 *eks.DescribeNodegroupInput) (*request.Request, *eks.DescribeNodegroupOutput) {
 	m.ctrl.T.Helper()
@@ -3181,6 +3212,21 @@ func (mr *MockEKSAPIMockRecorder) UpdateNodegroupConfigWithContext(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodegroupConfigWithContext", reflect.TypeOf((*MockEKSAPI)(nil).UpdateNodegroupConfigWithContext), varargs...)
 }
 
+// UpdateNodegroupConfigWithRepairConfig mocks base method.
+func (m *MockEKSAPI) UpdateNodegroupConfigWithRepairConfig(arg0 *nodegroup.UpdateConfigInput) (*eks.UpdateNodegroupConfigOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNodegroupConfigWithRepairConfig", arg0)
+	ret0, _ := ret[0].(*eks.UpdateNodegroupConfigOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNodegroupConfigWithRepairConfig indicates an expected call of UpdateNodegroupConfigWithRepairConfig.
+func (mr *MockEKSAPIMockRecorder) UpdateNodegroupConfigWithRepairConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNodegroupConfigWithRepairConfig", reflect.TypeOf((*MockEKSAPI)(nil).UpdateNodegroupConfigWithRepairConfig), arg0)
+}
+
 // UpdateNodegroupVersion mocks base method.
 func (m *MockEKSAPI) UpdateNodegroupVersion(arg0 *eks.UpdateNodegroupVersionInput) (*eks.UpdateNodegroupVersionOutput, error) {
 	m.ctrl.T.Helper()
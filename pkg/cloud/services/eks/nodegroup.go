@@ -19,6 +19,7 @@ package eks
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -35,9 +36,11 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/awserrors"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/converters"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/wait"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/nodegroup"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func (s *NodegroupService) describeNodegroup() (*eks.Nodegroup, error) {
@@ -121,6 +124,41 @@ func (s *NodegroupService) updateConfig() *eks.NodegroupUpdateConfig {
 	return converters.NodegroupUpdateconfigToSDK(updateConfig)
 }
 
+func (s *NodegroupService) updateConfigWithStrategy() *nodegroup.UpdateConfig {
+	updateConfig := s.scope.ManagedMachinePool.Spec.UpdateConfig
+	converted := converters.NodegroupUpdateconfigToSDK(updateConfig)
+	if converted == nil {
+		return nil
+	}
+
+	cfg := &nodegroup.UpdateConfig{
+		MaxUnavailable:           converted.MaxUnavailable,
+		MaxUnavailablePercentage: converted.MaxUnavailablePercentage,
+	}
+	if updateConfig.UpdateStrategy != nil {
+		cfg.UpdateStrategy = aws.String(string(*updateConfig.UpdateStrategy))
+	}
+
+	return cfg
+}
+
+func (s *NodegroupService) nodeRepairConfig() *nodegroup.NodeRepairConfig {
+	repairConfig := s.scope.ManagedMachinePool.Spec.NodeRepairConfig
+	if repairConfig == nil {
+		return nil
+	}
+
+	return &nodegroup.NodeRepairConfig{
+		Enabled: aws.Bool(aws.BoolValue(repairConfig.Enabled)),
+	}
+}
+
+// needsNodegroupConfig returns true if the spec sets configuration that is only returned by DescribeNodegroupConfig.
+func (s *NodegroupService) needsNodegroupConfig() bool {
+	managedPool := s.scope.ManagedMachinePool.Spec
+	return managedPool.NodeRepairConfig != nil || (managedPool.UpdateConfig != nil && managedPool.UpdateConfig.UpdateStrategy != nil)
+}
+
 func (s *NodegroupService) roleArn() (*string, error) {
 	var role *iam.Role
 	if s.scope.RoleName() != "" {
@@ -133,6 +171,24 @@ func (s *NodegroupService) roleArn() (*string, error) {
 	return role.Arn, nil
 }
 
+// currentUpdateConfig returns the update config of the nodegroup to compare with the desired update config.
+// The update strategy is only compared when EKS returns it, otherwise the nodegroup would be updated on
+// every reconcile.
+func currentUpdateConfig(ng *eks.Nodegroup, ngConfig *nodegroup.Config, desired *expinfrav1.UpdateConfig) *expinfrav1.UpdateConfig {
+	current := converters.NodegroupUpdateconfigFromSDK(ng.UpdateConfig)
+	if current == nil || desired == nil || desired.UpdateStrategy == nil {
+		return current
+	}
+
+	current.UpdateStrategy = desired.UpdateStrategy
+	if ngConfig != nil && ngConfig.UpdateConfig != nil && ngConfig.UpdateConfig.UpdateStrategy != nil {
+		updateStrategy := expinfrav1.NodegroupUpdateStrategy(*ngConfig.UpdateConfig.UpdateStrategy)
+		current.UpdateStrategy = &updateStrategy
+	}
+
+	return current
+}
+
 func ngTags(key string, additionalTags infrav1.Tags) map[string]string {
 	tags := additionalTags.DeepCopy()
 	tags[infrav1.ClusterAWSCloudProviderTagKey(key)] = string(infrav1.ResourceLifecycleOwned)
@@ -253,7 +309,13 @@ func (s *NodegroupService) createNodegroup() (*eks.Nodegroup, error) {
 		return nil, errors.Wrap(err, "created invalid CreateNodegroupInput")
 	}
 
-	out, err := s.EKSClient.CreateNodegroup(input)
+	var out *eks.CreateNodegroupOutput
+	if s.needsNodegroupConfig() {
+		// The node repair config and update strategy are only available through the raw request.
+		out, err = s.EKSClient.CreateNodegroupWithRepairConfig(nodegroup.NewCreateInput(input, s.nodeRepairConfig(), s.updateConfigWithStrategy()))
+	} else {
+		out, err = s.EKSClient.CreateNodegroup(input)
+	}
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -447,10 +509,21 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 	s.Debug("reconciling node group config", "cluster", eksClusterName, "name", *ng.NodegroupName)
 
 	managedPool := s.scope.ManagedMachinePool.Spec
-	input := &eks.UpdateNodegroupConfigInput{
+	input := &nodegroup.UpdateConfigInput{
 		ClusterName:   aws.String(eksClusterName),
 		NodegroupName: aws.String(managedPool.EKSNodegroupName),
 	}
+	var ngConfig *nodegroup.Config
+	if s.needsNodegroupConfig() {
+		var err error
+		ngConfig, err = s.EKSClient.DescribeNodegroupConfig(&eks.DescribeNodegroupInput{
+			ClusterName:   aws.String(eksClusterName),
+			NodegroupName: aws.String(managedPool.EKSNodegroupName),
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to describe nodegroup config")
+		}
+	}
 	var needsUpdate bool
 	if labelPayload := createLabelUpdate(managedPool.Labels, ng); labelPayload != nil {
 		s.Debug("Nodegroup labels need an update", "nodegroup", ng.NodegroupName)
@@ -483,12 +556,22 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		input.ScalingConfig = s.scalingConfig()
 		needsUpdate = true
 	}
-	currentUpdateConfig := converters.NodegroupUpdateconfigFromSDK(ng.UpdateConfig)
-	if !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig) {
+	if !cmp.Equal(managedPool.UpdateConfig, currentUpdateConfig(ng, ngConfig, managedPool.UpdateConfig)) {
 		s.Debug("Nodegroup update configuration differs from spec, updating the nodegroup update config", "nodegroup", ng.NodegroupName)
-		input.UpdateConfig = s.updateConfig()
+		input.UpdateConfig = s.updateConfigWithStrategy()
 		needsUpdate = true
 	}
+	if managedPool.NodeRepairConfig != nil {
+		var currentEnabled bool
+		if ngConfig != nil && ngConfig.NodeRepairConfig != nil {
+			currentEnabled = aws.BoolValue(ngConfig.NodeRepairConfig.Enabled)
+		}
+		if aws.BoolValue(managedPool.NodeRepairConfig.Enabled) != currentEnabled {
+			s.Debug("Nodegroup node repair configuration differs from spec, updating the nodegroup node repair config", "nodegroup", ng.NodegroupName)
+			input.NodeRepairConfig = s.nodeRepairConfig()
+			needsUpdate = true
+		}
+	}
 	if !needsUpdate {
 		s.Debug("node group config update not needed", "cluster", eksClusterName, "name", *ng.NodegroupName)
 		return nil
@@ -497,7 +580,7 @@ func (s *NodegroupService) reconcileNodegroupConfig(ng *eks.Nodegroup) error {
 		return errors.Wrap(err, "created invalid UpdateNodegroupConfigInput")
 	}
 
-	_, err = s.EKSClient.UpdateNodegroupConfig(input)
+	_, err = s.EKSClient.UpdateNodegroupConfigWithRepairConfig(input)
 	if err != nil {
 		return errors.Wrap(err, "failed to update nodegroup config")
 	}
@@ -583,6 +666,9 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 		managedPool.Status.Ready = false
 		// TODO FailureReason
 		failureMsg := fmt.Sprintf("EKS nodegroup in failed %s status", *ng.Status)
+		if issues := nodegroupHealthIssues(ng); len(issues) > 0 {
+			failureMsg = fmt.Sprintf("%s: %s", failureMsg, nodegroupHealthIssuesMessage(issues))
+		}
 		managedPool.Status.FailureMessage = &failureMsg
 	case eks.NodegroupStatusActive:
 		managedPool.Status.Ready = true
@@ -595,6 +681,7 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 	default:
 		return errors.Errorf("unexpected EKS nodegroup status %s", *ng.Status)
 	}
+	setNodegroupHealthCondition(managedPool, ng)
 	if managedPool.Status.Ready && ng.Resources != nil && len(ng.Resources.AutoScalingGroups) > 0 {
 		req := autoscaling.DescribeAutoScalingGroupsInput{}
 		for _, asg := range ng.Resources.AutoScalingGroups {
@@ -622,6 +709,44 @@ func (s *NodegroupService) setStatus(ng *eks.Nodegroup) error {
 	return nil
 }
 
+func nodegroupHealthIssues(ng *eks.Nodegroup) []*eks.Issue {
+	if ng.Health == nil {
+		return nil
+	}
+	return ng.Health.Issues
+}
+
+// nodegroupHealthIssuesMessage formats the health issues of a nodegroup as a message.
+func nodegroupHealthIssuesMessage(issues []*eks.Issue) string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		msg := fmt.Sprintf("%s: %s", aws.StringValue(issue.Code), aws.StringValue(issue.Message))
+		if len(issue.ResourceIds) > 0 {
+			msg = fmt.Sprintf("%s (%s)", msg, strings.Join(aws.StringValueSlice(issue.ResourceIds), ", "))
+		}
+		messages = append(messages, msg)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// setNodegroupHealthCondition sets the EKSNodegroupHealthyCondition from the health issues of the nodegroup.
+func setNodegroupHealthCondition(managedPool *expinfrav1.AWSManagedMachinePool, ng *eks.Nodegroup) {
+	issues := nodegroupHealthIssues(ng)
+	if len(issues) == 0 {
+		conditions.MarkTrue(managedPool, expinfrav1.EKSNodegroupHealthyCondition)
+		return
+	}
+
+	conditions.MarkFalse(
+		managedPool,
+		expinfrav1.EKSNodegroupHealthyCondition,
+		aws.StringValue(issues[0].Code),
+		clusterv1.ConditionSeverityWarning,
+		"%s",
+		nodegroupHealthIssuesMessage(issues),
+	)
+}
+
 func (s *NodegroupService) waitForNodegroupActive() (*eks.Nodegroup, error) {
 	eksClusterName := s.scope.KubernetesClusterName()
 	eksNodegroupName := s.scope.NodegroupName()
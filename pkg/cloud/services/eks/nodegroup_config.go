@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/nodegroup"
+)
+
+// DescribeNodegroupConfig returns the node repair config and update config of a nodegroup.
+// It's adapted from DescribeNodegroup in aws-sdk-go/service/eks/api.go.
+func (c EKSClient) DescribeNodegroupConfig(input *eks.DescribeNodegroupInput) (*nodegroup.Config, error) {
+	req, _ := c.DescribeNodegroupRequest(input)
+	output := &nodegroup.DescribeConfigOutput{}
+	req.Data = output
+	req.SetContext(aws.BackgroundContext())
+	if err := req.Send(); err != nil {
+		return nil, err
+	}
+
+	return output.Nodegroup, nil
+}
+
+// UpdateNodegroupConfigWithRepairConfig updates the configuration of a nodegroup including the
+// node repair config and update strategy. It's adapted from UpdateNodegroupConfig in
+// aws-sdk-go/service/eks/api.go.
+func (c EKSClient) UpdateNodegroupConfigWithRepairConfig(input *nodegroup.UpdateConfigInput) (*eks.UpdateNodegroupConfigOutput, error) {
+	req, output := c.UpdateNodegroupConfigRequest(&eks.UpdateNodegroupConfigInput{})
+	req.Params = input
+	req.SetContext(aws.BackgroundContext())
+
+	return output, req.Send()
+}
+
+// CreateNodegroupWithRepairConfig creates a nodegroup including the node repair config and update
+// strategy. It's adapted from CreateNodegroup in aws-sdk-go/service/eks/api.go.
+func (c EKSClient) CreateNodegroupWithRepairConfig(input *nodegroup.CreateInput) (*eks.CreateNodegroupOutput, error) {
+	req, output := c.CreateNodegroupRequest(&eks.CreateNodegroupInput{})
+	req.Params = input
+	req.SetContext(aws.BackgroundContext())
+
+	return output, req.Send()
+}
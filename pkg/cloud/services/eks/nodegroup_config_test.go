@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eks
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/gomega"
+
+	expinfrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/exp/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/nodegroup"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newTestEKSClient(t *testing.T, handler http.HandlerFunc) EKSClient {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	sess := session.Must(session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}))
+
+	return EKSClient{EKSAPI: eks.New(sess)}
+}
+
+func TestDescribeNodegroupConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	client := newTestEKSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodGet))
+		g.Expect(r.URL.Path).To(Equal("/clusters/cluster/node-groups/nodegroup"))
+		_, _ = w.Write([]byte(`{"nodegroup":{"nodegroupName":"nodegroup","nodeRepairConfig":{"enabled":true},"updateConfig":{"maxUnavailable":2,"updateStrategy":"MINIMAL"}}}`))
+	})
+
+	config, err := client.DescribeNodegroupConfig(&eks.DescribeNodegroupInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("nodegroup"),
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config).To(Equal(&nodegroup.Config{
+		NodeRepairConfig: &nodegroup.NodeRepairConfig{Enabled: aws.Bool(true)},
+		UpdateConfig: &nodegroup.UpdateConfig{
+			MaxUnavailable: aws.Int64(2),
+			UpdateStrategy: aws.String("MINIMAL"),
+		},
+	}))
+}
+
+func TestUpdateNodegroupConfigWithRepairConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	var body map[string]interface{}
+	client := newTestEKSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.URL.Path).To(Equal("/clusters/cluster/node-groups/nodegroup/update-config"))
+		raw, err := io.ReadAll(r.Body)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(json.Unmarshal(raw, &body)).To(Succeed())
+		_, _ = w.Write([]byte(`{"update":{"id":"update-id"}}`))
+	})
+
+	output, err := client.UpdateNodegroupConfigWithRepairConfig(&nodegroup.UpdateConfigInput{
+		ClusterName:      aws.String("cluster"),
+		NodegroupName:    aws.String("nodegroup"),
+		NodeRepairConfig: &nodegroup.NodeRepairConfig{Enabled: aws.Bool(true)},
+		UpdateConfig: &nodegroup.UpdateConfig{
+			MaxUnavailablePercentage: aws.Int64(50),
+			UpdateStrategy:           aws.String("DEFAULT"),
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(aws.StringValue(output.Update.Id)).To(Equal("update-id"))
+	g.Expect(body).To(HaveKeyWithValue("nodeRepairConfig", map[string]interface{}{"enabled": true}))
+	g.Expect(body).To(HaveKeyWithValue("updateConfig", map[string]interface{}{"maxUnavailablePercentage": float64(50), "updateStrategy": "DEFAULT"}))
+	g.Expect(body).To(HaveKey("clientRequestToken"))
+	g.Expect(body).NotTo(HaveKey("name"))
+}
+
+func TestCreateNodegroupWithRepairConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	var body map[string]interface{}
+	client := newTestEKSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.URL.Path).To(Equal("/clusters/cluster/node-groups"))
+		raw, err := io.ReadAll(r.Body)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(json.Unmarshal(raw, &body)).To(Succeed())
+		_, _ = w.Write([]byte(`{"nodegroup":{"nodegroupName":"nodegroup"}}`))
+	})
+
+	input := &eks.CreateNodegroupInput{
+		ClusterName:   aws.String("cluster"),
+		NodegroupName: aws.String("nodegroup"),
+		NodeRole:      aws.String("role"),
+		Subnets:       aws.StringSlice([]string{"subnet-1"}),
+	}
+	output, err := client.CreateNodegroupWithRepairConfig(nodegroup.NewCreateInput(input,
+		&nodegroup.NodeRepairConfig{Enabled: aws.Bool(true)},
+		&nodegroup.UpdateConfig{MaxUnavailable: aws.Int64(1), UpdateStrategy: aws.String("MINIMAL")},
+	))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(aws.StringValue(output.Nodegroup.NodegroupName)).To(Equal("nodegroup"))
+	g.Expect(body).To(HaveKeyWithValue("nodegroupName", "nodegroup"))
+	g.Expect(body).To(HaveKeyWithValue("nodeRole", "role"))
+	g.Expect(body).To(HaveKeyWithValue("subnets", []interface{}{"subnet-1"}))
+	g.Expect(body).To(HaveKeyWithValue("nodeRepairConfig", map[string]interface{}{"enabled": true}))
+	g.Expect(body).To(HaveKeyWithValue("updateConfig", map[string]interface{}{"maxUnavailable": float64(1), "updateStrategy": "MINIMAL"}))
+	g.Expect(body).NotTo(HaveKey("name"))
+}
+
+func TestCurrentUpdateConfig(t *testing.T) {
+	minimal := expinfrav1.NodegroupUpdateStrategyMinimal
+	defaultStrategy := expinfrav1.NodegroupUpdateStrategyDefault
+	ng := &eks.Nodegroup{UpdateConfig: &eks.NodegroupUpdateConfig{MaxUnavailable: aws.Int64(1)}}
+
+	tests := []struct {
+		name     string
+		ngConfig *nodegroup.Config
+		desired  *expinfrav1.UpdateConfig
+		expected *expinfrav1.UpdateConfig
+	}{
+		{
+			name:     "no update strategy",
+			desired:  &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1)},
+			expected: &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1)},
+		},
+		{
+			name:     "update strategy returned by EKS",
+			ngConfig: &nodegroup.Config{UpdateConfig: &nodegroup.UpdateConfig{UpdateStrategy: aws.String("DEFAULT")}},
+			desired:  &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1), UpdateStrategy: &minimal},
+			expected: &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1), UpdateStrategy: &defaultStrategy},
+		},
+		{
+			name:     "update strategy not returned by EKS",
+			ngConfig: &nodegroup.Config{UpdateConfig: &nodegroup.UpdateConfig{MaxUnavailable: aws.Int64(1)}},
+			desired:  &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1), UpdateStrategy: &minimal},
+			expected: &expinfrav1.UpdateConfig{MaxUnavailable: aws.Int(1), UpdateStrategy: &minimal},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(currentUpdateConfig(ng, tc.ngConfig, tc.desired)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestSetNodegroupHealthCondition(t *testing.T) {
+	tests := []struct {
+		name          string
+		health        *eks.NodegroupHealth
+		expectedState bool
+		reason        string
+		message       string
+	}{
+		{
+			name:          "no health issues",
+			health:        &eks.NodegroupHealth{},
+			expectedState: true,
+		},
+		{
+			name: "health issues",
+			health: &eks.NodegroupHealth{
+				Issues: []*eks.Issue{
+					{
+						Code:        aws.String(eks.NodegroupIssueCodeAsgInstanceLaunchFailures),
+						Message:     aws.String("Instance launch failed"),
+						ResourceIds: aws.StringSlice([]string{"asg-1", "asg-2"}),
+					},
+					{
+						Code:    aws.String(eks.NodegroupIssueCodeInsufficientFreeAddresses),
+						Message: aws.String("Not enough addresses"),
+					},
+				},
+			},
+			expectedState: false,
+			reason:        eks.NodegroupIssueCodeAsgInstanceLaunchFailures,
+			message:       "AsgInstanceLaunchFailures: Instance launch failed (asg-1, asg-2); InsufficientFreeAddresses: Not enough addresses",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			managedPool := &expinfrav1.AWSManagedMachinePool{}
+
+			setNodegroupHealthCondition(managedPool, &eks.Nodegroup{Health: tc.health})
+
+			condition := conditions.Get(managedPool, expinfrav1.EKSNodegroupHealthyCondition)
+			g.Expect(condition).NotTo(BeNil())
+			if tc.expectedState {
+				g.Expect(condition.Status).To(BeEquivalentTo("True"))
+				return
+			}
+			g.Expect(condition.Status).To(BeEquivalentTo("False"))
+			g.Expect(condition.Severity).To(Equal(clusterv1.ConditionSeverityWarning))
+			g.Expect(condition.Reason).To(Equal(tc.reason))
+			g.Expect(condition.Message).To(Equal(tc.message))
+		})
+	}
+}
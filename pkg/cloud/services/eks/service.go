@@ -28,12 +28,16 @@ import (
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/iam"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/eks/nodegroup"
 )
 
 // EKSAPI defines the EKS API interface.
 type EKSAPI interface {
 	eksiface.EKSAPI
 	WaitUntilClusterUpdating(input *eks.DescribeClusterInput, opts ...request.WaiterOption) error
+	DescribeNodegroupConfig(input *eks.DescribeNodegroupInput) (*nodegroup.Config, error)
+	UpdateNodegroupConfigWithRepairConfig(input *nodegroup.UpdateConfigInput) (*eks.UpdateNodegroupConfigOutput, error)
+	CreateNodegroupWithRepairConfig(input *nodegroup.CreateInput) (*eks.CreateNodegroupOutput, error)
 }
 
 // EKSClient defines a wrapper over EKS API.
@@ -91,7 +95,7 @@ func NewService(controlPlaneScope *scope.ManagedControlPlaneScope, opts ...Servi
 type NodegroupService struct {
 	scope             *scope.ManagedMachinePoolScope
 	AutoscalingClient autoscalingiface.AutoScalingAPI
-	EKSClient         eksiface.EKSAPI
+	EKSClient         EKSAPI
 	iam.IAMService
 	STSClient stsiface.STSAPI
 }
@@ -101,7 +105,9 @@ func NewNodegroupService(machinePoolScope *scope.ManagedMachinePoolScope) *Nodeg
 	return &NodegroupService{
 		scope:             machinePoolScope,
 		AutoscalingClient: scope.NewASGClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
-		EKSClient:         scope.NewEKSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		EKSClient: EKSClient{
+			EKSAPI: scope.NewEKSClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
+		},
 		IAMService: iam.IAMService{
 			Wrapper:   &machinePoolScope.Logger,
 			IAMClient: scope.NewIAMClient(machinePoolScope, machinePoolScope, machinePoolScope, machinePoolScope.ManagedMachinePool),
@@ -176,8 +176,25 @@ func (s *NodegroupService) reconcileASGTags(ng *eks.Nodegroup) error {
 }
 
 func (s *FargateService) reconcileTags(fp *eks.FargateProfile) error {
+	return updateTags(s.EKSClient, fp.FargateProfileArn, aws.StringValueMap(fp.Tags), s.activeProfileTags())
+}
+
+// profileTags returns the tags of the EKS fargate profiles created for the AWSFargateProfile.
+func (s *FargateService) profileTags() map[string]string {
 	tags := ngTags(s.scope.ClusterName(), s.scope.AdditionalTags())
-	return updateTags(s.EKSClient, fp.FargateProfileArn, aws.StringValueMap(fp.Tags), tags)
+	tags[infrav1.FargateProfileOwnerTag] = s.ownerTagValue()
+	return tags
+}
+
+// activeProfileTags returns the tags of the EKS fargate profile currently in use.
+func (s *FargateService) activeProfileTags() map[string]string {
+	tags := s.profileTags()
+	tags[infrav1.FargateProfileActiveTag] = "true"
+	return tags
+}
+
+func (s *FargateService) ownerTagValue() string {
+	return fmt.Sprintf("%s/%s", s.scope.FargateProfile.Namespace, s.scope.FargateProfile.Name)
 }
 
 func updateTags(client eksiface.EKSAPI, arn *string, existingTags, desiredTags map[string]string) error {
@@ -104,6 +104,14 @@ type SecretInterface interface {
 	UserData(secretPrefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) ([]byte, error)
 }
 
+// ChunkedSecretInterface encapsulates the methods to store data, which isn't owned by a
+// machine, in a secret backend and fetch it on instances.
+type ChunkedSecretInterface interface {
+	CreateChunks(name string, data []byte, additionalTags infrav1.Tags) (string, int32, error)
+	DeleteChunks(prefix string, chunks int32) error
+	FetchCommand(prefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) string
+}
+
 // ELBInterface encapsulates the methods exposed to the cluster and machine
 // controller.
 type ELBInterface interface {
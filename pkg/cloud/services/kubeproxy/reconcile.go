@@ -21,17 +21,24 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/record"
 )
 
 const (
-	kubeProxyName      = "kube-proxy"
-	kubeProxyNamespace = "kube-system"
+	kubeProxyName       = "kube-proxy"
+	kubeProxyNamespace  = "kube-system"
+	kubeProxyConfigName = "kube-proxy-config"
+	kubeProxyConfigKey  = "config"
+
+	ipv6BindAddress        = "::"
+	ipv6MetricsBindAddress = "[::]:10249"
 )
 
 // ReconcileKubeProxy will reconcile kube-proxy.
@@ -48,6 +55,58 @@ func (s *Service) ReconcileKubeProxy(ctx context.Context) error {
 		if err := s.deleteKubeProxy(ctx, remoteClient); err != nil {
 			return fmt.Errorf("disabling kube-proxy: %w", err)
 		}
+		return nil
+	}
+
+	// The kube-proxy of a cluster migrating to the kube-proxy addon is managed by the addon.
+	if s.scope.KubeProxy().MigrateToAddon != nil {
+		s.scope.Info("kube-proxy is managed by the kube-proxy addon, skipping", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+		return nil
+	}
+
+	if s.scope.VPC().IsIPv6Enabled() {
+		if err := s.reconcileIPv6Config(ctx, remoteClient); err != nil {
+			return fmt.Errorf("configuring kube-proxy for ipv6: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileIPv6Config ensures kube-proxy binds to IPv6 addresses.
+func (s *Service) reconcileIPv6Config(ctx context.Context, remoteClient client.Client) error {
+	cm := &corev1.ConfigMap{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{Namespace: kubeProxyNamespace, Name: kubeProxyConfigName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.scope.Debug("The kube-proxy ConfigMap is not found, no action")
+			return nil
+		}
+		return fmt.Errorf("getting kube-proxy configmap: %w", err)
+	}
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(cm.Data[kubeProxyConfigKey]), &config); err != nil {
+		return fmt.Errorf("parsing kube-proxy config: %w", err)
+	}
+
+	if config["bindAddress"] == ipv6BindAddress && config["metricsBindAddress"] == ipv6MetricsBindAddress {
+		return nil
+	}
+	config["bindAddress"] = ipv6BindAddress
+	config["metricsBindAddress"] = ipv6MetricsBindAddress
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("marshalling kube-proxy config: %w", err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[kubeProxyConfigKey] = string(data)
+
+	s.scope.Info("Updating kube-proxy ConfigMap for ipv6", "cluster", klog.KRef(s.scope.Namespace(), s.scope.Name()))
+	if err := remoteClient.Update(ctx, cm, &client.UpdateOptions{}); err != nil {
+		return fmt.Errorf("updating kube-proxy configmap: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeproxy
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	ekscontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/eks/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+func TestReconcileKubeProxyIPv6(t *testing.T) {
+	tests := []struct {
+		name   string
+		vpc    infrav1.VPCSpec
+		config string
+		expect map[string]interface{}
+	}{
+		{
+			name:   "ipv4 cluster config is unchanged",
+			config: "bindAddress: 0.0.0.0\nmetricsBindAddress: 0.0.0.0:10249\nmode: iptables\n",
+			expect: map[string]interface{}{
+				"bindAddress":        "0.0.0.0",
+				"metricsBindAddress": "0.0.0.0:10249",
+				"mode":               "iptables",
+			},
+		},
+		{
+			name: "ipv6 cluster binds to ipv6 addresses",
+			vpc: infrav1.VPCSpec{
+				IPv6: &infrav1.IPv6{},
+			},
+			config: "bindAddress: 0.0.0.0\nmetricsBindAddress: 0.0.0.0:10249\nmode: iptables\n",
+			expect: map[string]interface{}{
+				"bindAddress":        "::",
+				"metricsBindAddress": "[::]:10249",
+				"mode":               "iptables",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			remoteClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      kubeProxyConfigName,
+					Namespace: kubeProxyNamespace,
+				},
+				Data: map[string]string{
+					kubeProxyConfigKey: tc.config,
+				},
+			}).Build()
+			s := NewService(&mockScope{client: remoteClient, vpc: tc.vpc})
+
+			g.Expect(s.ReconcileKubeProxy(context.Background())).To(Succeed())
+
+			cm := &corev1.ConfigMap{}
+			g.Expect(remoteClient.Get(context.Background(), types.NamespacedName{Namespace: kubeProxyNamespace, Name: kubeProxyConfigName}, cm)).To(Succeed())
+			config := map[string]interface{}{}
+			g.Expect(yaml.Unmarshal([]byte(cm.Data[kubeProxyConfigKey]), &config)).To(Succeed())
+			g.Expect(config).To(Equal(tc.expect))
+		})
+	}
+}
+
+func TestReconcileKubeProxyMigrateToAddon(t *testing.T) {
+	g := NewWithT(t)
+	config := "bindAddress: 0.0.0.0\nmetricsBindAddress: 0.0.0.0:10249\nmode: iptables\n"
+	remoteClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kubeProxyConfigName,
+			Namespace: kubeProxyNamespace,
+		},
+		Data: map[string]string{
+			kubeProxyConfigKey: config,
+		},
+	}).Build()
+	s := NewService(&mockScope{
+		client:    remoteClient,
+		vpc:       infrav1.VPCSpec{IPv6: &infrav1.IPv6{}},
+		kubeProxy: ekscontrolplanev1.KubeProxy{MigrateToAddon: &ekscontrolplanev1.AddonMigration{Version: "default"}},
+	})
+
+	g.Expect(s.ReconcileKubeProxy(context.Background())).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(remoteClient.Get(context.Background(), types.NamespacedName{Namespace: kubeProxyNamespace, Name: kubeProxyConfigName}, cm)).To(Succeed())
+	g.Expect(cm.Data[kubeProxyConfigKey]).To(Equal(config))
+}
+
+type mockScope struct {
+	scope.KubeProxyScope
+	client    client.Client
+	vpc       infrav1.VPCSpec
+	kubeProxy ekscontrolplanev1.KubeProxy
+}
+
+func (s *mockScope) RemoteClient() (client.Client, error) {
+	return s.client, nil
+}
+
+func (s *mockScope) DisableKubeProxy() bool {
+	return false
+}
+
+func (s *mockScope) KubeProxy() ekscontrolplanev1.KubeProxy {
+	return s.kubeProxy
+}
+
+func (s *mockScope) VPC() *infrav1.VPCSpec {
+	return &s.vpc
+}
+
+func (s *mockScope) Info(_ string, _ ...interface{}) {}
+
+func (s *mockScope) Debug(_ string, _ ...interface{}) {}
+
+func (s *mockScope) Name() string {
+	return "mock-name"
+}
+
+func (s *mockScope) Namespace() string {
+	return "mock-namespace"
+}
@@ -19,6 +19,7 @@ package secretsmanager
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/secretsmanager"
@@ -67,7 +68,29 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	if prefix == "" {
 		prefix = path.Join(entryPrefix, string(uuid.NewUUID()))
 	}
-	// Split the data into chunks and create the secrets on demand.
+	chunks, err := s.createChunks(prefix, data, tags)
+	return prefix, chunks, err
+}
+
+// CreateChunks stores data in AWS Secrets Manager, chunking at 10kb per secret. Unlike Create,
+// the data isn't owned by a machine: the secrets are named after name, and are only deleted
+// by DeleteChunks. The prefix of the secret ARN and the number of chunks are returned.
+func (s *Service) CreateChunks(name string, data []byte, additionalTags infrav1.Tags) (string, int32, error) {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Additional:  additionalTags,
+	})
+	tags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+
+	prefix := path.Join(entryPrefix, name)
+	chunks, err := s.createChunks(prefix, data, tags)
+	return prefix, chunks, err
+}
+
+// createChunks splits the data into chunks and creates the secrets on demand.
+func (s *Service) createChunks(prefix string, data []byte, tags infrav1.Tags) (int32, error) {
 	chunks := int32(0)
 	var err error
 	bytes.Split(data, false, maxSecretSizeBytes, func(chunk []byte) {
@@ -81,7 +104,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 		chunks++
 	})
 
-	return prefix, chunks, err
+	return chunks, err
 }
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
@@ -115,12 +138,36 @@ func (s *Service) forceDeleteSecretEntry(name string) error {
 
 // Delete the secret belonging to a machine from AWS Secrets Manager.
 func (s *Service) Delete(m *scope.MachineScope) error {
+	return s.DeleteChunks(m.GetSecretPrefix(), m.GetSecretCount())
+}
+
+// DeleteChunks deletes the chunks of data stored under the prefix from AWS Secrets Manager.
+func (s *Service) DeleteChunks(prefix string, chunks int32) error {
 	var errs []error
-	for i := int32(0); i < m.GetSecretCount(); i++ {
-		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s-%d", m.GetSecretPrefix(), i)); err != nil {
+	for i := int32(0); i < chunks; i++ {
+		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s-%d", prefix, i)); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
 	return kerrors.NewAggregate(errs)
 }
+
+// FetchCommand returns a shell command which writes the data stored under the prefix to
+// stdout. The command uses the AWS CLI and the credentials of the instance.
+func (s *Service) FetchCommand(prefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) string {
+	endpoint := ""
+	for _, v := range endpoints {
+		if v.ServiceID == serviceID {
+			endpoint = " --endpoint-url " + v.URL
+		}
+	}
+
+	commands := make([]string, 0, chunks)
+	for i := int32(0); i < chunks; i++ {
+		commands = append(commands, fmt.Sprintf("aws secretsmanager%s --region %s get-secret-value --output text --query SecretBinary --secret-id %s-%d | base64 -d",
+			endpoint, region, prefix, i))
+	}
+
+	return "{ " + strings.Join(commands, " && ") + "; }"
+}
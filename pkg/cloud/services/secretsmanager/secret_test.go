@@ -290,3 +290,32 @@ func getMachineScope(client client.Client, clusterScope *scope.ClusterScope) (*s
 		},
 	})
 }
+
+func TestServiceCreateChunks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	client := setupClient()
+	clusterScope, err := getClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	secretManagerClientMock := mock_secretsmanageriface.NewMockSecretsManagerAPI(mockCtrl)
+	secretManagerClientMock.EXPECT().CreateSecret(gomock.AssignableToTypeOf(&secretsmanager.CreateSecretInput{})).
+		DoAndReturn(func(input *secretsmanager.CreateSecretInput) (*secretsmanager.CreateSecretOutput, error) {
+			g.Expect(aws.StringValue(input.Name)).To(HavePrefix("aws.cluster.x-k8s.io/eksconfig/config-"))
+			return &secretsmanager.CreateSecretOutput{}, nil
+		}).Times(2)
+
+	s := NewService(clusterScope)
+	s.SecretsManagerClient = secretManagerClientMock
+
+	prefix, chunks, err := s.CreateChunks("eksconfig/config", make([]byte, 10000), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(prefix).To(Equal("aws.cluster.x-k8s.io/eksconfig/config"))
+	g.Expect(chunks).To(Equal(int32(2)))
+
+	g.Expect(s.FetchCommand(prefix, chunks, "us-east-1", nil)).To(Equal(
+		"{ aws secretsmanager --region us-east-1 get-secret-value --output text --query SecretBinary --secret-id aws.cluster.x-k8s.io/eksconfig/config-0 | base64 -d" +
+			" && aws secretsmanager --region us-east-1 get-secret-value --output text --query SecretBinary --secret-id aws.cluster.x-k8s.io/eksconfig/config-1 | base64 -d; }"))
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path"
 	"regexp"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ssm"
@@ -67,14 +68,42 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 	if prefix == "" {
 		prefix = path.Join(entryPrefix, string(uuid.NewUUID()))
 	}
+	prefix = normalizePrefix(prefix)
+
+	chunks, err := s.createChunks(prefix, data, tags)
+	return prefix, chunks, err
+}
+
+// CreateChunks stores data in AWS SSM, chunking at 4kb per secret. Unlike Create, the data
+// isn't owned by a machine: the secrets are named after name, and are only deleted by
+// DeleteChunks. The prefix of the secret ARN and the number of chunks are returned.
+func (s *Service) CreateChunks(name string, data []byte, additionalTags infrav1.Tags) (string, int32, error) {
+	tags := infrav1.Build(infrav1.BuildParams{
+		ClusterName: s.scope.Name(),
+		Lifecycle:   infrav1.ResourceLifecycleOwned,
+		Name:        aws.String(name),
+		Additional:  additionalTags,
+	})
+	tags[infrav1.ClusterAWSCloudProviderTagKey(s.scope.Name())] = string(infrav1.ResourceLifecycleOwned)
+
+	prefix := normalizePrefix(path.Join(entryPrefix, name))
+	chunks, err := s.createChunks(prefix, data, tags)
+	return prefix, chunks, err
+}
+
+// normalizePrefix returns the prefix as a valid SSM parameter path.
+func normalizePrefix(prefix string) string {
 	// SSM Validation does not allow (/)aws|ssm in the beginning of the string
 	prefix = prefixRe.ReplaceAllString(prefix, "")
 	// Because the secret name has a slash in it, whole name must validate as a full path
 	if prefix[0] != byte('/') {
 		prefix = "/" + prefix
 	}
+	return prefix
+}
 
-	// Split the data into chunks and create the secrets on demand.
+// createChunks splits the data into chunks and creates the secrets on demand.
+func (s *Service) createChunks(prefix string, data []byte, tags infrav1.Tags) (int32, error) {
 	chunks := int32(0)
 	var err error
 	bytes.Split(data, true, maxSecretSizeBytes, func(chunk []byte) {
@@ -88,7 +117,7 @@ func (s *Service) Create(m *scope.MachineScope, data []byte) (string, int32, err
 		chunks++
 	})
 
-	return prefix, chunks, err
+	return chunks, err
 }
 
 // retryableCreateSecret is a function to be passed into a waiter. In a separate function for ease of reading.
@@ -118,12 +147,37 @@ func (s *Service) forceDeleteSecretEntry(name string) error {
 
 // Delete the secret belonging to a machine from AWS SSM.
 func (s *Service) Delete(m *scope.MachineScope) error {
+	return s.DeleteChunks(m.GetSecretPrefix(), m.GetSecretCount())
+}
+
+// DeleteChunks deletes the chunks of data stored under the prefix from AWS SSM.
+func (s *Service) DeleteChunks(prefix string, chunks int32) error {
 	var errs []error
-	for i := int32(0); i < m.GetSecretCount(); i++ {
-		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s/%d", m.GetSecretPrefix(), i)); err != nil {
+	for i := int32(0); i < chunks; i++ {
+		if err := s.forceDeleteSecretEntry(fmt.Sprintf("%s/%d", prefix, i)); err != nil {
 			errs = append(errs, err)
 		}
 	}
 
 	return kerrors.NewAggregate(errs)
 }
+
+// FetchCommand returns a shell command which writes the data stored under the prefix to
+// stdout. The command uses the AWS CLI and the credentials of the instance.
+func (s *Service) FetchCommand(prefix string, chunks int32, region string, endpoints []scope.ServiceEndpoint) string {
+	endpoint := ""
+	for _, v := range endpoints {
+		if v.ServiceID == serviceID {
+			endpoint = " --endpoint-url " + v.URL
+		}
+	}
+
+	commands := make([]string, 0, chunks)
+	for i := int32(0); i < chunks; i++ {
+		commands = append(commands, fmt.Sprintf("aws ssm%s --region %s get-parameter --output text --query Parameter.Value --with-decryption --name %s/%d",
+			endpoint, region, prefix, i))
+	}
+
+	// The chunks are base64 encoded before they are split.
+	return "{ " + strings.Join(commands, " && ") + "; } | tr -d '\\n' | base64 -d"
+}
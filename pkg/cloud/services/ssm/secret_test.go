@@ -283,3 +283,34 @@ func getMachineScope(client client.Client, clusterScope *scope.ClusterScope) (*s
 		},
 	})
 }
+
+func TestServiceCreateChunks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	clusterScope, err := getClusterScope(client)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ssmClientMock := mock_ssmiface.NewMockSSMAPI(mockCtrl)
+	ssmClientMock.EXPECT().PutParameter(gomock.AssignableToTypeOf(&ssm.PutParameterInput{})).
+		DoAndReturn(func(input *ssm.PutParameterInput) (*ssm.PutParameterOutput, error) {
+			g.Expect(aws.StringValue(input.Name)).To(HavePrefix("/cluster.x-k8s.io/eksconfig/config/"))
+			return &ssm.PutParameterOutput{}, nil
+		}).Times(2)
+
+	s := NewService(clusterScope)
+	s.SSMClient = ssmClientMock
+
+	prefix, chunks, err := s.CreateChunks("eksconfig/config", make([]byte, 6000), nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(prefix).To(Equal("/cluster.x-k8s.io/eksconfig/config"))
+	g.Expect(chunks).To(Equal(int32(2)))
+
+	g.Expect(s.FetchCommand(prefix, chunks, "us-east-1", []scope.ServiceEndpoint{{ServiceID: "ssm", URL: "https://ssm.example.com"}})).To(Equal(
+		"{ aws ssm --endpoint-url https://ssm.example.com --region us-east-1 get-parameter --output text --query Parameter.Value --with-decryption --name /cluster.x-k8s.io/eksconfig/config/0" +
+			" && aws ssm --endpoint-url https://ssm.example.com --region us-east-1 get-parameter --output text --query Parameter.Value --with-decryption --name /cluster.x-k8s.io/eksconfig/config/1; } | tr -d '\\n' | base64 -d"))
+}
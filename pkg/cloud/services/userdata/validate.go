@@ -0,0 +1,391 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	ignitionv3 "github.com/coreos/ignition/v2/config"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MaxUserDataSize is the maximum size of EC2 user data, before it's base64 encoded.
+	MaxUserDataSize = 16 * 1024
+
+	// FormatIgnition is the format of bootstrap data which is an Ignition config.
+	FormatIgnition = "ignition"
+
+	cloudConfigHeader = "#cloud-config"
+
+	// jinjaHeader is the first line of cloud-config documents which are rendered as Jinja
+	// templates by cloud-init, such as the ones generated by the kubeadm bootstrap provider.
+	jinjaHeader = "## template: jinja"
+)
+
+// cloudConfigModules validate the values of the cloud-config modules which are used by Cluster
+// API bootstrap providers.
+var cloudConfigModules = map[string]func(module string, value interface{}) error{
+	"write_files": validateWriteFiles,
+	"runcmd":      validateCommands,
+	"bootcmd":     validateCommands,
+	"users":       validateUsers,
+	"mounts":      validateMounts,
+	"fs_setup":    validateFSSetup,
+	"disk_setup":  validateDiskSetup,
+	"ntp":         validateNTP,
+}
+
+// writeFileEncodings are the encodings of write_files entries supported by cloud-init.
+var writeFileEncodings = map[string]bool{
+	"b64": true, "base64": true,
+	"gz": true, "gzip": true,
+	"gz+b64": true, "gz+base64": true, "gzip+b64": true, "gzip+base64": true,
+	"text/plain": true,
+}
+
+// Validate returns an error if the bootstrap data is malformed. Ignition v3 configs are
+// validated against the Ignition schema of their version. Cloud-config documents, also when they're part
+// of a multipart MIME document or a Jinja template, are validated against the cloud-config modules
+// used by Cluster API. Other bootstrap data, such as shell scripts, isn't validated.
+func Validate(data []byte, format string) error {
+	if format == FormatIgnition {
+		return validateIgnition(data)
+	}
+
+	switch {
+	case isCloudConfig(data):
+		return validateCloudConfig(data)
+	case bytes.HasPrefix(data, []byte("MIME-Version:")), bytes.HasPrefix(data, []byte("Content-Type: multipart/")):
+		return validateMultipart(data)
+	}
+
+	return nil
+}
+
+func validateIgnition(data []byte) error {
+	var header struct {
+		Ignition struct {
+			Version string `json:"version"`
+		} `json:"ignition"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return errors.Wrap(err, "failed to parse Ignition config")
+	}
+	version, err := semver.ParseTolerant(header.Ignition.Version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse Ignition version %q", header.Ignition.Version)
+	}
+
+	switch version.Major {
+	case 2:
+		// Ignition v2 configs are only used by older Flatcar releases, and aren't validated
+		// beyond their version.
+	case 3:
+		if _, report, err := ignitionv3.Parse(data); err != nil {
+			return errors.Wrapf(err, "invalid Ignition config: %s", report.String())
+		}
+	default:
+		return errors.Errorf("unsupported Ignition version %q", header.Ignition.Version)
+	}
+
+	return nil
+}
+
+// isCloudConfig returns whether the data is a cloud-config document, optionally preceded by
+// the Jinja template header.
+func isCloudConfig(data []byte) bool {
+	return bytes.HasPrefix(stripJinjaHeader(data), []byte(cloudConfigHeader))
+}
+
+// stripJinjaHeader returns the data without the Jinja template header line. The Jinja
+// expressions of templates generated by bootstrap providers are within YAML strings, so the
+// template is validated as is.
+func stripJinjaHeader(data []byte) []byte {
+	if !bytes.HasPrefix(data, []byte(jinjaHeader)) {
+		return data
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return data[i+1:]
+	}
+	return nil
+}
+
+func validateCloudConfig(data []byte) error {
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(stripJinjaHeader(data), &config); err != nil {
+		return errors.Wrap(err, "failed to parse cloud-config")
+	}
+
+	modules := make([]string, 0, len(cloudConfigModules))
+	for module := range cloudConfigModules {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+
+	for _, module := range modules {
+		value, ok := config[module]
+		if !ok || value == nil {
+			continue
+		}
+		if err := cloudConfigModules[module](module, value); err != nil {
+			return errors.Wrap(err, "invalid cloud-config")
+		}
+	}
+
+	return nil
+}
+
+func validateWriteFiles(module string, value interface{}) error {
+	files, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list", module)
+	}
+	for i, file := range files {
+		filePath := fmt.Sprintf("%s[%d]", module, i)
+		file, ok := file.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("%s must be a mapping", filePath)
+		}
+		if path, _ := file["path"].(string); path == "" {
+			return errors.Errorf("%s.path is required", filePath)
+		}
+		for _, key := range []string{"content", "owner"} {
+			if err := validateOptional(filePath, file, key, isString, "a string"); err != nil {
+				return err
+			}
+		}
+		for _, key := range []string{"append", "defer"} {
+			if err := validateOptional(filePath, file, key, isBool, "a boolean"); err != nil {
+				return err
+			}
+		}
+		if err := validateOptional(filePath, file, "permissions", isScalar, "an octal string"); err != nil {
+			return err
+		}
+		if encoding, ok := file["encoding"]; ok && encoding != nil {
+			if encoding, _ := encoding.(string); !writeFileEncodings[strings.ToLower(encoding)] {
+				return errors.Errorf("%s.encoding %v is not supported", filePath, file["encoding"])
+			}
+		}
+	}
+	return nil
+}
+
+// validateCommands validates the commands of runcmd and bootcmd, which are either a string run
+// by the shell or a list of arguments.
+func validateCommands(module string, value interface{}) error {
+	commands, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list", module)
+	}
+	for i, command := range commands {
+		switch command := command.(type) {
+		case string:
+		case []interface{}:
+			for j, arg := range command {
+				if !isScalar(arg) {
+					return errors.Errorf("%s[%d][%d] must be a string", module, i, j)
+				}
+			}
+		default:
+			return errors.Errorf("%s[%d] must be a string or a list of strings", module, i)
+		}
+	}
+	return nil
+}
+
+func validateUsers(module string, value interface{}) error {
+	users, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list", module)
+	}
+	for i, user := range users {
+		switch user := user.(type) {
+		case string:
+		case map[string]interface{}:
+			if name, _ := user["name"].(string); name == "" {
+				return errors.Errorf("%s[%d].name is required", module, i)
+			}
+			if err := validateOptional(fmt.Sprintf("%s[%d]", module, i), user, "ssh_authorized_keys", isStringList, "a list of strings"); err != nil {
+				return err
+			}
+		default:
+			return errors.Errorf("%s[%d] must be a string or a mapping", module, i)
+		}
+	}
+	return nil
+}
+
+func validateMounts(module string, value interface{}) error {
+	mounts, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list", module)
+	}
+	for i, mount := range mounts {
+		fields, ok := mount.([]interface{})
+		if !ok {
+			return errors.Errorf("%s[%d] must be a list", module, i)
+		}
+		for j, field := range fields {
+			if !isScalar(field) {
+				return errors.Errorf("%s[%d][%d] must be a string", module, i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func validateFSSetup(module string, value interface{}) error {
+	filesystems, ok := value.([]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a list", module)
+	}
+	for i, fs := range filesystems {
+		if _, ok := fs.(map[string]interface{}); !ok {
+			return errors.Errorf("%s[%d] must be a mapping", module, i)
+		}
+	}
+	return nil
+}
+
+func validateDiskSetup(module string, value interface{}) error {
+	disks, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a mapping", module)
+	}
+	for device, disk := range disks {
+		if _, ok := disk.(map[string]interface{}); !ok {
+			return errors.Errorf("%s.%s must be a mapping", module, device)
+		}
+	}
+	return nil
+}
+
+func validateNTP(module string, value interface{}) error {
+	ntp, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("%s must be a mapping", module)
+	}
+	if err := validateOptional(module, ntp, "enabled", isBool, "a boolean"); err != nil {
+		return err
+	}
+	for _, key := range []string{"servers", "pools"} {
+		if err := validateOptional(module, ntp, key, isStringList, "a list of strings"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOptional returns an error if the key is set to a value which isn't valid.
+func validateOptional(path string, values map[string]interface{}, key string, valid func(interface{}) bool, kind string) error {
+	if value, ok := values[key]; ok && value != nil && !valid(value) {
+		return errors.Errorf("%s.%s must be %s", path, key, kind)
+	}
+	return nil
+}
+
+func isString(value interface{}) bool {
+	_, ok := value.(string)
+	return ok
+}
+
+func isBool(value interface{}) bool {
+	_, ok := value.(bool)
+	return ok
+}
+
+// isScalar returns whether the value is a string, or a number which cloud-init converts to a
+// string, such as the unquoted permissions of a file.
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, float64:
+		return true
+	}
+	return false
+}
+
+func isStringList(value interface{}) bool {
+	values, ok := value.([]interface{})
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if !isString(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMultipart validates the cloud-config parts of a multipart MIME document.
+func validateMultipart(data []byte) error {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse MIME document")
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse MIME document content type")
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return nil
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse MIME document")
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if partType != "text/cloud-config" && partType != "text/jinja2" {
+			continue
+		}
+
+		var content io.Reader = part
+		if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+			content = base64.NewDecoder(base64.StdEncoding, part)
+		}
+		partData, err := io.ReadAll(content)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read MIME part %d", i)
+		}
+		if partType == "text/jinja2" && !isCloudConfig(partData) {
+			continue
+		}
+		if err := validateCloudConfig(partData); err != nil {
+			return errors.Wrapf(err, "MIME part %d", i)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package userdata
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+// kubeadmBootstrapData is shaped like the bootstrap data of a node generated by the kubeadm
+// bootstrap provider.
+const kubeadmBootstrapData = `## template: jinja
+#cloud-config
+
+write_files:
+-   path: /etc/kubernetes/pki/ca.crt
+    owner: root:root
+    permissions: '0640'
+    content: |
+      -----BEGIN CERTIFICATE-----
+      MIIC6jCCAdKgAwIBAgIBADANBgkqhkiG9w0BAQsFADAVMRMwEQYDVQQDEwprdWJl
+      -----END CERTIFICATE-----
+-   path: /run/kubeadm/kubeadm-join-config.yaml
+    owner: root:root
+    permissions: '0640'
+    content: |
+      ---
+      apiVersion: kubeadm.k8s.io/v1beta3
+      discovery:
+        bootstrapToken:
+          apiServerEndpoint: example.elb.amazonaws.com:6443
+          token: abcdef.0123456789abcdef
+          unsafeSkipCAVerification: true
+      kind: JoinConfiguration
+      nodeRegistration:
+        kubeletExtraArgs:
+          cloud-provider: external
+        name: '{{ ds.meta_data.local_hostname }}'
+-   path: /run/cluster-api/placeholder
+    owner: root:root
+    permissions: '0640'
+    content: "This placeholder file is used to create the /run/cluster-api sub directory in a way that is compatible with both Linux and Windows (mkdir -p /run/cluster-api does not work with Windows)"
+runcmd:
+  - "kubeadm join --config /run/kubeadm/kubeadm-join-config.yaml  && echo success > /run/cluster-api/bootstrap-success.complete"
+`
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		format    string
+		expectErr bool
+	}{
+		{
+			name: "shell script",
+			data: "#!/bin/bash\necho hello\n",
+		},
+		{
+			name: "cloud-config",
+			data: "#cloud-config\nwrite_files:\n- path: /etc/example.conf\n  content: example\nruncmd:\n- echo hello\n",
+		},
+		{
+			name:      "cloud-config with invalid yaml",
+			data:      "#cloud-config\nruncmd: [\n",
+			expectErr: true,
+		},
+		{
+			name:      "cloud-config with runcmd mapping",
+			data:      "#cloud-config\nruncmd:\n  cmd: echo hello\n",
+			expectErr: true,
+		},
+		{
+			name: "kubeadm bootstrap data",
+			data: kubeadmBootstrapData,
+		},
+		{
+			name:      "kubeadm bootstrap data with invalid command",
+			data:      kubeadmBootstrapData + "  - cmd: kubeadm reset\n",
+			expectErr: true,
+		},
+		{
+			name:      "kubeadm bootstrap data with invalid file",
+			data:      strings.Replace(kubeadmBootstrapData, "owner: root:root", "owner: [root]", 1),
+			expectErr: true,
+		},
+		{
+			name: "cloud-config with commands as arguments",
+			data: "#cloud-config\nruncmd:\n- [sysctl, -w, vm.max_map_count=262144]\n",
+		},
+		{
+			name:      "cloud-config with file with unsupported encoding",
+			data:      "#cloud-config\nwrite_files:\n- path: /etc/example.conf\n  encoding: rot13\n  content: example\n",
+			expectErr: true,
+		},
+		{
+			name:      "cloud-config with mount which isn't a list",
+			data:      "#cloud-config\nmounts:\n- /dev/xvdb /mnt\n",
+			expectErr: true,
+		},
+		{
+			name:      "cloud-config with ntp servers which aren't a list",
+			data:      "#cloud-config\nntp:\n  servers: pool.ntp.org\n",
+			expectErr: true,
+		},
+		{
+			name:      "cloud-config with file without path",
+			data:      "#cloud-config\nwrite_files:\n- content: example\n",
+			expectErr: true,
+		},
+		{
+			name: "multipart with cloud-config",
+			data: "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"BOUNDARY\"\n\n" +
+				"--BOUNDARY\nContent-Type: text/x-shellscript\n\n#!/bin/bash\necho hello\n" +
+				"--BOUNDARY\nContent-Type: text/cloud-config\n\n#cloud-config\nruncmd:\n- echo hello\n" +
+				"--BOUNDARY--\n",
+		},
+		{
+			name: "multipart with invalid cloud-config",
+			data: "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"BOUNDARY\"\n\n" +
+				"--BOUNDARY\nContent-Type: text/cloud-config\n\n#cloud-config\nntp:\n- pool.ntp.org\n" +
+				"--BOUNDARY--\n",
+			expectErr: true,
+		},
+		{
+			name: "multipart with invalid jinja cloud-config",
+			data: "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"BOUNDARY\"\n\n" +
+				"--BOUNDARY\nContent-Type: text/jinja2\n\n## template: jinja\n#cloud-config\nruncmd:\n- cmd: echo hello\n" +
+				"--BOUNDARY--\n",
+			expectErr: true,
+		},
+		{
+			name:   "ignition v2",
+			data:   `{"ignition":{"version":"2.3.0"}}`,
+			format: FormatIgnition,
+		},
+		{
+			name:   "ignition v3",
+			data:   `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"/etc/example.conf"}]}}`,
+			format: FormatIgnition,
+		},
+		{
+			name:      "ignition v3 with relative path",
+			data:      `{"ignition":{"version":"3.4.0"},"storage":{"files":[{"path":"etc/example.conf"}]}}`,
+			format:    FormatIgnition,
+			expectErr: true,
+		},
+		{
+			name:      "ignition with unsupported version",
+			data:      `{"ignition":{"version":"1.0.0"}}`,
+			format:    FormatIgnition,
+			expectErr: true,
+		},
+		{
+			name:      "ignition with invalid json",
+			data:      `{"ignition":`,
+			format:    FormatIgnition,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := Validate([]byte(tt.data), tt.format)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
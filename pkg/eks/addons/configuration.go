@@ -0,0 +1,128 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+// ErrInvalidAddonConfiguration defines an error for when the configuration of an addon
+// doesn't conform to the configuration schema of the addon.
+var ErrInvalidAddonConfiguration = errors.New("invalid addon configuration")
+
+// InvalidConfigurationError is returned when the configuration of an addon is not
+// valid for the addon version.
+type InvalidConfigurationError struct {
+	// AddonName is the name of the addon with the invalid configuration.
+	AddonName string
+	// Reason is a description of why the configuration is invalid.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvalidConfigurationError) Error() string {
+	return fmt.Sprintf("%s %s: %s", ErrInvalidAddonConfiguration.Error(), e.AddonName, e.Reason)
+}
+
+// Is allows the error to be matched against ErrInvalidAddonConfiguration.
+func (e *InvalidConfigurationError) Is(target error) bool {
+	return target == ErrInvalidAddonConfiguration
+}
+
+// NormalizeConfiguration converts an addon configuration, which can be either JSON
+// or YAML, into compact JSON with sorted keys. This allows configurations that only
+// differ in formatting to be compared.
+func NormalizeConfiguration(config string) (string, error) {
+	if strings.TrimSpace(config) == "" {
+		return "", nil
+	}
+
+	raw, err := yaml.YAMLToJSON([]byte(config))
+	if err != nil {
+		return "", fmt.Errorf("parsing addon configuration: %w", err)
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return "", fmt.Errorf("parsing addon configuration: %w", err)
+	}
+	if obj == nil {
+		return "", nil
+	}
+
+	normalized, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("normalizing addon configuration: %w", err)
+	}
+
+	return string(normalized), nil
+}
+
+// ValidateConfiguration validates an addon configuration against the JSON schema
+// returned by EKS for the addon version.
+func ValidateConfiguration(config, schema string) error {
+	normalized, err := NormalizeConfiguration(config)
+	if err != nil {
+		return err
+	}
+	if normalized == "" || strings.TrimSpace(schema) == "" {
+		return nil
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("schema.json", strings.NewReader(schema)); err != nil {
+		return fmt.Errorf("loading addon configuration schema: %w", err)
+	}
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		return fmt.Errorf("compiling addon configuration schema: %w", err)
+	}
+
+	var doc interface{}
+	decoder := json.NewDecoder(strings.NewReader(normalized))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("parsing addon configuration: %w", err)
+	}
+
+	return compiled.Validate(doc)
+}
+
+// configurationEqual determines if 2 addon configurations are semantically the same.
+func configurationEqual(a, b *string) bool {
+	var aConfig, bConfig string
+	if a != nil {
+		aConfig = *a
+	}
+	if b != nil {
+		bConfig = *b
+	}
+
+	aNormalized, aErr := NormalizeConfiguration(aConfig)
+	bNormalized, bErr := NormalizeConfiguration(bConfig)
+	if aErr != nil || bErr != nil {
+		return aConfig == bConfig
+	}
+
+	return aNormalized == bNormalized
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNormalizeConfiguration(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "empty configuration",
+			config:   "  ",
+			expected: "",
+		},
+		{
+			name:     "json configuration",
+			config:   `{ "resources": {"limits": {"memory": "170Mi"}}, "replicaCount": 2 }`,
+			expected: `{"replicaCount":2,"resources":{"limits":{"memory":"170Mi"}}}`,
+		},
+		{
+			name:     "yaml configuration",
+			config:   "replicaCount: 2\nresources:\n  limits:\n    memory: 170Mi\n",
+			expected: `{"replicaCount":2,"resources":{"limits":{"memory":"170Mi"}}}`,
+		},
+		{
+			name:        "malformed configuration",
+			config:      `{"replicaCount": 2`,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			normalized, err := NormalizeConfiguration(tc.config)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(normalized).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		schema      string
+		expectError bool
+	}{
+		{
+			name:   "valid configuration",
+			config: "replicaCount: 2",
+			schema: addonConfigSchema,
+		},
+		{
+			name:        "unknown property",
+			config:      `{"replicas": 2}`,
+			schema:      addonConfigSchema,
+			expectError: true,
+		},
+		{
+			name:        "wrong type in referenced definition",
+			config:      `{"resources": {"limits": "170Mi"}}`,
+			schema:      addonConfigSchema,
+			expectError: true,
+		},
+		{
+			name:   "no schema",
+			config: `{"replicas": 2}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateConfiguration(tc.config, tc.schema)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
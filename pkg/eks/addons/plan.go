@@ -20,6 +20,7 @@ package addons
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/eks"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 
@@ -27,34 +28,53 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/planner"
 )
 
-// NewPlan creates a new Plan to manage EKS addons.
-func NewPlan(clusterName string, desiredAddons, installedAddons []*EKSAddon, client eksiface.EKSAPI) planner.Plan {
+// NewPlan creates a new Plan to manage EKS addons. The Kubernetes version is the
+// current version of the cluster and is used to resolve addon version selectors.
+func NewPlan(clusterName, kubernetesVersion string, desiredAddons, installedAddons []*EKSAddon, client eksiface.EKSAPI) planner.Plan {
 	return &plan{
-		installedAddons: installedAddons,
-		desiredAddons:   desiredAddons,
-		eksClient:       client,
-		clusterName:     clusterName,
+		installedAddons:   installedAddons,
+		desiredAddons:     desiredAddons,
+		eksClient:         client,
+		clusterName:       clusterName,
+		kubernetesVersion: kubernetesVersion,
 	}
 }
 
 // Plan is a plan that will manage EKS addons.
 type plan struct {
-	installedAddons []*EKSAddon
-	desiredAddons   []*EKSAddon
-	eksClient       eksiface.EKSAPI
-	clusterName     string
+	installedAddons   []*EKSAddon
+	desiredAddons     []*EKSAddon
+	eksClient         eksiface.EKSAPI
+	clusterName       string
+	kubernetesVersion string
 }
 
 // Create will create the plan (i.e. list of procedures) for managing EKS addons.
 func (a *plan) Create(_ context.Context) ([]planner.Procedure, error) {
 	procedures := []planner.Procedure{}
 
+	// Resolve the versions of addons that use a version selector
+	for i := range a.desiredAddons {
+		desired := a.desiredAddons[i]
+		if aws.StringValue(desired.Version) != "" || aws.StringValue(desired.VersionSelector) == "" {
+			continue
+		}
+		resolved, err := a.resolveVersion(desired)
+		if err != nil {
+			return nil, err
+		}
+		desired.Version = aws.String(resolved)
+	}
+
 	// Handle create and update
 	for i := range a.desiredAddons {
 		desired := a.desiredAddons[i]
 		installed := a.getInstalled(*desired.Name)
 		if installed == nil {
 			// Need to add the addon
+			if hasConfiguration(desired) {
+				procedures = append(procedures, &ValidateAddonConfigurationProcedure{plan: a, name: *desired.Name})
+			}
 			procedures = append(procedures,
 				&CreateAddonProcedure{plan: a, name: *desired.Name},
 				&WaitAddonActiveProcedure{plan: a, name: *desired.Name, includeDegraded: true},
@@ -67,6 +87,9 @@ func (a *plan) Create(_ context.Context) ([]planner.Procedure, error) {
 			}
 			// Check if we also need to update the addon
 			if !desired.IsEqual(installed, false) {
+				if hasConfiguration(desired) {
+					procedures = append(procedures, &ValidateAddonConfigurationProcedure{plan: a, name: *desired.Name})
+				}
 				procedures = append(procedures,
 					&UpdateAddonProcedure{plan: a, name: *installed.Name},
 					&WaitAddonActiveProcedure{plan: a, name: *desired.Name, includeDegraded: true},
@@ -115,6 +138,10 @@ func (a *plan) getDesired(name string) *EKSAddon {
 	return nil
 }
 
+func hasConfiguration(addon *EKSAddon) bool {
+	return addon.Configuration != nil && *addon.Configuration != ""
+}
+
 func convertTags(tags infrav1.Tags) map[string]*string {
 	converted := map[string]*string{}
 
@@ -30,8 +30,27 @@ import (
 	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/services/eks/mock_eksiface"
 )
 
+const addonConfigSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"additionalProperties": false,
+	"properties": {
+		"replicaCount": {"type": "integer"},
+		"resources": {"$ref": "#/definitions/Resources"}
+	},
+	"definitions": {
+		"Resources": {
+			"type": "object",
+			"properties": {
+				"limits": {"type": "object"}
+			}
+		}
+	},
+	"type": "object"
+}`
+
 func TestEKSAddonPlan(t *testing.T) {
 	clusterName := "default.cluster"
+	kubernetesVersion := "1.28"
 	addonARN := "aws://someaddonarn"
 	addon1Name := "addon1"
 	addon1version := "1.0.0"
@@ -165,6 +184,116 @@ func TestEKSAddonPlan(t *testing.T) {
 			expectCreateError: false,
 			expectDoError:     false,
 		},
+		{
+			name: "no installed and 1 desired with latest version selector",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonVersions(gomock.Eq(&eks.DescribeAddonVersionsInput{
+						AddonName:         aws.String(addon1Name),
+						KubernetesVersion: aws.String(kubernetesVersion),
+					})).
+					Return(createAddonVersionsOutput(addon1Name, kubernetesVersion), nil)
+				m.
+					CreateAddon(gomock.Eq(&eks.CreateAddonInput{
+						AddonName:        aws.String(addon1Name),
+						AddonVersion:     aws.String("v1.16.0-eksbuild.1"),
+						ClusterName:      aws.String(clusterName),
+						ResolveConflicts: aws.String(eks.ResolveConflictsOverwrite),
+						Tags:             convertTags(createTags()),
+					})).
+					Return(&eks.CreateAddonOutput{
+						Addon: &eks.Addon{
+							AddonArn:     aws.String(addonARN),
+							AddonName:    aws.String(addon1Name),
+							AddonVersion: aws.String("v1.16.0-eksbuild.1"),
+							ClusterName:  aws.String(clusterName),
+							Status:       aws.String(addonStatusCreating),
+						},
+					}, nil)
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(&eks.DescribeAddonOutput{Addon: &eks.Addon{Status: aws.String(eks.AddonStatusActive)}}, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithSelector(addon1Name, VersionSelectorLatest),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired with range version selector - upgrade",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonVersions(gomock.Eq(&eks.DescribeAddonVersionsInput{
+						AddonName:         aws.String(addon1Name),
+						KubernetesVersion: aws.String(kubernetesVersion),
+					})).
+					Return(createAddonVersionsOutput(addon1Name, kubernetesVersion), nil)
+				m.
+					UpdateAddon(gomock.Eq(&eks.UpdateAddonInput{
+						AddonName:        aws.String(addon1Name),
+						AddonVersion:     aws.String("v1.15.1-eksbuild.2"),
+						ClusterName:      aws.String(clusterName),
+						ResolveConflicts: aws.String(eks.ResolveConflictsOverwrite),
+					})).
+					Return(&eks.UpdateAddonOutput{
+						Update: &eks.Update{
+							CreatedAt: &created,
+							Id:        aws.String("someid"),
+							Status:    aws.String(addonStatusUpdating),
+							Type:      aws.String(eks.UpdateTypeVersionUpdate),
+						},
+					}, nil)
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(&eks.DescribeAddonOutput{Addon: &eks.Addon{Status: aws.String(eks.AddonStatusActive)}}, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithSelector(addon1Name, ">=1.15.0 <1.16.0"),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, "v1.15.0-eksbuild.1", addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired with default version selector - already default",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonVersions(gomock.Eq(&eks.DescribeAddonVersionsInput{
+						AddonName:         aws.String(addon1Name),
+						KubernetesVersion: aws.String(kubernetesVersion),
+					})).
+					Return(createAddonVersionsOutput(addon1Name, kubernetesVersion), nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithSelector(addon1Name, VersionSelectorDefault),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddon(addon1Name, "v1.15.0-eksbuild.1", addonARN, addonStatusActive),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "no installed and 1 desired with version selector matching no version",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonVersions(gomock.Eq(&eks.DescribeAddonVersionsInput{
+						AddonName:         aws.String(addon1Name),
+						KubernetesVersion: aws.String(kubernetesVersion),
+					})).
+					Return(createAddonVersionsOutput(addon1Name, kubernetesVersion), nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithSelector(addon1Name, ">=2.0.0"),
+			},
+			expectCreateError: true,
+			expectDoError:     false,
+		},
 		{
 			name: "1 installed and 1 desired - both same and installed active",
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
@@ -324,6 +453,92 @@ func TestEKSAddonPlan(t *testing.T) {
 			expectCreateError: false,
 			expectDoError:     false,
 		},
+		{
+			name: "1 installed and 1 desired - configuration only differs in formatting",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				// No Action expected
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithConfig(addon1Name, addon1version, "replicaCount: 2\nresources:\n  limits:\n    memory: 170Mi\n"),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddonWithConfig(addon1Name, addon1version, addonARN, addonStatusActive, `{"resources":{"limits":{"memory":"170Mi"}},"replicaCount":2}`),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - configuration change",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonConfiguration(gomock.Eq(&eks.DescribeAddonConfigurationInput{
+						AddonName:    aws.String(addon1Name),
+						AddonVersion: aws.String(addon1version),
+					})).
+					Return(&eks.DescribeAddonConfigurationOutput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ConfigurationSchema: aws.String(addonConfigSchema),
+					}, nil)
+				m.
+					UpdateAddon(gomock.Eq(&eks.UpdateAddonInput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ClusterName:         aws.String(clusterName),
+						ConfigurationValues: aws.String(`{"replicaCount":3}`),
+						ResolveConflicts:    aws.String(eks.ResolveConflictsOverwrite),
+					})).
+					Return(&eks.UpdateAddonOutput{
+						Update: &eks.Update{
+							CreatedAt: &created,
+							Id:        aws.String("someid"),
+							Status:    aws.String(addonStatusUpdating),
+							Type:      aws.String(eks.UpdateTypeAddonUpdate),
+						},
+					}, nil)
+
+				out := &eks.DescribeAddonOutput{
+					Addon: &eks.Addon{
+						Status: aws.String(eks.AddonStatusActive),
+					},
+				}
+				m.DescribeAddon(gomock.Eq(&eks.DescribeAddonInput{
+					AddonName:   aws.String(addon1Name),
+					ClusterName: aws.String(clusterName),
+				})).Return(out, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithConfig(addon1Name, addon1version, `{"replicaCount":3}`),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddonWithConfig(addon1Name, addon1version, addonARN, addonStatusActive, `{"replicaCount":2}`),
+			},
+			expectCreateError: false,
+			expectDoError:     false,
+		},
+		{
+			name: "1 installed and 1 desired - invalid configuration",
+			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
+				m.
+					DescribeAddonConfiguration(gomock.Eq(&eks.DescribeAddonConfigurationInput{
+						AddonName:    aws.String(addon1Name),
+						AddonVersion: aws.String(addon1version),
+					})).
+					Return(&eks.DescribeAddonConfigurationOutput{
+						AddonName:           aws.String(addon1Name),
+						AddonVersion:        aws.String(addon1version),
+						ConfigurationSchema: aws.String(addonConfigSchema),
+					}, nil)
+			},
+			desiredAddons: []*EKSAddon{
+				createDesiredAddonWithConfig(addon1Name, addon1version, `{"replicas":3}`),
+			},
+			installedAddons: []*EKSAddon{
+				createInstalledAddonWithConfig(addon1Name, addon1version, addonARN, addonStatusActive, `{"replicaCount":2}`),
+			},
+			expectCreateError: false,
+			expectDoError:     true,
+		},
 		{
 			name: "1 installed and 0 desired - delete addon",
 			expect: func(m *mock_eksiface.MockEKSAPIMockRecorder) {
@@ -383,7 +598,7 @@ func TestEKSAddonPlan(t *testing.T) {
 
 			ctx := context.TODO()
 
-			planner := NewPlan(clusterName, tc.desiredAddons, tc.installedAddons, eksMock)
+			planner := NewPlan(clusterName, kubernetesVersion, tc.desiredAddons, tc.installedAddons, eksMock)
 			procedures, err := planner.Create(ctx)
 			if tc.expectCreateError {
 				g.Expect(err).To(HaveOccurred())
@@ -447,3 +662,50 @@ func createInstalledAddon(name, version, arn, status string) *EKSAddon {
 
 	return desired
 }
+
+func createDesiredAddonWithConfig(name, version, config string) *EKSAddon {
+	desired := createDesiredAddon(name, version)
+	desired.Configuration = &config
+
+	return desired
+}
+
+func createInstalledAddonWithConfig(name, version, arn, status, config string) *EKSAddon {
+	installed := createInstalledAddon(name, version, arn, status)
+	installed.Configuration = &config
+
+	return installed
+}
+
+func createDesiredAddonWithSelector(name, selector string) *EKSAddon {
+	desired := createDesiredAddon(name, "")
+	desired.Version = nil
+	desired.VersionSelector = &selector
+
+	return desired
+}
+
+func createAddonVersionsOutput(name, kubernetesVersion string) *eks.DescribeAddonVersionsOutput {
+	compatibility := func(isDefault bool) []*eks.Compatibility {
+		return []*eks.Compatibility{
+			{
+				ClusterVersion: aws.String(kubernetesVersion),
+				DefaultVersion: aws.Bool(isDefault),
+			},
+		}
+	}
+
+	return &eks.DescribeAddonVersionsOutput{
+		Addons: []*eks.AddonInfo{
+			{
+				AddonName: aws.String(name),
+				AddonVersions: []*eks.AddonVersionInfo{
+					{AddonVersion: aws.String("v1.16.0-eksbuild.1"), Compatibilities: compatibility(false)},
+					{AddonVersion: aws.String("v1.15.1-eksbuild.2"), Compatibilities: compatibility(false)},
+					{AddonVersion: aws.String("v1.15.1-eksbuild.1"), Compatibilities: compatibility(false)},
+					{AddonVersion: aws.String("v1.15.0-eksbuild.1"), Compatibilities: compatibility(true)},
+				},
+			},
+		},
+	}
+}
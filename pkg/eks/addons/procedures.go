@@ -61,6 +61,11 @@ func (p *DeleteAddonProcedure) Name() string {
 	return "addon_delete"
 }
 
+// AddonName is the name of the addon the procedure applies to.
+func (p *DeleteAddonProcedure) AddonName() string {
+	return p.name
+}
+
 // UpdateAddonProcedure is a procedure that will update an EKS addon.
 type UpdateAddonProcedure struct {
 	plan *plan
@@ -96,6 +101,52 @@ func (p *UpdateAddonProcedure) Name() string {
 	return "addon_update"
 }
 
+// AddonName is the name of the addon the procedure applies to.
+func (p *UpdateAddonProcedure) AddonName() string {
+	return p.name
+}
+
+// ValidateAddonConfigurationProcedure is a procedure that will validate the desired
+// configuration of an EKS addon against the configuration schema of the addon version.
+type ValidateAddonConfigurationProcedure struct {
+	plan *plan
+	name string
+}
+
+// Do implements the logic for the procedure.
+func (p *ValidateAddonConfigurationProcedure) Do(_ context.Context) error {
+	desired := p.plan.getDesired(p.name)
+	if desired == nil {
+		return fmt.Errorf("getting desired addon %s: %w", p.name, ErrAddonNotFound)
+	}
+
+	input := &eks.DescribeAddonConfigurationInput{
+		AddonName:    desired.Name,
+		AddonVersion: desired.Version,
+	}
+
+	output, err := p.plan.eksClient.DescribeAddonConfiguration(input)
+	if err != nil {
+		return fmt.Errorf("describing eks addon %s configuration: %w", p.name, err)
+	}
+
+	if err := ValidateConfiguration(aws.StringValue(desired.Configuration), aws.StringValue(output.ConfigurationSchema)); err != nil {
+		return &InvalidConfigurationError{AddonName: p.name, Reason: err.Error()}
+	}
+
+	return nil
+}
+
+// Name is the name of the procedure.
+func (p *ValidateAddonConfigurationProcedure) Name() string {
+	return "addon_validate_configuration"
+}
+
+// AddonName is the name of the addon the procedure applies to.
+func (p *ValidateAddonConfigurationProcedure) AddonName() string {
+	return p.name
+}
+
 // UpdateAddonTagsProcedure is a procedure that will update an EKS addon tags.
 type UpdateAddonTagsProcedure struct {
 	plan *plan
@@ -131,6 +182,11 @@ func (p *UpdateAddonTagsProcedure) Name() string {
 	return "addon_tags_update"
 }
 
+// AddonName is the name of the addon the procedure applies to.
+func (p *UpdateAddonTagsProcedure) AddonName() string {
+	return p.name
+}
+
 // CreateAddonProcedure is a procedure that will create an EKS addon for a cluster.
 type CreateAddonProcedure struct {
 	plan *plan
@@ -171,6 +227,11 @@ func (p *CreateAddonProcedure) Name() string {
 	return "addon_create"
 }
 
+// AddonName is the name of the addon the procedure applies to.
+func (p *CreateAddonProcedure) AddonName() string {
+	return p.name
+}
+
 // WaitAddonActiveProcedure is a procedure that will wait for an EKS addon
 // to be active in a cluster. Abd optionally include the degraded state.
 // Note: addons may be degraded until there are worker nodes.
@@ -214,6 +275,11 @@ func (p *WaitAddonActiveProcedure) Name() string {
 	return "addon_wait_active"
 }
 
+// AddonName is the name of the addon the procedure applies to.
+func (p *WaitAddonActiveProcedure) AddonName() string {
+	return p.name
+}
+
 // WaitAddonDeleteProcedure is a procedure that will wait for an EKS addon
 // to be deleted from a cluster.
 type WaitAddonDeleteProcedure struct {
@@ -239,3 +305,8 @@ func (p *WaitAddonDeleteProcedure) Do(_ context.Context) error {
 func (p *WaitAddonDeleteProcedure) Name() string {
 	return "addon_wait_delete"
 }
+
+// AddonName is the name of the addon the procedure applies to.
+func (p *WaitAddonDeleteProcedure) AddonName() string {
+	return p.name
+}
@@ -26,6 +26,7 @@ import (
 type EKSAddon struct {
 	Name                  *string
 	Version               *string
+	VersionSelector       *string
 	ServiceAccountRoleARN *string
 	Configuration         *string
 	Tags                  infrav1.Tags
@@ -46,6 +47,9 @@ func (e *EKSAddon) IsEqual(other *EKSAddon, includeTags bool) bool {
 	if !cmp.Equal(e.ServiceAccountRoleARN, other.ServiceAccountRoleARN) {
 		return false
 	}
+	if !configurationEqual(e.Configuration, other.Configuration) {
+		return false
+	}
 
 	if includeTags {
 		diffTags := e.Tags.Difference(other.Tags)
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/blang/semver"
+)
+
+const (
+	// VersionSelectorLatest selects the latest version of an addon that is
+	// compatible with the Kubernetes version of the cluster.
+	VersionSelectorLatest = "latest"
+	// VersionSelectorDefault selects the version of an addon that EKS marks as the
+	// default for the Kubernetes version of the cluster.
+	VersionSelectorDefault = "default"
+)
+
+// ErrAddonVersionNotFound defines an error for when no addon version matches a version selector.
+var ErrAddonVersionNotFound = errors.New("no addon version matches the version selector")
+
+// IsVersionSelector returns true if the version of an addon is a version selector
+// rather than a version.
+func IsVersionSelector(version string) bool {
+	if version == VersionSelectorLatest || version == VersionSelectorDefault {
+		return true
+	}
+	if _, err := semver.ParseTolerant(version); err == nil {
+		return false
+	}
+	_, err := semver.ParseRange(version)
+
+	return err == nil
+}
+
+// ValidateVersion checks that the version of an addon is either a version, latest,
+// default or a semver range such as ">=1.15.0 <1.16.0".
+func ValidateVersion(version string) error {
+	if _, err := semver.ParseTolerant(version); err == nil {
+		return nil
+	}
+	if IsVersionSelector(version) {
+		return nil
+	}
+
+	return fmt.Errorf("version must be a version, %q, %q or a semver range", VersionSelectorLatest, VersionSelectorDefault)
+}
+
+// resolveVersion resolves the version selector of an addon to a version that is
+// compatible with the Kubernetes version of the cluster.
+func (a *plan) resolveVersion(addon *EKSAddon) (string, error) {
+	input := &eks.DescribeAddonVersionsInput{
+		AddonName:         addon.Name,
+		KubernetesVersion: aws.String(a.kubernetesVersion),
+	}
+
+	versions := []*eks.AddonVersionInfo{}
+	for {
+		output, err := a.eksClient.DescribeAddonVersions(input)
+		if err != nil {
+			return "", fmt.Errorf("describing eks addon %s versions: %w", *addon.Name, err)
+		}
+		for _, info := range output.Addons {
+			if aws.StringValue(info.AddonName) == *addon.Name {
+				versions = append(versions, info.AddonVersions...)
+			}
+		}
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	resolved, err := selectVersion(aws.StringValue(addon.VersionSelector), a.kubernetesVersion, versions)
+	if err != nil {
+		return "", fmt.Errorf("resolving eks addon %s version for kubernetes %s: %w", *addon.Name, a.kubernetesVersion, err)
+	}
+
+	return resolved, nil
+}
+
+// selectVersion picks the addon version matching the selector from the versions
+// that are compatible with the Kubernetes version.
+func selectVersion(selector, kubernetesVersion string, versions []*eks.AddonVersionInfo) (string, error) {
+	var versionRange semver.Range
+	if selector != VersionSelectorLatest && selector != VersionSelectorDefault {
+		r, err := semver.ParseRange(selector)
+		if err != nil {
+			return "", fmt.Errorf("parsing version selector %q: %w", selector, err)
+		}
+		versionRange = r
+	}
+
+	var (
+		selected       string
+		selectedParsed semver.Version
+	)
+	for _, info := range versions {
+		compatibility := findCompatibility(info, kubernetesVersion)
+		if compatibility == nil {
+			continue
+		}
+
+		raw := aws.StringValue(info.AddonVersion)
+		if selector == VersionSelectorDefault {
+			if aws.BoolValue(compatibility.DefaultVersion) {
+				return raw, nil
+			}
+			continue
+		}
+
+		parsed, err := semver.ParseTolerant(raw)
+		if err != nil {
+			continue
+		}
+		// Addon versions have an eksbuild pre-release suffix which is ignored when matching the range.
+		if versionRange != nil && !versionRange(semver.Version{Major: parsed.Major, Minor: parsed.Minor, Patch: parsed.Patch}) {
+			continue
+		}
+		if selected == "" || parsed.GT(selectedParsed) {
+			selected = raw
+			selectedParsed = parsed
+		}
+	}
+
+	if selected == "" {
+		return "", fmt.Errorf("%w %q", ErrAddonVersionNotFound, selector)
+	}
+
+	return selected, nil
+}
+
+func findCompatibility(info *eks.AddonVersionInfo, kubernetesVersion string) *eks.Compatibility {
+	for _, compatibility := range info.Compatibilities {
+		if aws.StringValue(compatibility.ClusterVersion) == kubernetesVersion {
+			return compatibility
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nodegroup contains types for EKS nodegroup settings that aren't available in aws-sdk-go.
+package nodegroup
+
+import (
+	"github.com/aws/aws-sdk-go/service/eks"
+)
+
+// The node repair config and update strategy of nodegroups were added to the EKS API after
+// aws-sdk-go entered maintenance mode. The types below are adapted from the EKS API so that
+// they can be (un)marshalled by the aws-sdk-go REST JSON protocol.
+
+// NodeRepairConfig is the node auto repair configuration of a nodegroup.
+type NodeRepairConfig struct {
+	_ struct{} `type:"structure"`
+
+	// Specifies whether to enable node auto repair for the node group.
+	Enabled *bool `locationName:"enabled" type:"boolean"`
+}
+
+// UpdateConfig is eks.NodegroupUpdateConfig with the update strategy.
+type UpdateConfig struct {
+	_ struct{} `type:"structure"`
+
+	// The maximum number of nodes unavailable at once during a version update.
+	MaxUnavailable *int64 `locationName:"maxUnavailable" min:"1" type:"integer"`
+
+	// The maximum percentage of nodes unavailable during a version update.
+	MaxUnavailablePercentage *int64 `locationName:"maxUnavailablePercentage" min:"1" type:"integer"`
+
+	// The configuration for the behavior to follow during a node group version update.
+	UpdateStrategy *string `locationName:"updateStrategy" type:"string"`
+}
+
+// Config holds the configuration of a nodegroup that isn't part of eks.Nodegroup.
+type Config struct {
+	_ struct{} `type:"structure"`
+
+	// The node auto repair configuration for the node group.
+	NodeRepairConfig *NodeRepairConfig `locationName:"nodeRepairConfig" type:"structure"`
+
+	// The node group update configuration.
+	UpdateConfig *UpdateConfig `locationName:"updateConfig" type:"structure"`
+}
+
+// DescribeConfigOutput is the output of DescribeNodegroup restricted to the Config.
+type DescribeConfigOutput struct {
+	_ struct{} `type:"structure"`
+
+	Nodegroup *Config `locationName:"nodegroup" type:"structure"`
+}
+
+// UpdateConfigInput is eks.UpdateNodegroupConfigInput with the node repair config
+// and update strategy.
+type UpdateConfigInput struct {
+	_ struct{} `type:"structure"`
+
+	// A unique, case-sensitive identifier that you provide to ensure the idempotency
+	// of the request.
+	ClientRequestToken *string `locationName:"clientRequestToken" type:"string" idempotencyToken:"true"`
+
+	// The name of your cluster.
+	ClusterName *string `location:"uri" locationName:"name" type:"string" required:"true"`
+
+	// The Kubernetes labels to apply to the nodes in the node group after the update.
+	Labels *eks.UpdateLabelsPayload `locationName:"labels" type:"structure"`
+
+	// The node auto repair configuration for the node group.
+	NodeRepairConfig *NodeRepairConfig `locationName:"nodeRepairConfig" type:"structure"`
+
+	// The name of the managed node group to update.
+	NodegroupName *string `location:"uri" locationName:"nodegroupName" type:"string" required:"true"`
+
+	// The scaling configuration details for the Auto Scaling group after the update.
+	ScalingConfig *eks.NodegroupScalingConfig `locationName:"scalingConfig" type:"structure"`
+
+	// The Kubernetes taints to be applied to the nodes in the node group after
+	// the update.
+	Taints *eks.UpdateTaintsPayload `locationName:"taints" type:"structure"`
+
+	// The node group update configuration.
+	UpdateConfig *UpdateConfig `locationName:"updateConfig" type:"structure"`
+}
+
+// Validate inspects the fields of the type to determine if they are valid.
+func (s *UpdateConfigInput) Validate() error {
+	input := &eks.UpdateNodegroupConfigInput{
+		ClusterName:   s.ClusterName,
+		Labels:        s.Labels,
+		NodegroupName: s.NodegroupName,
+		ScalingConfig: s.ScalingConfig,
+		Taints:        s.Taints,
+	}
+	if s.UpdateConfig != nil {
+		input.UpdateConfig = &eks.NodegroupUpdateConfig{
+			MaxUnavailable:           s.UpdateConfig.MaxUnavailable,
+			MaxUnavailablePercentage: s.UpdateConfig.MaxUnavailablePercentage,
+		}
+	}
+
+	return input.Validate()
+}
+
+// CreateInput is eks.CreateNodegroupInput with the node repair config and update strategy.
+type CreateInput struct {
+	_ struct{} `type:"structure"`
+
+	// The AMI type for your node group.
+	AmiType *string `locationName:"amiType" type:"string"`
+
+	// The capacity type for your node group.
+	CapacityType *string `locationName:"capacityType" type:"string"`
+
+	// A unique, case-sensitive identifier that you provide to ensure the idempotency
+	// of the request.
+	ClientRequestToken *string `locationName:"clientRequestToken" type:"string" idempotencyToken:"true"`
+
+	// The name of your cluster.
+	ClusterName *string `location:"uri" locationName:"name" type:"string" required:"true"`
+
+	// The root device disk size (in GiB) for your node group instances.
+	DiskSize *int64 `locationName:"diskSize" type:"integer"`
+
+	// The instance types of the node group.
+	InstanceTypes []*string `locationName:"instanceTypes" type:"list"`
+
+	// The Kubernetes labels to apply to the nodes in the node group when they are created.
+	Labels map[string]*string `locationName:"labels" type:"map"`
+
+	// An object representing a node group's launch template specification.
+	LaunchTemplate *eks.LaunchTemplateSpecification `locationName:"launchTemplate" type:"structure"`
+
+	// The node auto repair configuration for the node group.
+	NodeRepairConfig *NodeRepairConfig `locationName:"nodeRepairConfig" type:"structure"`
+
+	// The Amazon Resource Name (ARN) of the IAM role to associate with your node group.
+	NodeRole *string `locationName:"nodeRole" type:"string" required:"true"`
+
+	// The unique name to give your node group.
+	NodegroupName *string `locationName:"nodegroupName" type:"string" required:"true"`
+
+	// The AMI version of the Amazon EKS optimized AMI to use with your node group.
+	ReleaseVersion *string `locationName:"releaseVersion" type:"string"`
+
+	// The remote access configuration to use with your node group.
+	RemoteAccess *eks.RemoteAccessConfig `locationName:"remoteAccess" type:"structure"`
+
+	// The scaling configuration details for the Auto Scaling group that is created
+	// for your node group.
+	ScalingConfig *eks.NodegroupScalingConfig `locationName:"scalingConfig" type:"structure"`
+
+	// The subnets to use for the Auto Scaling group that is created for your node group.
+	Subnets []*string `locationName:"subnets" type:"list" required:"true"`
+
+	// Metadata that assists with categorization and organization.
+	Tags map[string]*string `locationName:"tags" min:"1" type:"map"`
+
+	// The Kubernetes taints to be applied to the nodes in the node group.
+	Taints []*eks.Taint `locationName:"taints" type:"list"`
+
+	// The node group update configuration.
+	UpdateConfig *UpdateConfig `locationName:"updateConfig" type:"structure"`
+
+	// The Kubernetes version to use for your managed nodes.
+	Version *string `locationName:"version" type:"string"`
+}
+
+// NewCreateInput returns a CreateInput with the fields of the eks.CreateNodegroupInput, the node repair config
+// and the update config including the update strategy.
+func NewCreateInput(input *eks.CreateNodegroupInput, nodeRepairConfig *NodeRepairConfig, updateConfig *UpdateConfig) *CreateInput {
+	return &CreateInput{
+		AmiType:            input.AmiType,
+		CapacityType:       input.CapacityType,
+		ClientRequestToken: input.ClientRequestToken,
+		ClusterName:        input.ClusterName,
+		DiskSize:           input.DiskSize,
+		InstanceTypes:      input.InstanceTypes,
+		Labels:             input.Labels,
+		LaunchTemplate:     input.LaunchTemplate,
+		NodeRepairConfig:   nodeRepairConfig,
+		NodeRole:           input.NodeRole,
+		NodegroupName:      input.NodegroupName,
+		ReleaseVersion:     input.ReleaseVersion,
+		RemoteAccess:       input.RemoteAccess,
+		ScalingConfig:      input.ScalingConfig,
+		Subnets:            input.Subnets,
+		Tags:               input.Tags,
+		Taints:             input.Taints,
+		UpdateConfig:       updateConfig,
+		Version:            input.Version,
+	}
+}
@@ -19,6 +19,8 @@ package mime
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"html/template"
 	"mime/multipart"
@@ -39,6 +41,14 @@ var (
 		"content-type": {"text/cloud-boothook"},
 	}
 
+	shellScriptType = textproto.MIMEHeader{
+		"content-type": {"text/x-shellscript; charset=\"us-ascii\""},
+	}
+
+	cloudConfigType = textproto.MIMEHeader{
+		"content-type": {"text/cloud-config; charset=\"us-ascii\""},
+	}
+
 	multipartHeader = strings.Join([]string{
 		"MIME-Version: 1.0",
 		"Content-Type: multipart/mixed; boundary=\"%s\"",
@@ -99,3 +109,71 @@ func GenerateInitDocument(secretPrefix string, chunks int32, region string, endp
 
 	return buf.Bytes(), nil
 }
+
+// GenerateMultipartDocument wraps user data in a multipart MIME document, as required for
+// the user data of launch templates used by EKS managed node groups. The content type of
+// the part is derived from the user data. User data that is already a MIME document, or
+// that isn't a shell script, cloud-config or boothook (e.g. Bottlerocket TOML), is returned
+// unchanged.
+//
+// The boundary is derived from the user data so that the same user data always results in
+// the same document, which keeps the hash of the launch template user data stable.
+func GenerateMultipartDocument(userData []byte) ([]byte, error) {
+	if IsMultipartDocument(userData) {
+		return userData, nil
+	}
+
+	var partType textproto.MIMEHeader
+	switch {
+	case bytes.HasPrefix(userData, []byte("#cloud-config")):
+		partType = cloudConfigType
+	case bytes.HasPrefix(userData, []byte("#cloud-boothook")):
+		partType = boothookType
+	case bytes.HasPrefix(userData, []byte("#!")):
+		partType = shellScriptType
+	default:
+		return userData, nil
+	}
+
+	sum := sha256.Sum256(userData)
+
+	var buf bytes.Buffer
+	mpWriter := multipart.NewWriter(&buf)
+	if err := mpWriter.SetBoundary(hex.EncodeToString(sum[:])[:32]); err != nil {
+		return nil, err
+	}
+	buf.WriteString(fmt.Sprintf(multipartHeader, mpWriter.Boundary()))
+
+	partWriter, err := mpWriter.CreatePart(partType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := partWriter.Write(userData); err != nil {
+		return nil, err
+	}
+
+	if err := mpWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// IsMultipartDocument returns true if the user data is a multipart MIME document.
+func IsMultipartDocument(userData []byte) bool {
+	header := userData
+	if i := bytes.Index(userData, []byte("\n\n")); i >= 0 {
+		header = userData[:i]
+	}
+	for _, line := range strings.Split(string(header), "\n") {
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "content-type") && strings.HasPrefix(strings.ToLower(strings.TrimSpace(value)), "multipart/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -18,7 +18,11 @@ package mime
 
 import (
 	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/mail"
+	"strings"
 	"testing"
 )
 
@@ -30,3 +34,84 @@ func TestGenerateInitDocument(t *testing.T) {
 		t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
 	}
 }
+
+func TestGenerateMultipartDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		userData    string
+		contentType string
+		unchanged   bool
+	}{
+		{
+			name:        "shell script",
+			userData:    "#!/bin/bash\n/etc/eks/bootstrap.sh test-cluster\n",
+			contentType: "text/x-shellscript",
+		},
+		{
+			name:        "cloud-config",
+			userData:    "#cloud-config\nruncmd:\n- echo hello\n",
+			contentType: "text/cloud-config",
+		},
+		{
+			name:        "boothook",
+			userData:    "#cloud-boothook\necho hello\n",
+			contentType: "text/cloud-boothook",
+		},
+		{
+			name:      "toml",
+			userData:  "[settings.kubernetes]\ncluster-name = \"test-cluster\"\n",
+			unchanged: true,
+		},
+		{
+			name:      "already multipart",
+			userData:  "MIME-Version: 1.0\nContent-Type: multipart/mixed; boundary=\"abc\"\n\n--abc\nContent-Type: text/x-shellscript\n\n#!/bin/bash\n--abc--\n",
+			unchanged: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := GenerateMultipartDocument([]byte(tc.userData))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.unchanged {
+				if string(doc) != tc.userData {
+					t.Fatalf("expected user data to be unchanged, got:\n%s", string(doc))
+				}
+				return
+			}
+
+			again, err := GenerateMultipartDocument([]byte(tc.userData))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(doc, again) {
+				t.Fatalf("expected document to be deterministic")
+			}
+
+			msg, err := mail.ReadMessage(bytes.NewBuffer(doc))
+			if err != nil {
+				t.Fatalf("Cannot parse MIME doc: %+v\n%s", err, string(doc))
+			}
+			_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			if err != nil {
+				t.Fatalf("Cannot parse content type: %v", err)
+			}
+			reader := multipart.NewReader(msg.Body, params["boundary"])
+			part, err := reader.NextPart()
+			if err != nil {
+				t.Fatalf("Cannot read MIME part: %v", err)
+			}
+			if !strings.HasPrefix(part.Header.Get("Content-Type"), tc.contentType) {
+				t.Fatalf("expected content type %q, got %q", tc.contentType, part.Header.Get("Content-Type"))
+			}
+			body, err := io.ReadAll(part)
+			if err != nil {
+				t.Fatalf("Cannot read MIME part: %v", err)
+			}
+			if string(body) != tc.userData {
+				t.Fatalf("expected part to contain user data, got:\n%s", string(body))
+			}
+		})
+	}
+}
@@ -3,16 +3,18 @@ package rosa
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 	ocmcfg "github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-
-	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
 
 const (
@@ -20,8 +22,16 @@ const (
 	ocmAPIURLKey = "ocmApiUrl"
 )
 
+// OCMSecretsRetriever is implemented by the scopes of the resources holding a reference to the OCM credentials.
+type OCMSecretsRetriever interface {
+	// CredentialsSecret returns the secret holding the OCM credentials, or nil to use the environment variables.
+	CredentialsSecret() *corev1.Secret
+	// GetClient returns the Kubernetes client used to read the secret.
+	GetClient() client.Client
+}
+
 // NewOCMClient creates a new OCM client.
-func NewOCMClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*ocm.Client, error) {
+func NewOCMClient(ctx context.Context, rosaScope OCMSecretsRetriever) (*ocm.Client, error) {
 	token, url, err := ocmCredentials(ctx, rosaScope)
 	if err != nil {
 		return nil, err
@@ -32,7 +42,7 @@ func NewOCMClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (
 	}).Build()
 }
 
-func newOCMRawConnection(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*sdk.Connection, error) {
+func newOCMRawConnection(ctx context.Context, rosaScope OCMSecretsRetriever, transportWrappers ...sdk.TransportWrapper) (*sdk.Connection, error) {
 	logger, err := sdk.NewGoLoggerBuilder().
 		Debug(false).
 		Build()
@@ -44,11 +54,14 @@ func newOCMRawConnection(ctx context.Context, rosaScope *scope.ROSAControlPlaneS
 		return nil, err
 	}
 
-	connection, err := sdk.NewConnectionBuilder().
+	connectionBuilder := sdk.NewConnectionBuilder().
 		Logger(logger).
 		Tokens(token).
-		URL(url).
-		Build()
+		URL(url)
+	for _, transportWrapper := range transportWrappers {
+		connectionBuilder = connectionBuilder.TransportWrapper(transportWrapper)
+	}
+	connection, err := connectionBuilder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ocm connection: %w", err)
 	}
@@ -56,13 +69,55 @@ func newOCMRawConnection(ctx context.Context, rosaScope *scope.ROSAControlPlaneS
 	return connection, nil
 }
 
-func ocmCredentials(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (string, string, error) {
+// sendRawRequest sends a request over a raw OCM connection and returns the body of the response, turning error statuses
+// into errors.
+func sendRawRequest(request *sdk.Request, action string) ([]byte, error) {
+	response, err := request.Send()
+	if err != nil {
+		return nil, err
+	}
+	if response.Status() >= http.StatusBadRequest {
+		responseErr, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+		if err != nil {
+			return nil, fmt.Errorf("failed to %s, status %d: %w", action, response.Status(), err)
+		}
+		return nil, handleErr(responseErr, responseErr)
+	}
+	return response.Bytes(), nil
+}
+
+// mergeAWSSettings merges the AWS settings into the object under the given key of the JSON body of a raw request.
+func mergeAWSSettings(body []byte, key string, awsSettings interface{}) ([]byte, error) {
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil, err
+	}
+	aws, _ := object[key].(map[string]interface{})
+	if aws == nil {
+		aws = map[string]interface{}{}
+	}
+
+	settingsJSON, err := json.Marshal(awsSettings)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(settingsJSON, &aws); err != nil {
+		return nil, err
+	}
+	if len(aws) > 0 {
+		object[key] = aws
+	}
+
+	return json.Marshal(object)
+}
+
+func ocmCredentials(ctx context.Context, rosaScope OCMSecretsRetriever) (string, string, error) {
 	var token string
 	var ocmAPIUrl string
 
 	secret := rosaScope.CredentialsSecret()
 	if secret != nil {
-		if err := rosaScope.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+		if err := rosaScope.GetClient().Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
 			return "", "", fmt.Errorf("failed to get credentials secret: %w", err)
 		}
 
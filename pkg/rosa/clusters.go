@@ -0,0 +1,89 @@
+package rosa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/openshift/rosa/pkg/ocm"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+const clustersPath = "/api/clusters_mgmt/v1/clusters"
+
+// AWSClusterSettings are AWS settings of a cluster which the OCM client doesn't support yet.
+type AWSClusterSettings struct {
+	VPCEndpointRoleARN                   string `json:"vpc_endpoint_role_arn,omitempty"`
+	HCPInternalCommunicationHostedZoneID string `json:"hcp_internal_communication_hosted_zone_id,omitempty"`
+}
+
+// IsEmpty returns true if none of the AWS settings is set, in which case clusters are created with the OCM client.
+func (s AWSClusterSettings) IsEmpty() bool {
+	return s.VPCEndpointRoleARN == "" && s.HCPInternalCommunicationHostedZoneID == ""
+}
+
+// ClusterClient handles cluster operations which aren't supported by the OCM client.
+type ClusterClient struct {
+	ocm *sdk.Connection
+}
+
+// NewClusterClient creates and return a new client which creates clusters with the given AWS settings in addition to
+// the settings of their spec.
+func NewClusterClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope, awsSettings AWSClusterSettings) (*ClusterClient, error) {
+	ocmConnection, err := newOCMRawConnection(ctx, rosaScope, func(wrapped http.RoundTripper) http.RoundTripper {
+		return &awsClusterSettingsTransport{wrapped: wrapped, awsSettings: awsSettings}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ClusterClient{
+		ocm: ocmConnection,
+	}, nil
+}
+
+// Close closes the underlying ocm connection.
+func (c *ClusterClient) Close() error {
+	return c.ocm.Close()
+}
+
+// CreateCluster creates a new cluster from the spec. The OCM client builds the cluster, and the AWS settings are merged
+// into its `aws` before it's posted, so it's only meant for non-empty AWS settings; other clusters are created with
+// the OCM client.
+func (c *ClusterClient) CreateCluster(spec ocm.Spec) (*cmv1.Cluster, error) {
+	return ocm.NewClientWithConnection(c.ocm).CreateCluster(spec)
+}
+
+// awsClusterSettingsTransport merges the AWS settings into the body of the requests creating clusters.
+type awsClusterSettingsTransport struct {
+	wrapped     http.RoundTripper
+	awsSettings AWSClusterSettings
+}
+
+func (t *awsClusterSettingsTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodPost || request.URL.Path != clustersPath || request.Body == nil {
+		return t.wrapped.RoundTrip(request)
+	}
+
+	body, err := io.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster: %w", err)
+	}
+	body, err = mergeAWSSettings(body, "aws", t.awsSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge AWS settings into cluster: %w", err)
+	}
+
+	request = request.Clone(request.Context())
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.ContentLength = int64(len(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return t.wrapped.RoundTrip(request)
+}
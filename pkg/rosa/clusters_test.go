@@ -0,0 +1,59 @@
+package rosa
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAWSClusterSettingsTransport(t *testing.T) {
+	g := NewWithT(t)
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &awsClusterSettingsTransport{
+		wrapped: http.DefaultTransport,
+		awsSettings: AWSClusterSettings{
+			VPCEndpointRoleARN:                   "arn:aws:iam::210987654321:role/shared-vpc-endpoint",
+			HCPInternalCommunicationHostedZoneID: "Z0123456789ABCDEFGHIJ",
+		},
+	}}
+	post := func(path, body string) {
+		response, err := client.Post(server.URL+path, "application/json", bytes.NewBufferString(body))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(response.Body.Close()).To(Succeed())
+	}
+
+	// the AWS settings are merged into the clusters.
+	post(clustersPath, `{"kind":"Cluster","name":"capa-rosa","aws":{"subnet_ids":["subnet-1"]}}`)
+	g.Expect(received).To(MatchJSON(`{
+		"kind": "Cluster",
+		"name": "capa-rosa",
+		"aws": {
+			"subnet_ids": ["subnet-1"],
+			"vpc_endpoint_role_arn": "arn:aws:iam::210987654321:role/shared-vpc-endpoint",
+			"hcp_internal_communication_hosted_zone_id": "Z0123456789ABCDEFGHIJ"
+		}
+	}`))
+
+	// other requests are left alone.
+	post(clustersPath+"/cluster-id/node_pools", `{"kind":"NodePool","id":"nodepool-0"}`)
+	g.Expect(received).To(MatchJSON(`{"kind":"NodePool","id":"nodepool-0"}`))
+}
+
+func TestAWSClusterSettingsIsEmpty(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(AWSClusterSettings{}.IsEmpty()).To(BeTrue())
+	g.Expect(AWSClusterSettings{VPCEndpointRoleARN: "arn:aws:iam::210987654321:role/shared-vpc-endpoint"}.IsEmpty()).To(BeFalse())
+	g.Expect(AWSClusterSettings{HCPInternalCommunicationHostedZoneID: "Z0123456789ABCDEFGHIJ"}.IsEmpty()).To(BeFalse())
+}
@@ -1,15 +1,23 @@
 package rosa
 
 import (
+	"context"
 	"fmt"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
 )
 
 const (
-	clusterAdminUserGroup = "cluster-admins"
-	clusterAdminIDPname   = "cluster-admin"
+	clusterAdminIDPname = "cluster-admin"
+
+	// ClusterAdminsGroup is the group granting cluster-admin privileges on ROSA clusters.
+	ClusterAdminsGroup = "cluster-admins"
+	// DedicatedAdminsGroup is the group granting dedicated-admin privileges on ROSA clusters.
+	DedicatedAdminsGroup = "dedicated-admins"
 )
 
 // CreateAdminUserIfNotExist creates a new admin user withe username/password in the cluster if username doesn't already exist.
@@ -27,7 +35,7 @@ func CreateAdminUserIfNotExist(client *ocm.Client, clusterID, username, password
 	}
 
 	// Add admin user to the cluster-admins group:
-	user, err := CreateUserIfNotExist(client, clusterID, clusterAdminUserGroup, username)
+	user, err := CreateUserIfNotExist(client, clusterID, ClusterAdminsGroup, username)
 	if err != nil {
 		return fmt.Errorf("failed to add user '%s' to cluster '%s': %s",
 			username, clusterID, err)
@@ -64,7 +72,7 @@ func CreateAdminUserIfNotExist(client *ocm.Client, clusterID, username, password
 	_, err = client.CreateIdentityProvider(clusterID, clusterAdminIDP)
 	if err != nil {
 		// since we could not add the HTPasswd IDP to the cluster, roll back and remove the cluster admin
-		if err := client.DeleteUser(clusterID, clusterAdminUserGroup, user.ID()); err != nil {
+		if err := client.DeleteUser(clusterID, ClusterAdminsGroup, user.ID()); err != nil {
 			return fmt.Errorf("failed to revert the admin user for cluster '%s': %w",
 				clusterID, err)
 		}
@@ -126,3 +134,132 @@ func hasUser(username string, userList *cmv1.HTPasswdUserList) bool {
 	})
 	return hasUser
 }
+
+// IdentityProviderClient handles the identity providers of ROSA clusters and the users of their htpasswd identity providers.
+type IdentityProviderClient struct {
+	ocm *sdk.Connection
+}
+
+// NewIdentityProviderClient creates and return a new client to handle identity provider operations.
+func NewIdentityProviderClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*IdentityProviderClient, error) {
+	ocmConnection, err := newOCMRawConnection(ctx, rosaScope)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityProviderClient{
+		ocm: ocmConnection,
+	}, nil
+}
+
+// Close closes the underlying ocm connection.
+func (c *IdentityProviderClient) Close() error {
+	return c.ocm.Close()
+}
+
+// ListIdentityProviders lists all identity providers of the cluster.
+func (c *IdentityProviderClient) ListIdentityProviders(clusterID string) ([]*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// CreateIdentityProvider creates a new identity provider.
+func (c *IdentityProviderClient) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().
+		Add().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// UpdateIdentityProvider updates an existing identity provider.
+func (c *IdentityProviderClient) UpdateIdentityProvider(clusterID string, idpID string, idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Update().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// DeleteIdentityProvider deletes the specified identity provider.
+func (c *IdentityProviderClient) DeleteIdentityProvider(clusterID string, idpID string) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// ListHTPasswdUsers lists all users of the specified htpasswd identity provider.
+func (c *IdentityProviderClient) ListHTPasswdUsers(clusterID string, idpID string) ([]*cmv1.HTPasswdUser, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// AddHTPasswdUser adds a user to the specified htpasswd identity provider.
+func (c *IdentityProviderClient) AddHTPasswdUser(clusterID string, idpID string, user *cmv1.HTPasswdUser) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().
+		Add().Body(user).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// UpdateHTPasswdUser updates the password of a user of the specified htpasswd identity provider.
+func (c *IdentityProviderClient) UpdateHTPasswdUser(clusterID string, idpID string, userID string, user *cmv1.HTPasswdUser) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().HtpasswdUser(userID).
+		Update().Body(user).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// DeleteHTPasswdUser deletes a user of the specified htpasswd identity provider.
+func (c *IdentityProviderClient) DeleteHTPasswdUser(clusterID string, idpID string, userID string) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		HtpasswdUsers().HtpasswdUser(userID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
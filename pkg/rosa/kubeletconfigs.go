@@ -0,0 +1,83 @@
+package rosa
+
+import (
+	"context"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// KubeletConfigClient handles the kubelet configs of ROSA HCP clusters, which node pools reference by name.
+type KubeletConfigClient struct {
+	ocm *sdk.Connection
+}
+
+// NewKubeletConfigClient creates and return a new client to handle kubelet config operations.
+func NewKubeletConfigClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*KubeletConfigClient, error) {
+	ocmConnection, err := newOCMRawConnection(ctx, rosaScope)
+	if err != nil {
+		return nil, err
+	}
+	return &KubeletConfigClient{
+		ocm: ocmConnection,
+	}, nil
+}
+
+// Close closes the underlying ocm connection.
+func (c *KubeletConfigClient) Close() error {
+	return c.ocm.Close()
+}
+
+// ListKubeletConfigs lists all kubelet configs of the cluster.
+func (c *KubeletConfigClient) ListKubeletConfigs(clusterID string) ([]*cmv1.KubeletConfig, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		KubeletConfigs().
+		List().Page(1).Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// CreateKubeletConfig creates a new kubelet config.
+func (c *KubeletConfigClient) CreateKubeletConfig(clusterID string, kubeletConfig *cmv1.KubeletConfig) (*cmv1.KubeletConfig, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		KubeletConfigs().
+		Add().Body(kubeletConfig).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// UpdateKubeletConfig updates an existing kubelet config.
+func (c *KubeletConfigClient) UpdateKubeletConfig(clusterID string, kubeletConfig *cmv1.KubeletConfig) (*cmv1.KubeletConfig, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		KubeletConfigs().KubeletConfig(kubeletConfig.ID()).
+		Update().Body(kubeletConfig).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// DeleteKubeletConfig deletes the specified kubelet config.
+func (c *KubeletConfigClient) DeleteKubeletConfig(clusterID string, kubeletConfigID string) error {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		KubeletConfigs().KubeletConfig(kubeletConfigID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package rosa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// LogForwarder forwards the logs of control plane applications of a ROSA HCP cluster to a single destination.
+// The OCM SDK doesn't support log forwarders yet, so the types follow the log forwarder resource of the clusters_mgmt
+// v1 API model (github.com/openshift-online/ocm-api-model, model/clusters_mgmt/v1), served under
+// /api/clusters_mgmt/v1/clusters/{cluster_id}/control_plane/log_forwarders. The log forwarders are only managed with
+// the ROSALogForwarding feature gate until the OCM SDK supports them.
+type LogForwarder struct {
+	ID           string                  `json:"id,omitempty"`
+	Applications []string                `json:"applications,omitempty"`
+	Groups       []LogForwarderGroup     `json:"groups,omitempty"`
+	CloudWatch   *LogForwarderCloudWatch `json:"cloudwatch,omitempty"`
+	S3           *LogForwarderS3         `json:"s3,omitempty"`
+	Status       *LogForwarderStatus     `json:"status,omitempty"`
+}
+
+// LogForwarderGroup is a group of control plane applications whose logs are forwarded.
+type LogForwarderGroup struct {
+	ID      string `json:"id"`
+	Version string `json:"version,omitempty"`
+}
+
+// LogForwarderCloudWatch is a CloudWatch log group the logs are forwarded to.
+type LogForwarderCloudWatch struct {
+	LogGroupName           string `json:"log_group_name"`
+	LogDistributionRoleARN string `json:"log_distribution_role_arn"`
+}
+
+// LogForwarderS3 is an S3 bucket the logs are forwarded to.
+type LogForwarderS3 struct {
+	BucketName   string `json:"bucket_name"`
+	BucketPrefix string `json:"bucket_prefix,omitempty"`
+}
+
+// LogForwarderStatus is the state of a log forwarder.
+type LogForwarderStatus struct {
+	State                string   `json:"state,omitempty"`
+	Message              string   `json:"message,omitempty"`
+	ResolvedApplications []string `json:"resolved_applications,omitempty"`
+}
+
+// LogForwarderClient handles the log forwarders of ROSA HCP clusters.
+type LogForwarderClient struct {
+	ocm *sdk.Connection
+}
+
+// NewLogForwarderClient creates and return a new client to handle log forwarder operations.
+func NewLogForwarderClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*LogForwarderClient, error) {
+	ocmConnection, err := newOCMRawConnection(ctx, rosaScope)
+	if err != nil {
+		return nil, err
+	}
+	return &LogForwarderClient{
+		ocm: ocmConnection,
+	}, nil
+}
+
+// Close closes the underlying ocm connection.
+func (c *LogForwarderClient) Close() error {
+	return c.ocm.Close()
+}
+
+// ListLogForwarders lists all log forwarders of the cluster.
+func (c *LogForwarderClient) ListLogForwarders(clusterID string) ([]*LogForwarder, error) {
+	body, err := sendRawRequest(c.ocm.Get().Path(logForwardersPath(clusterID)).Parameter("size", -1), "list log forwarders")
+	if err != nil {
+		return nil, err
+	}
+
+	list := struct {
+		Items []*LogForwarder `json:"items"`
+	}{}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log forwarders: %w", err)
+	}
+	return list.Items, nil
+}
+
+// CreateLogForwarder creates a new log forwarder.
+func (c *LogForwarderClient) CreateLogForwarder(clusterID string, logForwarder *LogForwarder) (*LogForwarder, error) {
+	request, err := json.Marshal(logForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRawRequest(c.ocm.Post().Path(logForwardersPath(clusterID)).Bytes(request), "create log forwarder")
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLogForwarder(body)
+}
+
+// UpdateLogForwarder updates an existing log forwarder.
+func (c *LogForwarderClient) UpdateLogForwarder(clusterID string, logForwarderID string, logForwarder *LogForwarder) (*LogForwarder, error) {
+	request, err := json.Marshal(logForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := sendRawRequest(c.ocm.Patch().Path(logForwardersPath(clusterID)+"/"+logForwarderID).Bytes(request), "update log forwarder")
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalLogForwarder(body)
+}
+
+// DeleteLogForwarder deletes the specified log forwarder.
+func (c *LogForwarderClient) DeleteLogForwarder(clusterID string, logForwarderID string) error {
+	_, err := sendRawRequest(c.ocm.Delete().Path(logForwardersPath(clusterID)+"/"+logForwarderID), "delete log forwarder")
+	return err
+}
+
+func logForwardersPath(clusterID string) string {
+	return fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/control_plane/log_forwarders", clusterID)
+}
+
+func unmarshalLogForwarder(body []byte) (*LogForwarder, error) {
+	logForwarder := &LogForwarder{}
+	if err := json.Unmarshal(body, logForwarder); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal log forwarder: %w", err)
+	}
+	return logForwarder, nil
+}
@@ -0,0 +1,82 @@
+package rosa
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+// AWSNodePoolSettings are AWS settings of a node pool which the OCM SDK doesn't support yet.
+type AWSNodePoolSettings struct {
+	RootVolume            *AWSNodePoolRootVolume  `json:"root_volume,omitempty"`
+	EC2MetadataHTTPTokens string                  `json:"ec2_metadata_http_tokens,omitempty"`
+	CapacityReservation   *AWSCapacityReservation `json:"capacity_reservation,omitempty"`
+}
+
+// IsEmpty returns true if none of the AWS settings is set, in which case node pools are created with the OCM client.
+func (s AWSNodePoolSettings) IsEmpty() bool {
+	return s.RootVolume == nil && s.EC2MetadataHTTPTokens == "" && s.CapacityReservation == nil
+}
+
+// AWSNodePoolRootVolume is the root volume of the instances of a node pool.
+type AWSNodePoolRootVolume struct {
+	// Size in GiB.
+	Size int `json:"size"`
+}
+
+// AWSCapacityReservation is the EC2 capacity reservation targeted by the instances of a node pool.
+type AWSCapacityReservation struct {
+	ID         string `json:"id"`
+	MarketType string `json:"market_type,omitempty"`
+}
+
+// NodePoolClient handles node pool operations which aren't supported by the OCM client.
+type NodePoolClient struct {
+	ocm *sdk.Connection
+}
+
+// NewNodePoolClient creates and return a new client to handle node pool operations.
+func NewNodePoolClient(ctx context.Context, rosaScope *scope.ROSAControlPlaneScope) (*NodePoolClient, error) {
+	ocmConnection, err := newOCMRawConnection(ctx, rosaScope)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePoolClient{
+		ocm: ocmConnection,
+	}, nil
+}
+
+// Close closes the underlying ocm connection.
+func (c *NodePoolClient) Close() error {
+	return c.ocm.Close()
+}
+
+// CreateNodePool creates a new node pool with the given AWS settings in addition to the settings of the node pool. It
+// posts the raw node pool, so it's only meant for non-empty AWS settings; other node pools are created with the OCM client.
+func (c *NodePoolClient) CreateNodePool(clusterID string, nodePool *cmv1.NodePool, awsSettings AWSNodePoolSettings) (*cmv1.NodePool, error) {
+	body, err := nodePoolWithAWSSettings(nodePool, awsSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := sendRawRequest(c.ocm.Post().Path(fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/node_pools", clusterID)).Bytes(body), "create node pool")
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalNodePool(response)
+}
+
+// nodePoolWithAWSSettings returns the JSON body of the node pool with the AWS settings merged into its `aws_node_pool`.
+func nodePoolWithAWSSettings(nodePool *cmv1.NodePool, awsSettings AWSNodePoolSettings) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalNodePool(nodePool, &buffer); err != nil {
+		return nil, fmt.Errorf("failed to marshal node pool: %w", err)
+	}
+
+	return mergeAWSSettings(buffer.Bytes(), "aws_node_pool", awsSettings)
+}
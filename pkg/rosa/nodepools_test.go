@@ -0,0 +1,48 @@
+package rosa
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestNodePoolWithAWSSettings(t *testing.T) {
+	g := NewWithT(t)
+
+	nodePool, err := cmv1.NewNodePool().ID("nodepool-0").
+		AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge")).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	body, err := nodePoolWithAWSSettings(nodePool, AWSNodePoolSettings{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(body).To(MatchJSON(`{"kind":"NodePool","id":"nodepool-0","aws_node_pool":{"kind":"AWSNodePool","instance_type":"m5.xlarge"}}`))
+
+	body, err = nodePoolWithAWSSettings(nodePool, AWSNodePoolSettings{
+		RootVolume:            &AWSNodePoolRootVolume{Size: 300},
+		EC2MetadataHTTPTokens: "required",
+		CapacityReservation:   &AWSCapacityReservation{ID: "cr-0123456789abcdef0", MarketType: "OnDemand"},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(body).To(MatchJSON(`{
+		"kind": "NodePool",
+		"id": "nodepool-0",
+		"aws_node_pool": {
+			"kind": "AWSNodePool",
+			"instance_type": "m5.xlarge",
+			"root_volume": {"size": 300},
+			"ec2_metadata_http_tokens": "required",
+			"capacity_reservation": {"id": "cr-0123456789abcdef0", "market_type": "OnDemand"}
+		}
+	}`))
+}
+
+func TestAWSNodePoolSettingsIsEmpty(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(AWSNodePoolSettings{}.IsEmpty()).To(BeTrue())
+	g.Expect(AWSNodePoolSettings{RootVolume: &AWSNodePoolRootVolume{Size: 300}}.IsEmpty()).To(BeFalse())
+	g.Expect(AWSNodePoolSettings{EC2MetadataHTTPTokens: "required"}.IsEmpty()).To(BeFalse())
+	g.Expect(AWSNodePoolSettings{CapacityReservation: &AWSCapacityReservation{ID: "cr-0123456789abcdef0"}}.IsEmpty()).To(BeFalse())
+}
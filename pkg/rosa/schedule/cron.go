@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule provides parsing and evaluation of the cron schedules of ROSA maintenance windows.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears is how far ahead Next searches for a time matching a schedule. The 29th of
+// February can be up to eight years away.
+const maxSearchYears = 8
+
+// Cron is a parsed cron expression with the standard fields: minute, hour, day of month, month
+// and day of week.
+type Cron struct {
+	minutes     []bool
+	hours       []bool
+	daysOfMonth []bool
+	months      []bool
+	daysOfWeek  []bool
+	// anyDayOfMonth and anyDayOfWeek report whether the day fields are `*`. If both day
+	// fields are restricted, a time matches if either of them matches.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type fieldBounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds     = fieldBounds{"minute", 0, 59}
+	hourBounds       = fieldBounds{"hour", 0, 23}
+	dayOfMonthBounds = fieldBounds{"day of month", 1, 31}
+	monthBounds      = fieldBounds{"month", 1, 12}
+	// 7 is Sunday like 0.
+	dayOfWeekBounds = fieldBounds{"day of week", 0, 7}
+)
+
+// ParseCron parses a cron expression with five fields. Fields support `*`, values, ranges
+// (`1-5`), steps (`*/15`, `1-10/2`) and lists of those (`1,15,30`).
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, got %d", expr, len(fields))
+	}
+
+	c := &Cron{
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}
+	var err error
+	if c.minutes, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if c.daysOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if c.daysOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+	c.daysOfWeek[0] = c.daysOfWeek[0] || c.daysOfWeek[7]
+
+	return c, nil
+}
+
+func parseField(field string, bounds fieldBounds) ([]bool, error) {
+	values := make([]bool, bounds.max+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i != -1 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return nil, fmt.Errorf("invalid step in %s field %q", bounds.name, part)
+			}
+		}
+
+		start, end := bounds.min, bounds.max
+		if rangePart != "*" {
+			var err error
+			if i := strings.Index(rangePart, "-"); i != -1 {
+				if start, err = parseValue(rangePart[:i], bounds); err != nil {
+					return nil, err
+				}
+				if end, err = parseValue(rangePart[i+1:], bounds); err != nil {
+					return nil, err
+				}
+				if end < start {
+					return nil, fmt.Errorf("invalid range in %s field %q", bounds.name, part)
+				}
+			} else {
+				if start, err = parseValue(rangePart, bounds); err != nil {
+					return nil, err
+				}
+				end = start
+				if step > 1 {
+					end = bounds.max
+				}
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+
+	return values, nil
+}
+
+func parseValue(value string, bounds fieldBounds) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < bounds.min || v > bounds.max {
+		return 0, fmt.Errorf("invalid %s %q, must be between %d and %d", bounds.name, value, bounds.min, bounds.max)
+	}
+
+	return v, nil
+}
+
+// Matches reports whether the minute of t matches the schedule.
+func (c *Cron) Matches(t time.Time) bool {
+	return c.minutes[t.Minute()] && c.hours[t.Hour()] && c.months[int(t.Month())] && c.matchesDay(t)
+}
+
+// matchesDay reports whether the day of t matches the day of month and day of week fields.
+func (c *Cron) matchesDay(t time.Time) bool {
+	dayOfMonth := c.daysOfMonth[t.Day()]
+	dayOfWeek := c.daysOfWeek[int(t.Weekday())]
+	switch {
+	case c.anyDayOfMonth && c.anyDayOfWeek:
+		return true
+	case c.anyDayOfMonth:
+		return dayOfWeek
+	case c.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// Occurs reports whether the schedule matches any time. A schedule restricted to days of the
+// month which don't exist in its months, for example the 30th of February, never occurs.
+func (c *Cron) Occurs() bool {
+	// Any month has every day of the week.
+	if c.anyDayOfMonth || !c.anyDayOfWeek {
+		return true
+	}
+
+	for month := time.January; month <= time.December; month++ {
+		if !c.months[int(month)] {
+			continue
+		}
+		// The days in February of a leap year.
+		days := time.Date(2024, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		for day := 1; day <= days; day++ {
+			if c.daysOfMonth[day] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Next returns the first minute at or after t, in UTC, which matches the schedule. It returns
+// false if the schedule never occurs. Months, days and hours which don't match are skipped as
+// a whole.
+func (c *Cron) Next(t time.Time) (time.Time, bool) {
+	next := t.UTC().Truncate(time.Minute)
+	if next.Before(t) {
+		next = next.Add(time.Minute)
+	}
+
+	for end := next.AddDate(maxSearchYears, 0, 0); next.Before(end); {
+		switch {
+		case !c.months[int(next.Month())]:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !c.hours[next.Hour()]:
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case !c.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next, true
+		}
+	}
+
+	return time.Time{}, false
+}
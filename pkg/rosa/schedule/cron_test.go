@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name      string
+		expr      string
+		expectErr bool
+	}{
+		{
+			name: "every minute",
+			expr: "* * * * *",
+		},
+		{
+			name: "lists, ranges and steps",
+			expr: "0,30 */2 1-15/7 1-12 1-5",
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 2 * * 7",
+		},
+		{
+			name:      "too few fields",
+			expr:      "0 2 * *",
+			expectErr: true,
+		},
+		{
+			name:      "out of range",
+			expr:      "0 24 * * *",
+			expectErr: true,
+		},
+		{
+			name:      "invalid range",
+			expr:      "0 5-2 * * *",
+			expectErr: true,
+		},
+		{
+			name:      "invalid step",
+			expr:      "*/0 * * * *",
+			expectErr: true,
+		},
+		{
+			name:      "names aren't supported",
+			expr:      "0 2 * * SAT",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := ParseCron(tt.expr)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2024, time.May, 15, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+		expectOk bool
+	}{
+		{
+			name:     "next minute",
+			expr:     "* * * * *",
+			expected: time.Date(2024, time.May, 15, 10, 21, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "saturday at 02:00",
+			expr:     "0 2 * * 6",
+			expected: time.Date(2024, time.May, 18, 2, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 2 * * 7",
+			expected: time.Date(2024, time.May, 19, 2, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "day of month or day of week",
+			expr:     "0 0 1 * 5",
+			expected: time.Date(2024, time.May, 17, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "within the current hour",
+			expr:     "* 10-11 * * 3",
+			expected: time.Date(2024, time.May, 15, 10, 21, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "next hour",
+			expr:     "15 * * * *",
+			expected: time.Date(2024, time.May, 15, 11, 15, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "next month",
+			expr:     "30 4 1 * *",
+			expected: time.Date(2024, time.June, 1, 4, 30, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "next year",
+			expr:     "0 0 1 1 *",
+			expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+			expectOk: true,
+		},
+		{
+			name:     "never",
+			expr:     "0 0 31 2 *",
+			expectOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cron, err := ParseCron(tt.expr)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			next, ok := cron.Next(now)
+			g.Expect(ok).To(Equal(tt.expectOk))
+			if tt.expectOk {
+				g.Expect(next).To(Equal(tt.expected))
+			}
+		})
+	}
+}
+
+func TestCronOccurs(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected bool
+	}{
+		{
+			name:     "every minute",
+			expr:     "* * * * *",
+			expected: true,
+		},
+		{
+			name:     "leap day",
+			expr:     "0 0 29 2 *",
+			expected: true,
+		},
+		{
+			name:     "30th of February",
+			expr:     "0 0 30 2 *",
+			expected: false,
+		},
+		{
+			name:     "31st of April and June",
+			expr:     "0 0 31 4,6 *",
+			expected: false,
+		},
+		{
+			name:     "31st of February or Saturdays",
+			expr:     "0 0 31 2 6",
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cron, err := ParseCron(tt.expr)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(cron.Occurs()).To(Equal(tt.expected))
+		})
+	}
+}
@@ -2,11 +2,16 @@ package rosa
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/rosa/pkg/ocm"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/rosa/schedule"
 )
 
 // MinSupportedVersion is the minimum supported version for ROSA.
@@ -48,6 +53,87 @@ func ScheduleControlPlaneUpgrade(client *ocm.Client, cluster *cmv1.Cluster, vers
 	return client.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
 }
 
+// ScheduleAutomaticControlPlaneUpgrade schedules automatic control plane upgrades to the latest patch version
+// at the times of the specified cron schedule.
+func ScheduleAutomaticControlPlaneUpgrade(client *ocm.Client, cluster *cmv1.Cluster, cronSchedule string) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().
+		UpgradeType(cmv1.UpgradeTypeControlPlane).
+		ScheduleType(cmv1.ScheduleTypeAutomatic).
+		Schedule(cronSchedule).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return client.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), upgradePolicy)
+}
+
+var weekdays = map[rosacontrolplanev1.MaintenanceWindowDay]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// MaintenanceWindowSchedule returns the cron schedule of the start of the maintenance window.
+func MaintenanceWindowSchedule(window *rosacontrolplanev1.MaintenanceWindow) string {
+	if window.Schedule != "" {
+		return window.Schedule
+	}
+
+	startHour, _ := maintenanceWindowHours(window)
+	return fmt.Sprintf("0 %d * * %s", startHour, maintenanceWindowDays(window))
+}
+
+// NextMaintenanceWindowRun returns the first time at or after t within the maintenance window. With a
+// cron schedule, that's the next time of the schedule.
+func NextMaintenanceWindowRun(window *rosacontrolplanev1.MaintenanceWindow, t time.Time) (time.Time, error) {
+	expr := window.Schedule
+	if expr == "" {
+		startHour, endHour := maintenanceWindowHours(window)
+		expr = fmt.Sprintf("* %d-%d * * %s", startHour, endHour-1, maintenanceWindowDays(window))
+	}
+
+	cron, err := schedule.ParseCron(expr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid maintenance window: %w", err)
+	}
+	next, ok := cron.Next(t)
+	if !ok {
+		return time.Time{}, fmt.Errorf("maintenance window %q never occurs", expr)
+	}
+
+	return next, nil
+}
+
+func maintenanceWindowHours(window *rosacontrolplanev1.MaintenanceWindow) (int32, int32) {
+	startHour := int32(0)
+	if window.StartHour != nil {
+		startHour = *window.StartHour
+	}
+	endHour := startHour + 1
+	if window.EndHour != nil {
+		endHour = *window.EndHour
+	}
+
+	return startHour, endHour
+}
+
+func maintenanceWindowDays(window *rosacontrolplanev1.MaintenanceWindow) string {
+	if len(window.Days) == 0 {
+		return "*"
+	}
+
+	days := make([]string, 0, len(window.Days))
+	for _, day := range window.Days {
+		days = append(days, strconv.Itoa(int(weekdays[day])))
+	}
+
+	return strings.Join(days, ",")
+}
+
 // ScheduleNodePoolUpgrade schedules a new nodePool upgrade to the specified version at the specified time.
 func ScheduleNodePoolUpgrade(client *ocm.Client, clusterID string, nodePool *cmv1.NodePool, version string, nextRun time.Time) (*cmv1.NodePoolUpgradePolicy, error) {
 	// earliestNextRun is set to at least 5 min from now by the OCM API.
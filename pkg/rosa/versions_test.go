@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+
+	rosacontrolplanev1 "sigs.k8s.io/cluster-api-provider-aws/v2/controlplane/rosa/api/v1beta2"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2024, time.May, 15, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		window           rosacontrolplanev1.MaintenanceWindow
+		expectedSchedule string
+		expectedNextRun  time.Time
+	}{
+		{
+			name:             "cron schedule",
+			window:           rosacontrolplanev1.MaintenanceWindow{Schedule: "30 2 * * 6"},
+			expectedSchedule: "30 2 * * 6",
+			expectedNextRun:  time.Date(2024, time.May, 18, 2, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "days and hours",
+			window: rosacontrolplanev1.MaintenanceWindow{
+				Days:      []rosacontrolplanev1.MaintenanceWindowDay{"Saturday", "Sunday"},
+				StartHour: ptr.To[int32](1),
+				EndHour:   ptr.To[int32](4),
+			},
+			expectedSchedule: "0 1 * * 6,0",
+			expectedNextRun:  time.Date(2024, time.May, 18, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "inside the window",
+			window: rosacontrolplanev1.MaintenanceWindow{
+				StartHour: ptr.To[int32](8),
+				EndHour:   ptr.To[int32](12),
+			},
+			expectedSchedule: "0 8 * * *",
+			expectedNextRun:  time.Date(2024, time.May, 15, 10, 21, 0, 0, time.UTC),
+		},
+		{
+			name: "hour after start hour",
+			window: rosacontrolplanev1.MaintenanceWindow{
+				StartHour: ptr.To[int32](9),
+			},
+			expectedSchedule: "0 9 * * *",
+			expectedNextRun:  time.Date(2024, time.May, 16, 9, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(MaintenanceWindowSchedule(&tt.window)).To(Equal(tt.expectedSchedule))
+
+			nextRun, err := NextMaintenanceWindowRun(&tt.window, now)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(nextRun).To(Equal(tt.expectedNextRun))
+		})
+	}
+}
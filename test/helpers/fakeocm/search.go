@@ -0,0 +1,338 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeocm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// searchExpression is a parsed OCM search query, such as `name = 'foo' AND (id = 'bar' OR external_id LIKE '%baz%')`.
+type searchExpression func(object map[string]interface{}) bool
+
+// parseSearch parses the subset of the OCM search language used by the ROSA clients: comparisons of fields with
+// `=`, `!=`, `<>`, `LIKE`, `ILIKE` and `IN`, combined with `AND`, `OR`, `NOT` and parentheses.
+func parseSearch(query string) (searchExpression, error) {
+	tokens, err := tokenizeSearch(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return func(map[string]interface{}) bool { return true }, nil
+	}
+
+	p := &searchParser{tokens: tokens}
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.position < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in search %q", p.tokens[p.position].value, query)
+	}
+	return expression, nil
+}
+
+type searchTokenKind int
+
+const (
+	searchTokenWord searchTokenKind = iota
+	searchTokenString
+	searchTokenSymbol
+)
+
+type searchToken struct {
+	kind  searchTokenKind
+	value string
+}
+
+func tokenizeSearch(query string) ([]searchToken, error) {
+	var tokens []searchToken
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')' || c == ',' || c == '=':
+			tokens = append(tokens, searchToken{kind: searchTokenSymbol, value: string(c)})
+			i++
+		case strings.HasPrefix(query[i:], "!=") || strings.HasPrefix(query[i:], "<>"):
+			tokens = append(tokens, searchToken{kind: searchTokenSymbol, value: "!="})
+			i += 2
+		case c == '\'':
+			var value strings.Builder
+			i++
+			for {
+				if i >= len(query) {
+					return nil, fmt.Errorf("unterminated string in search %q", query)
+				}
+				if query[i] == '\'' {
+					// quotes are escaped by doubling them.
+					if i+1 < len(query) && query[i+1] == '\'' {
+						value.WriteByte('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteByte(query[i])
+				i++
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenString, value: value.String()})
+		default:
+			start := i
+			for i < len(query) && strings.IndexByte(" \t\n(),=!<>'", query[i]) < 0 {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("unexpected %q in search %q", c, query)
+			}
+			tokens = append(tokens, searchToken{kind: searchTokenWord, value: query[start:i]})
+		}
+	}
+	return tokens, nil
+}
+
+type searchParser struct {
+	tokens   []searchToken
+	position int
+}
+
+func (p *searchParser) peek() *searchToken {
+	if p.position >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.position]
+}
+
+func (p *searchParser) next() (searchToken, error) {
+	token := p.peek()
+	if token == nil {
+		return searchToken{}, fmt.Errorf("unexpected end of search")
+	}
+	p.position++
+	return *token, nil
+}
+
+// acceptKeyword consumes the next token if it is the given keyword, case insensitively.
+func (p *searchParser) acceptKeyword(keyword string) bool {
+	token := p.peek()
+	if token == nil || token.kind != searchTokenWord || !strings.EqualFold(token.value, keyword) {
+		return false
+	}
+	p.position++
+	return true
+}
+
+// acceptSymbol consumes the next token if it is the given symbol.
+func (p *searchParser) acceptSymbol(symbol string) bool {
+	token := p.peek()
+	if token == nil || token.kind != searchTokenSymbol || token.value != symbol {
+		return false
+	}
+	p.position++
+	return true
+}
+
+func (p *searchParser) parseOr() (searchExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = func(left, right searchExpression) searchExpression {
+			return func(object map[string]interface{}) bool { return left(object) || right(object) }
+		}(left, right)
+	}
+	return left, nil
+}
+
+func (p *searchParser) parseAnd() (searchExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = func(left, right searchExpression) searchExpression {
+			return func(object map[string]interface{}) bool { return left(object) && right(object) }
+		}(left, right)
+	}
+	return left, nil
+}
+
+func (p *searchParser) parseNot() (searchExpression, error) {
+	if p.acceptKeyword("NOT") {
+		expression, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool { return !expression(object) }, nil
+	}
+
+	if p.acceptSymbol("(") {
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptSymbol(")") {
+			return nil, fmt.Errorf("missing closing parenthesis in search")
+		}
+		return expression, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *searchParser) parseComparison() (searchExpression, error) {
+	field, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if field.kind != searchTokenWord {
+		return nil, fmt.Errorf("expected a field name in search, got %q", field.value)
+	}
+
+	switch {
+	case p.acceptSymbol("="):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			current, found := lookupField(object, field.value)
+			return found && current == value
+		}, nil
+	case p.acceptSymbol("!="):
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			current, found := lookupField(object, field.value)
+			return found && current != value
+		}, nil
+	case p.acceptKeyword("LIKE"), p.acceptKeyword("ILIKE"):
+		caseInsensitive := strings.EqualFold(p.tokens[p.position-1].value, "ILIKE")
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		pattern := likePattern(value, caseInsensitive)
+		return func(object map[string]interface{}) bool {
+			current, found := lookupField(object, field.value)
+			return found && pattern.MatchString(current)
+		}, nil
+	case p.acceptKeyword("IN"):
+		if !p.acceptSymbol("(") {
+			return nil, fmt.Errorf("expected a list of values after IN in search")
+		}
+		var values []string
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if p.acceptSymbol(")") {
+				break
+			}
+			if !p.acceptSymbol(",") {
+				return nil, fmt.Errorf("expected a comma or a closing parenthesis in the list of values of search")
+			}
+		}
+		return func(object map[string]interface{}) bool {
+			current, found := lookupField(object, field.value)
+			if !found {
+				return false
+			}
+			for _, value := range values {
+				if current == value {
+					return true
+				}
+			}
+			return false
+		}, nil
+	}
+
+	return nil, fmt.Errorf("expected an operator after %q in search", field.value)
+}
+
+func (p *searchParser) parseValue() (string, error) {
+	value, err := p.next()
+	if err != nil {
+		return "", err
+	}
+	if value.kind == searchTokenSymbol {
+		return "", fmt.Errorf("expected a value in search, got %q", value.value)
+	}
+	return value.value, nil
+}
+
+// likePattern turns a SQL LIKE pattern into a regular expression.
+func likePattern(pattern string, caseInsensitive bool) *regexp.Regexp {
+	var expression strings.Builder
+	if caseInsensitive {
+		expression.WriteString("(?i)")
+	}
+	expression.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String())
+}
+
+// lookupField returns the value of the dot separated field of the object as a string.
+func lookupField(object map[string]interface{}, field string) (string, bool) {
+	var current interface{} = object
+	for _, name := range strings.Split(field, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = fields[name]; !ok {
+			return "", false
+		}
+	}
+
+	switch value := current.(type) {
+	case string:
+		return value, true
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(value), true
+	}
+	return "", false
+}
@@ -0,0 +1,637 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakeocm provides an in-memory stand-in for the OpenShift Cluster Manager (OCM) API, so that the ROSA
+// controllers can be tested without a live service.
+package fakeocm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api-provider-aws/v2/pkg/cloud/scope"
+)
+
+const (
+	apiPrefix = "/api/clusters_mgmt/v1"
+
+	// DefaultAccountID is the AWS account ID returned by the STS GetCallerIdentity calls served by the Server.
+	DefaultAccountID = "123456789012"
+
+	// DefaultRegion is the signing region of the STS endpoint served by the Server.
+	DefaultRegion = "us-east-1"
+)
+
+// collections are the names of the OCM collections served by the Server. The other path segments are either
+// the IDs of items or sub-resources of an item, such as the control plane of a cluster.
+var collections = map[string]bool{
+	"break_glass_credentials": true,
+	"clusters":                true,
+	"external_auths":          true,
+	"groups":                  true,
+	"identity_providers":      true,
+	"kubelet_configs":         true,
+	"log_forwarders":          true,
+	"node_pools":              true,
+	"tuning_configs":          true,
+	"upgrade_policies":        true,
+	"users":                   true,
+	"versions":                true,
+}
+
+// Server is a fake OCM API storing clusters, node pools, versions, upgrade policies, external auths and the other
+// resources managed by the ROSA controllers in memory. Items are created, read, updated and deleted like in OCM,
+// and list requests support paging and the subset of the search language used by the ROSA clients.
+//
+// The Server also answers the STS GetCallerIdentity calls made by the ROSA scopes, see ServiceEndpoints.
+type Server struct {
+	server    *httptest.Server
+	accountID string
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	lastID      int
+}
+
+// collection holds the items of an OCM collection in creation order.
+type collection struct {
+	ids   []string
+	items map[string]map[string]interface{}
+}
+
+// NewServer starts a new fake OCM server, which must be closed when the test is done.
+func NewServer() *Server {
+	s := &Server{
+		accountID:   DefaultAccountID,
+		collections: map[string]*collection{},
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the URL of the OCM API.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// AccountID returns the AWS account ID of the caller identity returned by STS.
+func (s *Server) AccountID() string {
+	return s.accountID
+}
+
+// ServiceEndpoints returns the service endpoints directing the STS calls of the AWS sessions to the server.
+func (s *Server) ServiceEndpoints() []scope.ServiceEndpoint {
+	return []scope.ServiceEndpoint{
+		{
+			ServiceID:     "sts",
+			URL:           s.server.URL,
+			SigningRegion: DefaultRegion,
+		},
+	}
+}
+
+// CredentialsSecret returns a secret with the OCM credentials pointing to the server, to be referenced by the
+// `credentialsSecretRef` of a ROSAControlPlane.
+func (s *Server) CredentialsSecret(name, namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string][]byte{
+			"ocmToken":  []byte(AccessToken()),
+			"ocmApiUrl": []byte(s.server.URL),
+		},
+	}
+}
+
+// AccessToken returns an unsigned OCM access token which doesn't expire during the tests, so that the OCM
+// clients never try to refresh it.
+func AccessToken() string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims := base64.RawURLEncoding.EncodeToString([]byte(
+		fmt.Sprintf(`{"typ":"Bearer","exp":%d}`, time.Now().Add(24*time.Hour).Unix())))
+	return header + "." + claims + "."
+}
+
+// AddVersion adds an OpenShift version.
+func (s *Server) AddVersion(version *cmv1.Version) (*cmv1.Version, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalVersion(version, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.add(apiPrefix+"/versions", buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalVersion(body)
+}
+
+// AddCluster adds a cluster, as if it was created through the API.
+func (s *Server) AddCluster(cluster *cmv1.Cluster) (*cmv1.Cluster, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalCluster(cluster, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.add(apiPrefix+"/clusters", buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalCluster(body)
+}
+
+// GetCluster returns the cluster with the given ID, or false if it doesn't exist.
+func (s *Server) GetCluster(clusterID string) (*cmv1.Cluster, bool, error) {
+	body, found, err := s.get(clusterPath(clusterID))
+	if !found || err != nil {
+		return nil, found, err
+	}
+	cluster, err := cmv1.UnmarshalCluster(body)
+	return cluster, true, err
+}
+
+// UpdateCluster merges the fields set in the given cluster into the cluster with the given ID, for example to
+// move it to the ready state.
+func (s *Server) UpdateCluster(clusterID string, cluster *cmv1.Cluster) (*cmv1.Cluster, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalCluster(cluster, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.update(clusterPath(clusterID), buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalCluster(body)
+}
+
+// RemoveCluster removes the cluster with the given ID, as if its uninstallation was complete.
+func (s *Server) RemoveCluster(clusterID string) error {
+	return s.remove(clusterPath(clusterID))
+}
+
+// AddNodePool adds a node pool to the cluster with the given ID.
+func (s *Server) AddNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalNodePool(nodePool, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.add(clusterPath(clusterID)+"/node_pools", buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalNodePool(body)
+}
+
+// GetNodePool returns the node pool with the given ID, or false if it doesn't exist.
+func (s *Server) GetNodePool(clusterID, nodePoolID string) (*cmv1.NodePool, bool, error) {
+	body, found, err := s.get(clusterPath(clusterID) + "/node_pools/" + nodePoolID)
+	if !found || err != nil {
+		return nil, found, err
+	}
+	nodePool, err := cmv1.UnmarshalNodePool(body)
+	return nodePool, true, err
+}
+
+// UpdateNodePool merges the fields set in the given node pool into the node pool with the given ID, for example
+// to update its status.
+func (s *Server) UpdateNodePool(clusterID, nodePoolID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalNodePool(nodePool, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.update(clusterPath(clusterID)+"/node_pools/"+nodePoolID, buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalNodePool(body)
+}
+
+// AddControlPlaneUpgradePolicy adds an upgrade policy to the control plane of the cluster with the given ID.
+func (s *Server) AddControlPlaneUpgradePolicy(clusterID string, upgradePolicy *cmv1.ControlPlaneUpgradePolicy) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	var buffer bytes.Buffer
+	if err := cmv1.MarshalControlPlaneUpgradePolicy(upgradePolicy, &buffer); err != nil {
+		return nil, err
+	}
+	body, err := s.add(clusterPath(clusterID)+"/control_plane/upgrade_policies", buffer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalControlPlaneUpgradePolicy(body)
+}
+
+// ControlPlaneUpgradePolicies returns the upgrade policies of the control plane of the cluster with the given ID.
+func (s *Server) ControlPlaneUpgradePolicies(clusterID string) ([]*cmv1.ControlPlaneUpgradePolicy, error) {
+	return cmv1.UnmarshalControlPlaneUpgradePolicyList(s.list(clusterPath(clusterID) + "/control_plane/upgrade_policies"))
+}
+
+// NodePoolUpgradePolicies returns the upgrade policies of the node pool with the given ID.
+func (s *Server) NodePoolUpgradePolicies(clusterID, nodePoolID string) ([]*cmv1.NodePoolUpgradePolicy, error) {
+	return cmv1.UnmarshalNodePoolUpgradePolicyList(s.list(clusterPath(clusterID) + "/node_pools/" + nodePoolID + "/upgrade_policies"))
+}
+
+// ExternalAuths returns the external authentication providers of the cluster with the given ID.
+func (s *Server) ExternalAuths(clusterID string) ([]*cmv1.ExternalAuth, error) {
+	return cmv1.UnmarshalExternalAuthList(s.list(clusterPath(clusterID) + "/external_auth_config/external_auths"))
+}
+
+func clusterPath(clusterID string) string {
+	return apiPrefix + "/clusters/" + clusterID
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && r.URL.Path == "/" {
+		s.serveSTS(w, r)
+		return
+	}
+
+	collectionPath, id, ok := splitPath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "path %q is not supported by the fake OCM server", r.URL.Path)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body: %v", err)
+		return
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		s.serveList(w, r, collectionPath)
+	case id == "" && r.Method == http.MethodPost:
+		if r.URL.Query().Get("dryRun") == "true" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		item, err := s.add(collectionPath, body)
+		if err != nil {
+			writeError(w, statusForError(err), "%v", err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, item)
+	case id != "" && r.Method == http.MethodGet:
+		item, found, err := s.get(collectionPath + "/" + id)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if !found {
+			writeError(w, http.StatusNotFound, "%s not found", r.URL.Path)
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	case id != "" && r.Method == http.MethodPatch:
+		item, err := s.update(collectionPath+"/"+id, body)
+		if err != nil {
+			writeError(w, statusForError(err), "%v", err)
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	case id != "" && r.Method == http.MethodDelete:
+		if err := s.delete(collectionPath, id); err != nil {
+			writeError(w, statusForError(err), "%v", err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not supported on %s", r.Method, r.URL.Path)
+	}
+}
+
+// splitPath splits the path of an OCM API request into the path of the collection and the ID of the item, which
+// is empty for requests on the collection.
+func splitPath(path string) (string, string, bool) {
+	if !strings.HasPrefix(path, apiPrefix+"/") {
+		return "", "", false
+	}
+	segments := strings.Split(strings.TrimSuffix(strings.TrimPrefix(path, apiPrefix+"/"), "/"), "/")
+
+	last := len(segments) - 1
+	if collections[segments[last]] {
+		return path, "", true
+	}
+	if last > 0 && collections[segments[last-1]] {
+		return apiPrefix + "/" + strings.Join(segments[:last], "/"), segments[last], true
+	}
+	return "", "", false
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, collectionPath string) {
+	query := r.URL.Query()
+	search, err := parseSearch(query.Get("search"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	page, size := 1, 100
+	if value := query.Get("page"); value != "" {
+		if page, err = strconv.Atoi(value); err != nil || page < 1 {
+			writeError(w, http.StatusBadRequest, "invalid page %q", value)
+			return
+		}
+	}
+	if value := query.Get("size"); value != "" {
+		if size, err = strconv.Atoi(value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid size %q", value)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	var matches []map[string]interface{}
+	if c, ok := s.collections[collectionPath]; ok {
+		for _, id := range c.ids {
+			if search(c.items[id]) {
+				matches = append(matches, c.items[id])
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	items := []map[string]interface{}{}
+	if size < 0 {
+		// a negative size returns all the items.
+		items = append(items, matches...)
+	} else if start := (page - 1) * size; start < len(matches) {
+		end := start + size
+		if end > len(matches) {
+			end = len(matches)
+		}
+		items = append(items, matches[start:end]...)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"page":  page,
+		"size":  len(items),
+		"total": len(matches),
+		"items": items,
+	})
+}
+
+// serveSTS answers the STS GetCallerIdentity calls, which the ROSA scopes make to identify the cluster creator.
+func (s *Server) serveSTS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("Action") != "GetCallerIdentity" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	fmt.Fprintf(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <GetCallerIdentityResult>
+    <Arn>arn:aws:iam::%[1]s:user/capa</Arn>
+    <UserId>AIDACAPA</UserId>
+    <Account>%[1]s</Account>
+  </GetCallerIdentityResult>
+  <ResponseMetadata>
+    <RequestId>00000000-0000-0000-0000-000000000000</RequestId>
+  </ResponseMetadata>
+</GetCallerIdentityResponse>`, s.accountID)
+}
+
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return e.message
+}
+
+func statusForError(err error) int {
+	if statusErr, ok := err.(*statusError); ok {
+		return statusErr.status
+	}
+	return http.StatusInternalServerError
+}
+
+// add stores a new item in the collection, generating its ID unless it is set, and returns the stored item.
+func (s *Server) add(collectionPath string, body []byte) ([]byte, error) {
+	item := map[string]interface{}{}
+	if err := json.Unmarshal(body, &item); err != nil {
+		return nil, &statusError{status: http.StatusBadRequest, message: fmt.Sprintf("invalid body: %v", err)}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		c = &collection{items: map[string]map[string]interface{}{}}
+		s.collections[collectionPath] = c
+	}
+
+	id, _ := item["id"].(string)
+	if id == "" {
+		s.lastID++
+		id = fmt.Sprintf("%032x", s.lastID)
+		item["id"] = id
+	}
+	if _, exists := c.items[id]; exists {
+		return nil, &statusError{status: http.StatusConflict, message: fmt.Sprintf("%s/%s already exists", collectionPath, id)}
+	}
+	item["href"] = collectionPath + "/" + id
+	setDefaults(collectionPath, item)
+
+	c.ids = append(c.ids, id)
+	c.items[id] = item
+	return json.Marshal(item)
+}
+
+// setDefaults sets the fields OCM sets on new items.
+func setDefaults(collectionPath string, item map[string]interface{}) {
+	switch collectionPath[strings.LastIndex(collectionPath, "/")+1:] {
+	case "clusters":
+		status, _ := item["status"].(map[string]interface{})
+		if status == nil {
+			status = map[string]interface{}{}
+			item["status"] = status
+		}
+		if _, ok := status["state"]; !ok {
+			status["state"] = string(cmv1.ClusterStateInstalling)
+		}
+	case "upgrade_policies":
+		if _, ok := item["state"]; !ok {
+			item["state"] = map[string]interface{}{
+				"value": string(cmv1.UpgradePolicyStateValueScheduled),
+			}
+		}
+	case "break_glass_credentials":
+		// break glass credentials are issued right away.
+		item["status"] = string(cmv1.BreakGlassCredentialStatusIssued)
+		item["kubeconfig"] = fmt.Sprintf(breakGlassKubeconfig, item["username"])
+	}
+}
+
+// breakGlassKubeconfig is the kubeconfig of the issued break glass credentials, for the user name of the credential.
+const breakGlassKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.example.com:443
+contexts:
+- name: %[1]s
+  context:
+    cluster: cluster
+    user: %[1]s
+current-context: %[1]s
+users:
+- name: %[1]s
+  user:
+    token: fake
+`
+
+func (s *Server) get(path string) ([]byte, bool, error) {
+	collectionPath, id, _ := splitPath(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collectionPath]
+	if !ok || c.items[id] == nil {
+		return nil, false, nil
+	}
+	body, err := json.Marshal(c.items[id])
+	return body, true, err
+}
+
+// list returns the JSON array of the items of the collection.
+func (s *Server) list(collectionPath string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := []map[string]interface{}{}
+	if c, ok := s.collections[collectionPath]; ok {
+		for _, id := range c.ids {
+			items = append(items, c.items[id])
+		}
+	}
+	body, _ := json.Marshal(items)
+	return body
+}
+
+// update merges the fields of the body into the item, like OCM does for PATCH requests.
+func (s *Server) update(path string, body []byte) ([]byte, error) {
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return nil, &statusError{status: http.StatusBadRequest, message: fmt.Sprintf("invalid body: %v", err)}
+	}
+	// the identity of an item can't be changed.
+	delete(patch, "id")
+	delete(patch, "href")
+	delete(patch, "kind")
+
+	collectionPath, id, _ := splitPath(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collectionPath]
+	if !ok || c.items[id] == nil {
+		return nil, &statusError{status: http.StatusNotFound, message: fmt.Sprintf("%s not found", path)}
+	}
+	merge(c.items[id], patch)
+	return json.Marshal(c.items[id])
+}
+
+func merge(item, patch map[string]interface{}) {
+	for key, value := range patch {
+		current, currentIsObject := item[key].(map[string]interface{})
+		patchValue, patchIsObject := value.(map[string]interface{})
+		if currentIsObject && patchIsObject {
+			merge(current, patchValue)
+			continue
+		}
+		item[key] = value
+	}
+}
+
+// delete handles DELETE requests. Clusters are moved to the uninstalling state instead of being removed, see
+// RemoveCluster.
+func (s *Server) delete(collectionPath, id string) error {
+	if collectionPath == apiPrefix+"/clusters" {
+		_, err := s.update(collectionPath+"/"+id, []byte(fmt.Sprintf(`{"status":{"state":%q}}`, cmv1.ClusterStateUninstalling)))
+		return err
+	}
+	return s.remove(collectionPath + "/" + id)
+}
+
+// remove removes the item.
+func (s *Server) remove(path string) error {
+	collectionPath, id, _ := splitPath(path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.collections[collectionPath]
+	if !ok || c.items[id] == nil {
+		return &statusError{status: http.StatusNotFound, message: fmt.Sprintf("%s not found", path)}
+	}
+	delete(c.items, id)
+	for i := range c.ids {
+		if c.ids[i] == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	data, ok := body.([]byte)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			writeError(w, http.StatusInternalServerError, "failed to marshal response: %v", err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// writeError writes an error in the format of the OCM API.
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"kind":   "Error",
+		"id":     strconv.Itoa(status),
+		"href":   fmt.Sprintf("%s/errors/%d", apiPrefix, status),
+		"code":   fmt.Sprintf("CLUSTERS-MGMT-%d", status),
+		"reason": fmt.Sprintf(format, args...),
+	})
+}
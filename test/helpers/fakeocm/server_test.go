@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakeocm
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	rosaaws "github.com/openshift/rosa/pkg/aws"
+	ocmcfg "github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/sirupsen/logrus"
+)
+
+func newOCMClient(g *WithT, server *Server) *ocm.Client {
+	client, err := ocm.NewClient().Logger(logrus.New()).Config(&ocmcfg.Config{
+		AccessToken: AccessToken(),
+		URL:         server.URL(),
+	}).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	return client
+}
+
+func TestSearch(t *testing.T) {
+	object := map[string]interface{}{
+		"id":   "2a3b4c",
+		"name": "capa",
+		"product": map[string]interface{}{
+			"id": "rosa",
+		},
+		"properties": map[string]interface{}{
+			"rosa_creator_arn": "arn:aws:iam::123456789012:user/capa",
+		},
+		"replicas": float64(3),
+	}
+
+	tests := []struct {
+		search string
+		match  bool
+	}{
+		{search: "", match: true},
+		{search: "name = 'capa'", match: true},
+		{search: "name='other'", match: false},
+		{search: "name != 'other'", match: true},
+		{search: "missing = 'capa'", match: false},
+		{search: "replicas = 3", match: true},
+		{search: "product.id = 'rosa' AND (id = 'capa' OR name = 'capa')", match: true},
+		{search: "product.id = 'rosa' AND (id = 'other' OR name = 'other')", match: false},
+		{search: "properties.rosa_creator_arn LIKE '%:123456789012:%'", match: true},
+		{search: "properties.rosa_creator_arn LIKE '%:210987654321:%'", match: false},
+		{search: "name ILIKE 'CA_A'", match: true},
+		{search: "name in ('foo', 'capa')", match: true},
+		{search: "NOT name = 'capa'", match: false},
+		{search: "name = 'it''s'", match: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			g := NewWithT(t)
+
+			search, err := parseSearch(tt.search)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(search(object)).To(Equal(tt.match))
+		})
+	}
+
+	for _, invalid := range []string{"name =", "name 'capa'", "(name = 'capa'", "name = 'capa", "name IN 'capa'"} {
+		_, err := parseSearch(invalid)
+		NewWithT(t).Expect(err).To(HaveOccurred(), invalid)
+	}
+}
+
+func TestClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := newOCMClient(g, server)
+	defer client.Close()
+
+	version, err := cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5").ChannelGroup("stable").
+		HostedControlPlaneEnabled(true).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = server.AddVersion(version)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	valid, err := client.ValidateHypershiftVersion("4.14.5", "stable")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(valid).To(BeTrue())
+	_, err = client.ValidateHypershiftVersion("4.15.0", "stable")
+	g.Expect(err).To(MatchError(ContainSubstring("not found")))
+
+	creator := &rosaaws.Creator{
+		ARN:       "arn:aws:iam::" + server.AccountID() + ":user/capa",
+		AccountID: server.AccountID(),
+	}
+	_, err = client.GetCluster("capa", creator)
+	g.Expect(err).To(HaveOccurred())
+
+	cluster, err := cmv1.NewCluster().Name("capa").
+		Product(cmv1.NewProduct().ID("rosa")).
+		Properties(map[string]string{"rosa_creator_arn": creator.ARN}).
+		Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	cluster, err = server.AddCluster(cluster)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cluster.ID()).ToNot(BeEmpty())
+	g.Expect(cluster.Status().State()).To(Equal(cmv1.ClusterStateInstalling))
+
+	found, err := client.GetCluster("capa", creator)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found.ID()).To(Equal(cluster.ID()))
+
+	_, err = client.GetCluster("capa", &rosaaws.Creator{ARN: "arn:aws:iam::210987654321:user/capa", AccountID: "210987654321"})
+	g.Expect(err).To(HaveOccurred())
+
+	ready, err := cmv1.NewCluster().Status(cmv1.NewClusterStatus().State(cmv1.ClusterStateReady)).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = server.UpdateCluster(cluster.ID(), ready)
+	g.Expect(err).ToNot(HaveOccurred())
+	found, err = client.GetCluster(cluster.ID(), creator)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found.Status().State()).To(Equal(cmv1.ClusterStateReady))
+	g.Expect(found.Name()).To(Equal("capa"))
+
+	_, err = client.DeleteCluster(cluster.ID(), false, creator)
+	g.Expect(err).ToNot(HaveOccurred())
+	found, err = client.GetCluster(cluster.ID(), creator)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found.Status().State()).To(Equal(cmv1.ClusterStateUninstalling))
+
+	g.Expect(server.RemoveCluster(cluster.ID())).To(Succeed())
+	_, exists, err := server.GetCluster(cluster.ID())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+}
+
+func TestNodePools(t *testing.T) {
+	g := NewWithT(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := newOCMClient(g, server)
+	defer client.Close()
+
+	_, exists, err := client.GetNodePool("2a3b4c", "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+
+	nodePool, err := cmv1.NewNodePool().ID("workers").Replicas(2).
+		AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge")).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = server.AddNodePool("2a3b4c", nodePool)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	nodePool, err = cmv1.NewNodePool().ID("workers").Replicas(3).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = client.UpdateNodePool("2a3b4c", nodePool)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	nodePool, exists, err = client.GetNodePool("2a3b4c", "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeTrue())
+	g.Expect(nodePool.Replicas()).To(Equal(3))
+	g.Expect(nodePool.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+
+	upgradePolicy, err := client.BuildNodeUpgradePolicy("4.14.6", "workers", ocm.UpgradeScheduling{NextRun: time.Now()})
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = client.ScheduleNodePoolUpgrade("2a3b4c", "workers", upgradePolicy)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, scheduledUpgrade, err := client.GetHypershiftNodePoolUpgrade("2a3b4c", "capa", "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(scheduledUpgrade.Version()).To(Equal("4.14.6"))
+	g.Expect(scheduledUpgrade.State().Value()).To(Equal(cmv1.UpgradePolicyStateValueScheduled))
+
+	g.Expect(client.DeleteNodePool("2a3b4c", "workers")).To(Succeed())
+	_, exists, err = client.GetNodePool("2a3b4c", "workers")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(exists).To(BeFalse())
+	g.Expect(client.DeleteNodePool("2a3b4c", "workers")).ToNot(Succeed())
+}
+
+func TestControlPlaneUpgradePolicies(t *testing.T) {
+	g := NewWithT(t)
+
+	server := NewServer()
+	defer server.Close()
+	client := newOCMClient(g, server)
+	defer client.Close()
+
+	upgradePolicy, err := cmv1.NewControlPlaneUpgradePolicy().UpgradeType(cmv1.UpgradeTypeControlPlane).Version("4.14.6").Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	for i := 0; i < 3; i++ {
+		_, err := server.AddControlPlaneUpgradePolicy("2a3b4c", upgradePolicy)
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	upgradePolicies, err := client.GetControlPlaneUpgradePolicies("2a3b4c")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(upgradePolicies).To(HaveLen(3))
+
+	_, err = client.CancelControlPlaneUpgrade("2a3b4c", upgradePolicies[0].ID())
+	g.Expect(err).ToNot(HaveOccurred())
+	upgradePolicies, err = server.ControlPlaneUpgradePolicies("2a3b4c")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(upgradePolicies).To(HaveLen(2))
+}